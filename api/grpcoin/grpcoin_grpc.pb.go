@@ -19,13 +19,22 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TickerInfoClient interface {
 	// Watch returns real-time quotes of the ticker.
-	// The only supported tickers are "BTC", "ETH", "DOGE", "DOT".
+	// See ListSupportedCurrencies for the supported tickers.
 	//
 	// This stream terminates after 15 minutes, so expect being
 	// abruptly disconnected and need to reconnect.
 	//
 	// No authentication required.
 	Watch(ctx context.Context, in *TickerWatchRequest, opts ...grpc.CallOption) (TickerInfo_WatchClient, error)
+	// WatchMany returns real-time quotes of multiple tickers over a
+	// single stream. Each quote has its currency set. If no currencies
+	// are specified, quotes of all supported tickers are returned.
+	//
+	// Each ticker is throttled independently, similar to Watch.
+	// This stream also terminates after 15 minutes.
+	//
+	// No authentication required.
+	WatchMany(ctx context.Context, in *TickerWatchManyRequest, opts ...grpc.CallOption) (TickerInfo_WatchManyClient, error)
 }
 
 type tickerInfoClient struct {
@@ -68,18 +77,59 @@ func (x *tickerInfoWatchClient) Recv() (*Quote, error) {
 	return m, nil
 }
 
+func (c *tickerInfoClient) WatchMany(ctx context.Context, in *TickerWatchManyRequest, opts ...grpc.CallOption) (TickerInfo_WatchManyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TickerInfo_ServiceDesc.Streams[1], "/grpcoin.TickerInfo/WatchMany", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tickerInfoWatchManyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TickerInfo_WatchManyClient interface {
+	Recv() (*Quote, error)
+	grpc.ClientStream
+}
+
+type tickerInfoWatchManyClient struct {
+	grpc.ClientStream
+}
+
+func (x *tickerInfoWatchManyClient) Recv() (*Quote, error) {
+	m := new(Quote)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TickerInfoServer is the server API for TickerInfo service.
 // All implementations must embed UnimplementedTickerInfoServer
 // for forward compatibility
 type TickerInfoServer interface {
 	// Watch returns real-time quotes of the ticker.
-	// The only supported tickers are "BTC", "ETH", "DOGE", "DOT".
+	// See ListSupportedCurrencies for the supported tickers.
 	//
 	// This stream terminates after 15 minutes, so expect being
 	// abruptly disconnected and need to reconnect.
 	//
 	// No authentication required.
 	Watch(*TickerWatchRequest, TickerInfo_WatchServer) error
+	// WatchMany returns real-time quotes of multiple tickers over a
+	// single stream. Each quote has its currency set. If no currencies
+	// are specified, quotes of all supported tickers are returned.
+	//
+	// Each ticker is throttled independently, similar to Watch.
+	// This stream also terminates after 15 minutes.
+	//
+	// No authentication required.
+	WatchMany(*TickerWatchManyRequest, TickerInfo_WatchManyServer) error
 	mustEmbedUnimplementedTickerInfoServer()
 }
 
@@ -90,6 +140,9 @@ type UnimplementedTickerInfoServer struct {
 func (UnimplementedTickerInfoServer) Watch(*TickerWatchRequest, TickerInfo_WatchServer) error {
 	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
 }
+func (UnimplementedTickerInfoServer) WatchMany(*TickerWatchManyRequest, TickerInfo_WatchManyServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchMany not implemented")
+}
 func (UnimplementedTickerInfoServer) mustEmbedUnimplementedTickerInfoServer() {}
 
 // UnsafeTickerInfoServer may be embedded to opt out of forward compatibility for this service.
@@ -124,6 +177,27 @@ func (x *tickerInfoWatchServer) Send(m *Quote) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TickerInfo_WatchMany_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TickerWatchManyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TickerInfoServer).WatchMany(m, &tickerInfoWatchManyServer{stream})
+}
+
+type TickerInfo_WatchManyServer interface {
+	Send(*Quote) error
+	grpc.ServerStream
+}
+
+type tickerInfoWatchManyServer struct {
+	grpc.ServerStream
+}
+
+func (x *tickerInfoWatchManyServer) Send(m *Quote) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // TickerInfo_ServiceDesc is the grpc.ServiceDesc for TickerInfo service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -137,6 +211,11 @@ var TickerInfo_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _TickerInfo_Watch_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchMany",
+			Handler:       _TickerInfo_WatchMany_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "grpcoin.proto",
 }
@@ -151,8 +230,44 @@ type PaperTradeClient interface {
 	// All trades are executed immediately with the real-time market
 	// price provided on TickerInfo.Watch endpoint.
 	Trade(ctx context.Context, in *TradeRequest, opts ...grpc.CallOption) (*TradeResponse, error)
-	// Returns symbols supported by Trade or Watch methods.
+	// Returns currencies supported by Trade or Watch methods, with their
+	// names, minimum trade sizes and price precisions.
+	//
+	// The supported currencies can change while the servers are running.
 	ListSupportedCurrencies(ctx context.Context, in *ListSupportedCurrenciesRequest, opts ...grpc.CallOption) (*ListSupportedCurrenciesResponse, error)
+	// Places an order that is stored on the server and executed when the
+	// real-time market price reaches the price specified on the order.
+	//
+	// Orders that can be executed at the time they are placed are filled
+	// immediately at the real-time market price.
+	//
+	// LIMIT orders can have protective exit orders attached ("bracket"),
+	// which are placed once the order is filled.
+	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
+	// Returns authenticated user's orders, most recent first.
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	// Returns an order of the authenticated user.
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
+	// Cancels an open order. Fails with FAILED_PRECONDITION if the order
+	// is not open anymore (e.g. it is already filled).
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	// Returns authenticated user's past trades, most recent first.
+	TradeHistory(ctx context.Context, in *TradeHistoryRequest, opts ...grpc.CallOption) (*TradeHistoryResponse, error)
+	// Returns the value of authenticated user's portfolio over time,
+	// summarized in intervals of the requested resolution. The value is
+	// recorded hourly, and rolled up into daily and weekly values (kept
+	// indefinitely) after 31 days, so the intervals older than that have
+	// the daily values of the last year and the weekly values before it.
+	// The whole history is returned if start_time is not set.
+	PortfolioHistory(ctx context.Context, in *PortfolioHistoryRequest, opts ...grpc.CallOption) (*PortfolioHistoryResponse, error)
+	// Streams authenticated user's portfolio valued at the real-time market
+	// prices. An update is sent when the stream starts, whenever the
+	// portfolio changes (e.g. after a trade) and periodically (every 5
+	// seconds) with the latest prices.
+	//
+	// This stream terminates after 15 minutes, so expect being
+	// disconnected and reconnect.
+	WatchPortfolio(ctx context.Context, in *WatchPortfolioRequest, opts ...grpc.CallOption) (PaperTrade_WatchPortfolioClient, error)
 }
 
 type paperTradeClient struct {
@@ -190,6 +305,92 @@ func (c *paperTradeClient) ListSupportedCurrencies(ctx context.Context, in *List
 	return out, nil
 }
 
+func (c *paperTradeClient) PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error) {
+	out := new(PlaceOrderResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.PaperTrade/PlaceOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperTradeClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.PaperTrade/ListOrders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperTradeClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error) {
+	out := new(GetOrderResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.PaperTrade/GetOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperTradeClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.PaperTrade/CancelOrder", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperTradeClient) TradeHistory(ctx context.Context, in *TradeHistoryRequest, opts ...grpc.CallOption) (*TradeHistoryResponse, error) {
+	out := new(TradeHistoryResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.PaperTrade/TradeHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperTradeClient) PortfolioHistory(ctx context.Context, in *PortfolioHistoryRequest, opts ...grpc.CallOption) (*PortfolioHistoryResponse, error) {
+	out := new(PortfolioHistoryResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.PaperTrade/PortfolioHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperTradeClient) WatchPortfolio(ctx context.Context, in *WatchPortfolioRequest, opts ...grpc.CallOption) (PaperTrade_WatchPortfolioClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PaperTrade_ServiceDesc.Streams[0], "/grpcoin.PaperTrade/WatchPortfolio", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &paperTradeWatchPortfolioClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PaperTrade_WatchPortfolioClient interface {
+	Recv() (*PortfolioUpdate, error)
+	grpc.ClientStream
+}
+
+type paperTradeWatchPortfolioClient struct {
+	grpc.ClientStream
+}
+
+func (x *paperTradeWatchPortfolioClient) Recv() (*PortfolioUpdate, error) {
+	m := new(PortfolioUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // PaperTradeServer is the server API for PaperTrade service.
 // All implementations must embed UnimplementedPaperTradeServer
 // for forward compatibility
@@ -200,8 +401,44 @@ type PaperTradeServer interface {
 	// All trades are executed immediately with the real-time market
 	// price provided on TickerInfo.Watch endpoint.
 	Trade(context.Context, *TradeRequest) (*TradeResponse, error)
-	// Returns symbols supported by Trade or Watch methods.
+	// Returns currencies supported by Trade or Watch methods, with their
+	// names, minimum trade sizes and price precisions.
+	//
+	// The supported currencies can change while the servers are running.
 	ListSupportedCurrencies(context.Context, *ListSupportedCurrenciesRequest) (*ListSupportedCurrenciesResponse, error)
+	// Places an order that is stored on the server and executed when the
+	// real-time market price reaches the price specified on the order.
+	//
+	// Orders that can be executed at the time they are placed are filled
+	// immediately at the real-time market price.
+	//
+	// LIMIT orders can have protective exit orders attached ("bracket"),
+	// which are placed once the order is filled.
+	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
+	// Returns authenticated user's orders, most recent first.
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	// Returns an order of the authenticated user.
+	GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error)
+	// Cancels an open order. Fails with FAILED_PRECONDITION if the order
+	// is not open anymore (e.g. it is already filled).
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	// Returns authenticated user's past trades, most recent first.
+	TradeHistory(context.Context, *TradeHistoryRequest) (*TradeHistoryResponse, error)
+	// Returns the value of authenticated user's portfolio over time,
+	// summarized in intervals of the requested resolution. The value is
+	// recorded hourly, and rolled up into daily and weekly values (kept
+	// indefinitely) after 31 days, so the intervals older than that have
+	// the daily values of the last year and the weekly values before it.
+	// The whole history is returned if start_time is not set.
+	PortfolioHistory(context.Context, *PortfolioHistoryRequest) (*PortfolioHistoryResponse, error)
+	// Streams authenticated user's portfolio valued at the real-time market
+	// prices. An update is sent when the stream starts, whenever the
+	// portfolio changes (e.g. after a trade) and periodically (every 5
+	// seconds) with the latest prices.
+	//
+	// This stream terminates after 15 minutes, so expect being
+	// disconnected and reconnect.
+	WatchPortfolio(*WatchPortfolioRequest, PaperTrade_WatchPortfolioServer) error
 	mustEmbedUnimplementedPaperTradeServer()
 }
 
@@ -218,6 +455,27 @@ func (UnimplementedPaperTradeServer) Trade(context.Context, *TradeRequest) (*Tra
 func (UnimplementedPaperTradeServer) ListSupportedCurrencies(context.Context, *ListSupportedCurrenciesRequest) (*ListSupportedCurrenciesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSupportedCurrencies not implemented")
 }
+func (UnimplementedPaperTradeServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
+}
+func (UnimplementedPaperTradeServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedPaperTradeServer) GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedPaperTradeServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedPaperTradeServer) TradeHistory(context.Context, *TradeHistoryRequest) (*TradeHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TradeHistory not implemented")
+}
+func (UnimplementedPaperTradeServer) PortfolioHistory(context.Context, *PortfolioHistoryRequest) (*PortfolioHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PortfolioHistory not implemented")
+}
+func (UnimplementedPaperTradeServer) WatchPortfolio(*WatchPortfolioRequest, PaperTrade_WatchPortfolioServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPortfolio not implemented")
+}
 func (UnimplementedPaperTradeServer) mustEmbedUnimplementedPaperTradeServer() {}
 
 // UnsafePaperTradeServer may be embedded to opt out of forward compatibility for this service.
@@ -285,6 +543,135 @@ func _PaperTrade_ListSupportedCurrencies_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaperTrade_PlaceOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaperTradeServer).PlaceOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.PaperTrade/PlaceOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaperTradeServer).PlaceOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaperTrade_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaperTradeServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.PaperTrade/ListOrders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaperTradeServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaperTrade_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaperTradeServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.PaperTrade/GetOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaperTradeServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaperTrade_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaperTradeServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.PaperTrade/CancelOrder",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaperTradeServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaperTrade_TradeHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TradeHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaperTradeServer).TradeHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.PaperTrade/TradeHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaperTradeServer).TradeHistory(ctx, req.(*TradeHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaperTrade_PortfolioHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortfolioHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaperTradeServer).PortfolioHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.PaperTrade/PortfolioHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaperTradeServer).PortfolioHistory(ctx, req.(*PortfolioHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaperTrade_WatchPortfolio_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPortfolioRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PaperTradeServer).WatchPortfolio(m, &paperTradeWatchPortfolioServer{stream})
+}
+
+type PaperTrade_WatchPortfolioServer interface {
+	Send(*PortfolioUpdate) error
+	grpc.ServerStream
+}
+
+type paperTradeWatchPortfolioServer struct {
+	grpc.ServerStream
+}
+
+func (x *paperTradeWatchPortfolioServer) Send(m *PortfolioUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // PaperTrade_ServiceDesc is the grpc.ServiceDesc for PaperTrade service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -304,11 +691,417 @@ var PaperTrade_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSupportedCurrencies",
 			Handler:    _PaperTrade_ListSupportedCurrencies_Handler,
 		},
+		{
+			MethodName: "PlaceOrder",
+			Handler:    _PaperTrade_PlaceOrder_Handler,
+		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _PaperTrade_ListOrders_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _PaperTrade_GetOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _PaperTrade_CancelOrder_Handler,
+		},
+		{
+			MethodName: "TradeHistory",
+			Handler:    _PaperTrade_TradeHistory_Handler,
+		},
+		{
+			MethodName: "PortfolioHistory",
+			Handler:    _PaperTrade_PortfolioHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPortfolio",
+			Handler:       _PaperTrade_WatchPortfolio_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "grpcoin.proto",
+}
+
+// LeaderboardClient is the client API for Leaderboard service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LeaderboardClient interface {
+	// Returns users ranked by their portfolio value, highest first.
+	//
+	// Rankings are computed periodically (hourly), so they do not reflect
+	// the real-time portfolio values.
+	ListRankings(ctx context.Context, in *ListRankingsRequest, opts ...grpc.CallOption) (*ListRankingsResponse, error)
+	// Returns authenticated user's rank. Fails with NOT_FOUND if the user
+	// is not ranked yet.
+	MyRank(ctx context.Context, in *MyRankRequest, opts ...grpc.CallOption) (*MyRankResponse, error)
+}
+
+type leaderboardClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardClient(cc grpc.ClientConnInterface) LeaderboardClient {
+	return &leaderboardClient{cc}
+}
+
+func (c *leaderboardClient) ListRankings(ctx context.Context, in *ListRankingsRequest, opts ...grpc.CallOption) (*ListRankingsResponse, error) {
+	out := new(ListRankingsResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.Leaderboard/ListRankings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) MyRank(ctx context.Context, in *MyRankRequest, opts ...grpc.CallOption) (*MyRankResponse, error) {
+	out := new(MyRankResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.Leaderboard/MyRank", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LeaderboardServer is the server API for Leaderboard service.
+// All implementations must embed UnimplementedLeaderboardServer
+// for forward compatibility
+type LeaderboardServer interface {
+	// Returns users ranked by their portfolio value, highest first.
+	//
+	// Rankings are computed periodically (hourly), so they do not reflect
+	// the real-time portfolio values.
+	ListRankings(context.Context, *ListRankingsRequest) (*ListRankingsResponse, error)
+	// Returns authenticated user's rank. Fails with NOT_FOUND if the user
+	// is not ranked yet.
+	MyRank(context.Context, *MyRankRequest) (*MyRankResponse, error)
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+// UnimplementedLeaderboardServer must be embedded to have forward compatible implementations.
+type UnimplementedLeaderboardServer struct {
+}
+
+func (UnimplementedLeaderboardServer) ListRankings(context.Context, *ListRankingsRequest) (*ListRankingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRankings not implemented")
+}
+func (UnimplementedLeaderboardServer) MyRank(context.Context, *MyRankRequest) (*MyRankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MyRank not implemented")
+}
+func (UnimplementedLeaderboardServer) mustEmbedUnimplementedLeaderboardServer() {}
+
+// UnsafeLeaderboardServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServer will
+// result in compilation errors.
+type UnsafeLeaderboardServer interface {
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+func RegisterLeaderboardServer(s grpc.ServiceRegistrar, srv LeaderboardServer) {
+	s.RegisterService(&Leaderboard_ServiceDesc, srv)
+}
+
+func _Leaderboard_ListRankings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRankingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).ListRankings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.Leaderboard/ListRankings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).ListRankings(ctx, req.(*ListRankingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_MyRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MyRankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).MyRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.Leaderboard/MyRank",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).MyRank(ctx, req.(*MyRankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Leaderboard_ServiceDesc is the grpc.ServiceDesc for Leaderboard service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Leaderboard_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcoin.Leaderboard",
+	HandlerType: (*LeaderboardServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRankings",
+			Handler:    _Leaderboard_ListRankings_Handler,
+		},
+		{
+			MethodName: "MyRank",
+			Handler:    _Leaderboard_MyRank_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "grpcoin.proto",
 }
 
+// AlertsClient is the client API for Alerts service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AlertsClient interface {
+	// Creates a price alert on a currency, which fires once when its
+	// condition is met. Fired alerts are delivered on StreamNotifications
+	// and kept (with their fired_at time) until deleted.
+	CreateAlert(ctx context.Context, in *CreateAlertRequest, opts ...grpc.CallOption) (*CreateAlertResponse, error)
+	// Returns authenticated user's alerts, including the fired ones.
+	ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error)
+	// Deletes an alert. Fails with NOT_FOUND if it does not exist.
+	DeleteAlert(ctx context.Context, in *DeleteAlertRequest, opts ...grpc.CallOption) (*DeleteAlertResponse, error)
+	// Streams the user's alerts as they fire. Alerts fired while the user
+	// is not connected are not delivered (use ListAlerts to find them).
+	//
+	// This stream terminates after 15 minutes, so expect being
+	// abruptly disconnected and need to reconnect.
+	StreamNotifications(ctx context.Context, in *StreamNotificationsRequest, opts ...grpc.CallOption) (Alerts_StreamNotificationsClient, error)
+}
+
+type alertsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAlertsClient(cc grpc.ClientConnInterface) AlertsClient {
+	return &alertsClient{cc}
+}
+
+func (c *alertsClient) CreateAlert(ctx context.Context, in *CreateAlertRequest, opts ...grpc.CallOption) (*CreateAlertResponse, error) {
+	out := new(CreateAlertResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.Alerts/CreateAlert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertsClient) ListAlerts(ctx context.Context, in *ListAlertsRequest, opts ...grpc.CallOption) (*ListAlertsResponse, error) {
+	out := new(ListAlertsResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.Alerts/ListAlerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertsClient) DeleteAlert(ctx context.Context, in *DeleteAlertRequest, opts ...grpc.CallOption) (*DeleteAlertResponse, error) {
+	out := new(DeleteAlertResponse)
+	err := c.cc.Invoke(ctx, "/grpcoin.Alerts/DeleteAlert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *alertsClient) StreamNotifications(ctx context.Context, in *StreamNotificationsRequest, opts ...grpc.CallOption) (Alerts_StreamNotificationsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Alerts_ServiceDesc.Streams[0], "/grpcoin.Alerts/StreamNotifications", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &alertsStreamNotificationsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Alerts_StreamNotificationsClient interface {
+	Recv() (*AlertNotification, error)
+	grpc.ClientStream
+}
+
+type alertsStreamNotificationsClient struct {
+	grpc.ClientStream
+}
+
+func (x *alertsStreamNotificationsClient) Recv() (*AlertNotification, error) {
+	m := new(AlertNotification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AlertsServer is the server API for Alerts service.
+// All implementations must embed UnimplementedAlertsServer
+// for forward compatibility
+type AlertsServer interface {
+	// Creates a price alert on a currency, which fires once when its
+	// condition is met. Fired alerts are delivered on StreamNotifications
+	// and kept (with their fired_at time) until deleted.
+	CreateAlert(context.Context, *CreateAlertRequest) (*CreateAlertResponse, error)
+	// Returns authenticated user's alerts, including the fired ones.
+	ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error)
+	// Deletes an alert. Fails with NOT_FOUND if it does not exist.
+	DeleteAlert(context.Context, *DeleteAlertRequest) (*DeleteAlertResponse, error)
+	// Streams the user's alerts as they fire. Alerts fired while the user
+	// is not connected are not delivered (use ListAlerts to find them).
+	//
+	// This stream terminates after 15 minutes, so expect being
+	// abruptly disconnected and need to reconnect.
+	StreamNotifications(*StreamNotificationsRequest, Alerts_StreamNotificationsServer) error
+	mustEmbedUnimplementedAlertsServer()
+}
+
+// UnimplementedAlertsServer must be embedded to have forward compatible implementations.
+type UnimplementedAlertsServer struct {
+}
+
+func (UnimplementedAlertsServer) CreateAlert(context.Context, *CreateAlertRequest) (*CreateAlertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAlert not implemented")
+}
+func (UnimplementedAlertsServer) ListAlerts(context.Context, *ListAlertsRequest) (*ListAlertsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAlerts not implemented")
+}
+func (UnimplementedAlertsServer) DeleteAlert(context.Context, *DeleteAlertRequest) (*DeleteAlertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAlert not implemented")
+}
+func (UnimplementedAlertsServer) StreamNotifications(*StreamNotificationsRequest, Alerts_StreamNotificationsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamNotifications not implemented")
+}
+func (UnimplementedAlertsServer) mustEmbedUnimplementedAlertsServer() {}
+
+// UnsafeAlertsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AlertsServer will
+// result in compilation errors.
+type UnsafeAlertsServer interface {
+	mustEmbedUnimplementedAlertsServer()
+}
+
+func RegisterAlertsServer(s grpc.ServiceRegistrar, srv AlertsServer) {
+	s.RegisterService(&Alerts_ServiceDesc, srv)
+}
+
+func _Alerts_CreateAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertsServer).CreateAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.Alerts/CreateAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertsServer).CreateAlert(ctx, req.(*CreateAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Alerts_ListAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertsServer).ListAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.Alerts/ListAlerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertsServer).ListAlerts(ctx, req.(*ListAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Alerts_DeleteAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AlertsServer).DeleteAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcoin.Alerts/DeleteAlert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AlertsServer).DeleteAlert(ctx, req.(*DeleteAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Alerts_StreamNotifications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamNotificationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AlertsServer).StreamNotifications(m, &alertsStreamNotificationsServer{stream})
+}
+
+type Alerts_StreamNotificationsServer interface {
+	Send(*AlertNotification) error
+	grpc.ServerStream
+}
+
+type alertsStreamNotificationsServer struct {
+	grpc.ServerStream
+}
+
+func (x *alertsStreamNotificationsServer) Send(m *AlertNotification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Alerts_ServiceDesc is the grpc.ServiceDesc for Alerts service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Alerts_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "grpcoin.Alerts",
+	HandlerType: (*AlertsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAlert",
+			Handler:    _Alerts_CreateAlert_Handler,
+		},
+		{
+			MethodName: "ListAlerts",
+			Handler:    _Alerts_ListAlerts_Handler,
+		},
+		{
+			MethodName: "DeleteAlert",
+			Handler:    _Alerts_DeleteAlert_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamNotifications",
+			Handler:       _Alerts_StreamNotifications_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "grpcoin.proto",
+}
+
 // AccountClient is the client API for Account service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
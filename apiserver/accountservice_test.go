@@ -26,7 +26,6 @@ import (
 	"github.com/grpcoin/grpcoin/api/grpcoin"
 	"github.com/grpcoin/grpcoin/apiserver/auth"
 	"github.com/grpcoin/grpcoin/apiserver/auth/github"
-	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
 	"github.com/grpcoin/grpcoin/testutil"
 	"github.com/grpcoin/grpcoin/userdb"
 )
@@ -40,16 +39,15 @@ func TestTestAuth(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fs := firestoreutil.StartTestEmulator(t, context.TODO())
 	au := auth.MockAuthenticator{
 		F: func(c context.Context) (auth.AuthenticatedUser, error) {
 			return &github.GitHubUser{ID: 1, Username: "abc"}, nil
 		},
 	}
-	udb := &userdb.UserDB{DB: fs, T: trace.NewNoopTracerProvider().Tracer("")}
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: trace.NewNoopTracerProvider().Tracer("")}
 	lg, _ := zap.NewDevelopment()
 	r := testutil.MockRedis(t)
-	srv := prepServer(lg, au, mockRateLimiter{}, udb, &accountService{cache: &AccountCache{cache: r}}, nil, nil)
+	srv := prepServer(lg, au, mockRateLimiter{}, udb, &accountService{cache: &AccountCache{cache: r}}, nil, nil, nil, nil)
 	go srv.Serve(l)
 	defer srv.Stop()
 	defer l.Close()
@@ -0,0 +1,265 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth"
+	"github.com/grpcoin/grpcoin/notifications"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/tickers"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+const defaultAlertRefreshInterval = time.Second * 10
+
+type alertService struct {
+	udb              *userdb.UserDB
+	quoteProvider    realtimequote.QuoteProvider
+	supportedTickers *tickers.Universe
+	notifier         *notifications.Notifier
+	evaluator        *alertEvaluator
+
+	grpcoin.UnimplementedAlertsServer
+}
+
+func (a *alertService) CreateAlert(ctx context.Context, req *grpcoin.CreateAlertRequest) (*grpcoin.CreateAlertResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	if err := validateCreateAlertRequest(req, a.supportedTickers.Symbols()); err != nil {
+		return nil, err
+	}
+	product := req.GetCurrency().GetSymbol()
+
+	quoteCtx, cancel := context.WithTimeout(ctx, quoteDeadline)
+	defer cancel()
+	quote, err := a.quoteProvider.GetQuote(quoteCtx, product)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.Errorf(codes.Unavailable, "could not get real-time market quote for %s in %v",
+			product, quoteDeadline)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to retrieve a quote: %v", err)
+	}
+
+	v := userdb.Alert{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		Ticker:    product,
+		Condition: req.GetCondition(),
+		Price:     toAmount(req.GetPrice()),
+		Percent:   req.GetPercent(),
+		RefPrice:  toAmount(quote),
+		CreatedAt: time.Now().UTC(),
+		Active:    true,
+	}
+	if err := a.udb.CreateAlert(ctx, v); status.Code(err) == codes.ResourceExhausted {
+		return nil, err
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save alert: %v", err)
+	}
+	if a.evaluator != nil {
+		a.evaluator.add(v)
+	}
+	return &grpcoin.CreateAlertResponse{Alert: toAlertProto(v)}, nil
+}
+
+func (a *alertService) ListAlerts(ctx context.Context, _ *grpcoin.ListAlertsRequest) (*grpcoin.ListAlertsResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	alerts, err := a.udb.ListAlerts(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query alerts: %v", err)
+	}
+	out := &grpcoin.ListAlertsResponse{}
+	for _, v := range alerts {
+		out.Alerts = append(out.Alerts, toAlertProto(v))
+	}
+	return out, nil
+}
+
+func (a *alertService) DeleteAlert(ctx context.Context, req *grpcoin.DeleteAlertRequest) (*grpcoin.DeleteAlertResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "alert id not specified")
+	}
+	if err := a.udb.DeleteAlert(ctx, user.ID, req.GetId()); status.Code(err) == codes.NotFound {
+		return nil, err
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete alert: %v", err)
+	}
+	if a.evaluator != nil {
+		a.evaluator.remove(req.GetId())
+	}
+	return &grpcoin.DeleteAlertResponse{}, nil
+}
+
+func (a *alertService) StreamNotifications(_ *grpcoin.StreamNotificationsRequest, stream grpcoin.Alerts_StreamNotificationsServer) error {
+	user := auth.AuthInfoFromContext(stream.Context())
+	if user == nil {
+		return status.Error(codes.Internal, "req ctx did not have user info")
+	}
+	ch, err := a.notifier.Subscribe(stream.Context(), user.DBKey())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to subscribe to notifications: %v", err)
+	}
+	for v := range ch {
+		if err := stream.Send(&grpcoin.AlertNotification{Alert: toAlertProto(v)}); err != nil {
+			if errors.Is(err, context.Canceled) {
+				break
+			}
+			return err
+		}
+	}
+	if err := stream.Context().Err(); err != nil {
+		return status.Error(codes.Canceled, fmt.Sprintf("client cancelled request: %v", err))
+	}
+	return status.Error(codes.Unavailable, "notification subscription ended, please retry by reconnecting")
+}
+
+func validateCreateAlertRequest(req *grpcoin.CreateAlertRequest, supportedTickers []string) error {
+	if req.GetCurrency().GetSymbol() == "" {
+		return status.Error(codes.InvalidArgument, "currency not specified")
+	}
+	if !realtimequote.IsSupported(supportedTickers, req.GetCurrency().GetSymbol()) {
+		return status.Errorf(codes.InvalidArgument, "currency %q is not supported (supported tickers: %v)",
+			req.GetCurrency().GetSymbol(), supportedTickers)
+	}
+	price := toAmount(req.GetPrice())
+	switch req.GetCondition() {
+	case grpcoin.AlertCondition_PRICE_ABOVE, grpcoin.AlertCondition_PRICE_BELOW:
+		if price.IsZero() || price.IsNegative() {
+			return status.Error(codes.InvalidArgument, "price must be positive")
+		} else if req.GetPercent() != 0 {
+			return status.Errorf(codes.InvalidArgument, "percent cannot be specified for %s alerts", req.GetCondition())
+		}
+	case grpcoin.AlertCondition_PERCENT_MOVE:
+		if req.GetPercent() <= 0 {
+			return status.Error(codes.InvalidArgument, "percent must be positive")
+		} else if !price.IsZero() {
+			return status.Errorf(codes.InvalidArgument, "price cannot be specified for %s alerts", req.GetCondition())
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid alert condition: %s", req.GetCondition())
+	}
+	return nil
+}
+
+func toAlertProto(a userdb.Alert) *grpcoin.Alert {
+	out := &grpcoin.Alert{
+		Id:             a.ID,
+		Currency:       &grpcoin.Currency{Symbol: a.Ticker},
+		Condition:      a.Condition,
+		Percent:        a.Percent,
+		ReferencePrice: a.RefPrice.V(),
+		CreatedAt:      timestamppb.New(a.CreatedAt),
+	}
+	if a.Condition != grpcoin.AlertCondition_PERCENT_MOVE {
+		out.Price = a.Price.V()
+	}
+	if !a.Active {
+		out.FiredAt = timestamppb.New(a.FiredAt)
+		out.FiredPrice = a.FiredPrice.V()
+	}
+	return out
+}
+
+// alertEvaluator keeps track of the active alerts and fires them when the
+// real-time quotes meet their conditions. Multiple instances can run
+// concurrently, since firing an alert is transactional.
+type alertEvaluator struct {
+	udb      *userdb.UserDB
+	notifier *notifications.Notifier
+	log      *zap.Logger
+	book     *quoteBook
+}
+
+func newAlertEvaluator(udb *userdb.UserDB, f *fanout.QuoteFanoutService, n *notifications.Notifier, log *zap.Logger) *alertEvaluator {
+	e := &alertEvaluator{udb: udb, notifier: n, log: log}
+	e.book = newQuoteBook(f, log, defaultAlertRefreshInterval, e.load, e.fire)
+	return e
+}
+
+// bookAlert is an active alert in the book.
+type bookAlert userdb.Alert
+
+func (a bookAlert) key() (string, string) { return a.Ticker, a.ID }
+func (a bookAlert) created() time.Time    { return a.CreatedAt }
+
+func (a bookAlert) triggeredBy(price *grpcoin.Amount) bool {
+	return userdb.Alert(a).Triggered(price)
+}
+
+// add starts tracking an active alert.
+func (e *alertEvaluator) add(a userdb.Alert) { e.book.add(bookAlert(a)) }
+
+// remove stops tracking an alert.
+func (e *alertEvaluator) remove(id string) { e.book.remove(id) }
+
+// run evaluates the alerts against the quote stream until ctx is done. It is
+// meant to be invoked in a goroutine.
+func (e *alertEvaluator) run(ctx context.Context) { e.book.run(ctx) }
+
+func (e *alertEvaluator) fire(ctx context.Context, v bookEntry, price *grpcoin.Amount) {
+	a := userdb.Alert(v.(bookAlert))
+	log := e.log.With(zap.String("uid", a.UserID), zap.String("alert.id", a.ID))
+	ctx, cancel := context.WithTimeout(ctx, tradeExecutionDeadline)
+	defer cancel()
+	fired, err := e.udb.FireAlert(ctx, a, price)
+	switch status.Code(err) {
+	case codes.OK:
+		log.Debug("fired alert", zap.Any("price", price))
+		e.remove(a.ID)
+		if err := e.notifier.Publish(ctx, fired); err != nil {
+			log.Warn("failed to publish alert notification", zap.Error(err))
+		}
+	case codes.FailedPrecondition, codes.NotFound:
+		e.remove(a.ID) // fired or deleted elsewhere
+	default:
+		log.Warn("failed to fire alert, will retry", zap.Error(err))
+	}
+}
+
+// load returns the active alerts (to learn about alerts created, deleted or
+// fired on other instances).
+func (e *alertEvaluator) load(ctx context.Context) ([]bookEntry, error) {
+	alerts, err := e.udb.ActiveAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]bookEntry, len(alerts))
+	for i, a := range alerts {
+		out[i] = bookAlert(a)
+	}
+	return out, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth"
+	"github.com/grpcoin/grpcoin/apiserver/auth/github"
+	"github.com/grpcoin/grpcoin/notifications"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tradecounters"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func Test_validateCreateAlertRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *grpcoin.CreateAlertRequest
+		code codes.Code
+	}{
+		{name: "price above",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Condition: grpcoin.AlertCondition_PRICE_ABOVE, Price: &grpcoin.Amount{Units: 50_000}},
+			code: codes.OK},
+		{name: "percent move",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Condition: grpcoin.AlertCondition_PERCENT_MOVE, Percent: 2.5},
+			code: codes.OK},
+		{name: "no currency",
+			req: &grpcoin.CreateAlertRequest{
+				Condition: grpcoin.AlertCondition_PRICE_ABOVE, Price: &grpcoin.Amount{Units: 50_000}},
+			code: codes.InvalidArgument},
+		{name: "unsupported currency",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "XXX"},
+				Condition: grpcoin.AlertCondition_PRICE_ABOVE, Price: &grpcoin.Amount{Units: 50_000}},
+			code: codes.InvalidArgument},
+		{name: "no condition",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Price: &grpcoin.Amount{Units: 50_000}},
+			code: codes.InvalidArgument},
+		{name: "no price",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Condition: grpcoin.AlertCondition_PRICE_BELOW},
+			code: codes.InvalidArgument},
+		{name: "negative price",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Condition: grpcoin.AlertCondition_PRICE_BELOW, Price: &grpcoin.Amount{Units: -1}},
+			code: codes.InvalidArgument},
+		{name: "price with percent",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Condition: grpcoin.AlertCondition_PRICE_ABOVE, Price: &grpcoin.Amount{Units: 50_000}, Percent: 1},
+			code: codes.InvalidArgument},
+		{name: "no percent",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Condition: grpcoin.AlertCondition_PERCENT_MOVE},
+			code: codes.InvalidArgument},
+		{name: "percent with price",
+			req: &grpcoin.CreateAlertRequest{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Condition: grpcoin.AlertCondition_PERCENT_MOVE, Percent: 1, Price: &grpcoin.Amount{Units: 50_000}},
+			code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateCreateAlertRequest(tt.req, []string{"BTC"}); status.Code(err) != tt.code {
+				t.Errorf("validateCreateAlertRequest() error = %v, wantErr %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestAlertEvaluator(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
+	au := &github.GitHubUser{ID: 4, Username: "jkl"}
+	user, err := udb.EnsureAccountExists(context.TODO(), au)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := userdb.Alert{
+		ID:        "alert1",
+		UserID:    user.ID,
+		Ticker:    "BTC",
+		Condition: grpcoin.AlertCondition_PRICE_BELOW,
+		Price:     userdb.Amount{Units: 40_000},
+		CreatedAt: time.Now().UTC(),
+		Active:    true,
+	}
+	if err := udb.CreateAlert(context.TODO(), a); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	n := &notifications.Notifier{DB: testutil.MockRedis(t)}
+	ch, err := n.Subscribe(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := fanout.NewQuoteFanoutService(func(ctx context.Context) (<-chan realtimequote.Quote, error) {
+		return mockQuoteStream{product: "BTC", price: &grpcoin.Amount{Units: 35_000}, n: 100}.Watch(ctx)
+	})
+	e := newAlertEvaluator(udb, f, n, zap.NewNop())
+	go e.run(ctx)
+
+	select {
+	case got := <-ch:
+		if got.ID != a.ID || got.Active || got.FiredPrice != (userdb.Amount{Units: 35_000}) {
+			t.Fatalf("wrong notification: %#v", got)
+		}
+	case <-ctx.Done():
+		t.Fatal("alert was not fired in time")
+	}
+}
+
+type notificationStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *notificationStream) Context() context.Context              { return s.ctx }
+func (s *notificationStream) Send(*grpcoin.AlertNotification) error { return nil }
+
+func TestStreamNotifications_subscriptionEnded(t *testing.T) {
+	rc := testutil.MockRedis(t)
+	a := &alertService{notifier: &notifications.Notifier{DB: rc}}
+	ctx, cancel := context.WithTimeout(auth.WithUser(context.Background(), &github.GitHubUser{ID: 1, Username: "abc"}), time.Second*5)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- a.StreamNotifications(&grpcoin.StreamNotificationsRequest{}, &notificationStream{ctx: ctx})
+	}()
+	time.Sleep(time.Millisecond * 100) // let it subscribe
+	rc.Close()
+	select {
+	case err := <-errCh:
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("expected Unavailable, got: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("stream did not end with the subscription")
+	}
+}
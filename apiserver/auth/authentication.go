@@ -17,9 +17,11 @@ package auth
 import (
 	"context"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 type ctxAuthUserInfo struct{} // stores authenticated user info (e.g. github profile)
@@ -49,6 +51,29 @@ func AuthenticatingInterceptor(a Authenticator) grpc_auth.AuthFunc {
 	}
 }
 
+// AuthenticatingStreamInterceptor authenticates the calls to the specified
+// streaming methods (e.g. "/grpcoin.Alerts/StreamNotifications"), while
+// leaving the other streams unauthenticated.
+func AuthenticatingStreamInterceptor(a Authenticator, methods ...string) grpc.StreamServerInterceptor {
+	authFn := AuthenticatingInterceptor(a)
+	authenticated := make(map[string]bool)
+	for _, m := range methods {
+		authenticated[m] = true
+	}
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !authenticated[info.FullMethod] {
+			return handler(srv, stream)
+		}
+		ctx, err := authFn(stream.Context())
+		if err != nil {
+			return err
+		}
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
 // AuthInfoFromContext extracts authenticated user info from the ctx.
 func AuthInfoFromContext(ctx context.Context) AuthenticatedUser {
 	v := ctx.Value(ctxAuthUserInfo{})
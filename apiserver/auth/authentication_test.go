@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"testing"
+
+	"google.golang.org/grpc"
 )
 
 type testUser struct {
@@ -61,3 +63,39 @@ func TestAuthenticatingInterceptor(t *testing.T) {
 		t.Fatal("did not get the error from auth func")
 	}
 }
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testStream) Context() context.Context { return s.ctx }
+
+func TestAuthenticatingStreamInterceptor(t *testing.T) {
+	interceptor := AuthenticatingStreamInterceptor(MockAuthenticator{
+		func(c context.Context) (AuthenticatedUser, error) { return nil, fmt.Errorf("some error") },
+	}, "/svc/Authenticated")
+	handler := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+	stream := testStream{ctx: context.Background()}
+
+	if err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/svc/Other"}, handler); err != nil {
+		t.Fatalf("unauthenticated method failed: %v", err)
+	}
+	if err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/svc/Authenticated"}, handler); err == nil {
+		t.Fatal("did not get the error from auth func")
+	}
+
+	interceptor = AuthenticatingStreamInterceptor(MockAuthenticator{
+		func(c context.Context) (AuthenticatedUser, error) { return testUser{}, nil },
+	}, "/svc/Authenticated")
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/svc/Authenticated"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			if u := AuthInfoFromContext(stream.Context()); u == nil {
+				t.Fatal("auth info did not propagate into stream context")
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -36,44 +36,55 @@ type Doc struct {
 //      {"p": "col/<NAME>/<SUBCOL>/<NAME>", "v": {base64 then gob-encoded map[string]interface{}}
 //      {"p": "col/<NAME>/<SUBCOL>/<NAME>", "v": {base64 then gob-encoded map[string]interface{}}
 func ImportData(r io.Reader, c *firestore.Client) error {
-
 	batch := c.Batch()
 	written := 0
 	batchSize := 400
 
+	err := ReadData(r, func(path string, data map[string]interface{}) error {
+		batch.Create(c.Doc(path), data)
+		written++
+		if written < batchSize {
+			return nil
+		}
+		if _, err := batch.Commit(context.TODO()); err != nil {
+			return fmt.Errorf("failed to commit batch: %w", err)
+		}
+		written = 0
+		batch = c.Batch() // start new batch
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if written > 0 {
+		if _, err := batch.Commit(context.TODO()); err != nil {
+			return fmt.Errorf("failed to commit batch: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadData decodes the documents in the format loaded by ImportData, and
+// invokes f with the path and the data of each document.
+func ReadData(r io.Reader, f func(path string, data map[string]interface{}) error) error {
 	d := json.NewDecoder(r)
 	d.DisallowUnknownFields()
 	gob.Register(map[string]interface{}{})
 	gob.Register(time.Time{})
 	for {
 		var v Doc
-		for {
-			err := d.Decode(&v)
-			if err == io.EOF {
-				break
-			} else if err != nil {
-				return fmt.Errorf("failed to decode json: %w", err)
-			}
-			var data map[string]interface{}
-			if err := gob.NewDecoder(bytes.NewReader(v.Value)).Decode(&data); err != nil {
-				return fmt.Errorf("failed to decode gob: %w", err)
-			}
-			batch.Create(c.Doc(v.Path), data)
-			written++
-			v = Doc{} // reset for reuse
-			if written == batchSize {
-				break
-			}
+		err := d.Decode(&v)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode json: %w", err)
 		}
-		if written == 0 {
-			break
-		} else {
-			if _, err := batch.Commit(context.TODO()); err != nil {
-				return fmt.Errorf("failed to commit batch: %w", err)
-			}
-			written = 0
-			batch = c.Batch() // start new batch
+		var data map[string]interface{}
+		if err := gob.NewDecoder(bytes.NewReader(v.Value)).Decode(&data); err != nil {
+			return fmt.Errorf("failed to decode gob: %w", err)
+		}
+		if err := f(v.Path, data); err != nil {
+			return err
 		}
 	}
-	return nil
 }
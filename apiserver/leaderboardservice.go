@@ -0,0 +1,113 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/leaderboard"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+type leaderboardService struct {
+	lb *leaderboard.Leaderboard
+
+	grpcoin.UnimplementedLeaderboardServer
+}
+
+func (l *leaderboardService) ListRankings(ctx context.Context, req *grpcoin.ListRankingsRequest) (*grpcoin.ListRankingsResponse, error) {
+	pageSize, err := toPageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	var offset int64
+	if req.GetPageToken() != "" {
+		offset, err = strconv.ParseInt(req.GetPageToken(), 10, 64)
+		if err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+	}
+	entries, total, err := l.lb.Page(ctx, offset, int64(pageSize))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query leaderboard: %v", err)
+	}
+	updated, err := l.lb.UpdatedAt(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query leaderboard: %v", err)
+	}
+	out := &grpcoin.ListRankingsResponse{TotalUsers: total}
+	if !updated.IsZero() {
+		out.UpdatedAt = timestamppb.New(updated)
+	}
+	for _, e := range entries {
+		out.Entries = append(out.Entries, toLeaderboardEntryProto(e))
+	}
+	if next := offset + int64(len(entries)); next < total {
+		out.NextPageToken = strconv.FormatInt(next, 10)
+	}
+	return out, nil
+}
+
+func (l *leaderboardService) MyRank(ctx context.Context, _ *grpcoin.MyRankRequest) (*grpcoin.MyRankResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	e, total, ok, err := l.lb.Rank(ctx, user.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query leaderboard: %v", err)
+	} else if !ok {
+		return nil, status.Error(codes.NotFound, "user is not ranked yet, rankings are updated hourly")
+	}
+	updated, err := l.lb.UpdatedAt(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query leaderboard: %v", err)
+	}
+	out := &grpcoin.MyRankResponse{
+		Entry:      toLeaderboardEntryProto(e),
+		TotalUsers: total,
+		Percentile: percentile(e.Rank, total),
+	}
+	if !updated.IsZero() {
+		out.UpdatedAt = timestamppb.New(updated)
+	}
+	return out, nil
+}
+
+// percentile returns the percentage of users ranked below the rank.
+func percentile(rank, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(total-rank) / float64(total) * 100
+}
+
+func toLeaderboardEntryProto(e leaderboard.Entry) *grpcoin.LeaderboardEntry {
+	return &grpcoin.LeaderboardEntry{
+		Rank:           e.Rank,
+		UserId:         e.UserID,
+		DisplayName:    e.DisplayName,
+		PortfolioValue: e.Value.V(),
+		DayReturn:      e.DayReturn.V(),
+		WeekReturn:     e.WeekReturn.V(),
+		MonthReturn:    e.MonthReturn.V(),
+	}
+}
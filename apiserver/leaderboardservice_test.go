@@ -0,0 +1,84 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/leaderboard"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func TestLeaderboardService(t *testing.T) {
+	ctx := context.Background()
+	lb := &leaderboard.Leaderboard{DB: testutil.MockRedis(t)}
+	var entries []leaderboard.Entry
+	for i := 0; i < 5; i++ {
+		entries = append(entries, leaderboard.Entry{
+			UserID:      fmt.Sprintf("u%d", i),
+			DisplayName: fmt.Sprintf("user%d", i),
+			Value:       userdb.Amount{Units: int64(100_000 + i*1000)},
+		})
+	}
+	now := time.Date(2050, 1, 1, 10, 0, 0, 0, time.UTC)
+	if err := lb.Save(ctx, entries, now); err != nil {
+		t.Fatal(err)
+	}
+	ls := &leaderboardService{lb: lb}
+
+	var got []string
+	req := &grpcoin.ListRankingsRequest{PageSize: 2}
+	for {
+		resp, err := ls.ListRankings(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetTotalUsers() != 5 || !resp.GetUpdatedAt().AsTime().Equal(now) {
+			t.Fatalf("unexpected response: %v", resp)
+		}
+		for _, e := range resp.GetEntries() {
+			got = append(got, fmt.Sprintf("%d:%s", e.GetRank(), e.GetUserId()))
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	if fmt.Sprint(got) != "[1:u4 2:u3 3:u2 4:u1 5:u0]" {
+		t.Fatalf("unexpected rankings: %v", got)
+	}
+	if _, err := ls.ListRankings(ctx, &grpcoin.ListRankingsRequest{PageToken: "x"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for bad page token, got: %v", err)
+	}
+
+	resp, err := ls.MyRank(userdb.WithUserRecord(ctx, userdb.User{ID: "u3"}), &grpcoin.MyRankRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetEntry().GetRank() != 2 || resp.GetTotalUsers() != 5 || resp.GetPercentile() != 60 {
+		t.Fatalf("unexpected rank: %v", resp)
+	}
+	if _, err := ls.MyRank(userdb.WithUserRecord(ctx, userdb.User{ID: "new"}), &grpcoin.MyRankRequest{}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for unranked user, got: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+const defaultLiquidationInterval = time.Minute
+
+// liquidator periodically checks the portfolios with short positions and
+// closes their positions if they fall below the maintenance margin. Multiple
+// instances can run concurrently, since a position is closed only if it has
+// not changed since it was checked.
+type liquidator struct {
+	udb      *userdb.UserDB
+	quotes   realtimequote.QuoteProvider
+	log      *zap.Logger
+	interval time.Duration
+}
+
+// run checks the portfolios until ctx is done. It is meant to be invoked in
+// a goroutine.
+func (l *liquidator) run(ctx context.Context) {
+	tick := time.NewTicker(l.interval)
+	defer tick.Stop()
+	for {
+		if err := l.check(ctx); err != nil {
+			l.log.Warn("failed to check portfolios for liquidation", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+func (l *liquidator) check(ctx context.Context) error {
+	users, err := l.udb.UsersWithShorts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query users with short positions: %w", err)
+	}
+	for _, u := range users {
+		if err := l.checkUser(ctx, u); err != nil {
+			l.log.Warn("failed to liquidate portfolio", zap.String("uid", u.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+func (l *liquidator) checkUser(ctx context.Context, u userdb.User) error {
+	prices, err := positionPrices(ctx, l.quotes, u.Portfolio)
+	if err != nil {
+		return err
+	}
+	below, err := l.udb.Margin.BelowMaintenance(u.Portfolio, prices)
+	if err != nil || !below {
+		return err
+	}
+	tickers, err := userdb.LiquidationOrder(u.Portfolio, prices)
+	if err != nil {
+		return err
+	}
+	log := l.log.With(zap.String("uid", u.ID))
+	log.Info("portfolio is below maintenance margin, liquidating", zap.Strings("tickers", tickers))
+	for _, ticker := range tickers {
+		tradeCtx, cancel := context.WithTimeout(ctx, tradeExecutionDeadline)
+		tr, _, err := l.udb.Liquidate(tradeCtx, u.ID, ticker, u.Portfolio.Positions[ticker], prices[ticker].V())
+		cancel()
+		if status.Code(err) == codes.FailedPrecondition {
+			log.Debug("position changed, skipping liquidation", zap.String("ticker", ticker))
+			return nil // will be checked again with the new portfolio
+		} else if err != nil {
+			return fmt.Errorf("failed to close %s position: %w", ticker, err)
+		}
+		log.Debug("closed position", zap.String("ticker", ticker), zap.Any("trade", tr))
+	}
+	return nil
+}
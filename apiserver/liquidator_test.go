@@ -0,0 +1,143 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth/github"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tradecounters"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func TestLiquidator(t *testing.T) {
+	ctx := context.Background()
+	quotes := &mockQuoteProvider{a: &grpcoin.Amount{Units: 10_000}}
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(),
+		T:            trace.NewNoopTracerProvider().Tracer(""),
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+		Margin:       userdb.MarginPolicy{InitialMargin: 0.5, MaintenanceMargin: 0.25},
+		Quotes:       quotes}
+	user, err := udb.EnsureAccountExists(ctx, &github.GitHubUser{ID: 5, Username: "mno"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// $100k cash can hold $200k of shorts at 50% initial margin
+	if _, _, err := udb.Trade(ctx, user.ID, "BTC", grpcoin.TradeAction_SELL,
+		quotes.a, &grpcoin.Amount{Units: 21}, ""); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for short exceeding margin, got: %v", err)
+	}
+	if _, _, err := udb.Trade(ctx, user.ID, "BTC", grpcoin.TradeAction_SELL,
+		quotes.a, &grpcoin.Amount{Units: 20}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &liquidator{udb: udb, quotes: quotes, log: zap.NewNop()}
+	if err := l.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	u, _, err := udb.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (userdb.Amount{Units: -20}); u.Portfolio.Positions["BTC"] != expected {
+		t.Fatalf("position should not be liquidated, got: %v", u.Portfolio.Positions)
+	}
+
+	// $300k cash is below 125% of $260k shorts
+	quotes.a = &grpcoin.Amount{Units: 13_000}
+	if err := l.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	u, _, err = udb.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Portfolio.Positions) != 0 || u.Portfolio.HasShorts {
+		t.Fatalf("position should be liquidated, got: %#v", u.Portfolio)
+	}
+	if expected := (userdb.Amount{Units: 40_000}); u.Portfolio.CashUSD != expected {
+		t.Fatalf("wrong cash after liquidation: %v", u.Portfolio.CashUSD)
+	}
+}
+
+func TestLiquidator_openOrders(t *testing.T) {
+	ctx := context.Background()
+	quotes := &mockQuoteProvider{a: &grpcoin.Amount{Units: 10_000}}
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(),
+		T:            trace.NewNoopTracerProvider().Tracer(""),
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+		Margin:       userdb.MarginPolicy{InitialMargin: 0.5, MaintenanceMargin: 0.25},
+		Quotes:       quotes}
+	user, err := udb.EnsureAccountExists(ctx, &github.GitHubUser{ID: 5, Username: "mno"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := udb.Trade(ctx, user.ID, "BTC", grpcoin.TradeAction_SELL,
+		quotes.a, &grpcoin.Amount{Units: 20}, ""); err != nil {
+		t.Fatal(err)
+	}
+	// reserves $100k of the $300k cash
+	o, err := udb.CreateOrder(ctx, userdb.Order{
+		ID:          "buy",
+		UserID:      user.ID,
+		Type:        grpcoin.OrderType_LIMIT,
+		Ticker:      "ETH",
+		Action:      grpcoin.TradeAction_BUY,
+		Size:        userdb.Amount{Units: 100},
+		LimitPrice:  userdb.Amount{Units: 1000},
+		TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+		Status:      grpcoin.OrderStatus_OPEN,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quotes.a = &grpcoin.Amount{Units: 13_000}
+	l := &liquidator{udb: udb, quotes: quotes, log: zap.NewNop()}
+	if err := l.check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	u, _, err := udb.Get(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Portfolio.Positions) != 0 || u.Portfolio.HasShorts {
+		t.Fatalf("position should be liquidated despite the open order, got: %#v", u.Portfolio)
+	}
+	if !u.Portfolio.ReservedCashUSD.IsZero() {
+		t.Fatalf("reservation of the open order should be released, got: %v", u.Portfolio.ReservedCashUSD)
+	}
+	o, _, err = udb.GetOrder(ctx, user.ID, o.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Status != grpcoin.OrderStatus_CANCELLED {
+		t.Fatalf("open order should be cancelled, got: %s", o.Status)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+const defaultOrderBookRefreshInterval = time.Second * 10
+
+// orderMatcher keeps track of open orders and fills them when the real-time
+// quotes reach the order prices. Multiple instances can run concurrently,
+// since filling an order is transactional.
+type orderMatcher struct {
+	udb  *userdb.UserDB
+	log  *zap.Logger
+	book *quoteBook
+}
+
+func newOrderMatcher(udb *userdb.UserDB, f *fanout.QuoteFanoutService, log *zap.Logger) *orderMatcher {
+	m := &orderMatcher{udb: udb, log: log}
+	m.book = newQuoteBook(f, log, defaultOrderBookRefreshInterval, m.load, m.fill)
+	return m
+}
+
+// bookOrder is an open order in the book.
+type bookOrder userdb.Order
+
+func (o bookOrder) key() (string, string) { return o.Ticker, o.ID }
+func (o bookOrder) created() time.Time    { return o.CreatedAt }
+
+func (o bookOrder) triggeredBy(price *grpcoin.Amount) bool {
+	return userdb.Order(o).Executable(price)
+}
+
+// add starts tracking an open order.
+func (m *orderMatcher) add(o userdb.Order) { m.book.add(bookOrder(o)) }
+
+// addBracket starts tracking the exit orders placed by the filled order.
+func (m *orderMatcher) addBracket(filled userdb.Order) {
+	for _, o := range userdb.BracketOrders(filled, filled.FilledAt) {
+		m.add(o)
+	}
+}
+
+// remove stops tracking an order.
+func (m *orderMatcher) remove(o userdb.Order) { m.book.remove(o.ID) }
+
+// run matches the orders against the quote stream until ctx is done. It is
+// meant to be invoked in a goroutine.
+func (m *orderMatcher) run(ctx context.Context) { m.book.run(ctx) }
+
+func (m *orderMatcher) fill(ctx context.Context, e bookEntry, price *grpcoin.Amount) {
+	o := userdb.Order(e.(bookOrder))
+	log := m.log.With(zap.String("uid", o.UserID), zap.String("order.id", o.ID))
+	ctx, cancel := context.WithTimeout(ctx, tradeExecutionDeadline)
+	defer cancel()
+	filled, _, err := m.udb.FillOrder(ctx, o, price)
+	switch status.Code(err) {
+	case codes.OK:
+		log.Debug("filled order", zap.Any("price", price))
+		m.remove(o)
+		m.addBracket(filled)
+		if o.OCOID != "" {
+			m.book.remove(o.OCOID)
+		}
+	case codes.InvalidArgument:
+		log.Debug("rejecting order", zap.Error(err))
+		if _, err := m.udb.CloseOrder(ctx, o.UserID, o.ID, grpcoin.OrderStatus_REJECTED,
+			status.Convert(err).Message()); err != nil && status.Code(err) != codes.FailedPrecondition {
+			log.Warn("failed to reject order", zap.Error(err))
+			return
+		}
+		m.remove(o)
+	case codes.FailedPrecondition, codes.NotFound:
+		m.remove(o) // closed elsewhere
+	default:
+		log.Warn("failed to fill order, will retry", zap.Error(err))
+	}
+}
+
+// load returns the open orders (to learn about orders placed or closed on
+// other instances) and expires orders past their time.
+func (m *orderMatcher) load(ctx context.Context) ([]bookEntry, error) {
+	now := time.Now()
+	orders, err := m.udb.OpenOrders(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []bookEntry
+	for _, o := range orders {
+		if o.Expired(now) {
+			if _, err := m.udb.CloseOrder(ctx, o.UserID, o.ID, grpcoin.OrderStatus_EXPIRED, ""); err != nil &&
+				status.Code(err) != codes.FailedPrecondition {
+				m.log.Warn("failed to expire order", zap.String("order.id", o.ID), zap.Error(err))
+			}
+			continue
+		}
+		out = append(out, bookOrder(o))
+	}
+	return out, nil
+}
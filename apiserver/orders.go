@@ -0,0 +1,293 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func (t *tradingService) PlaceOrder(ctx context.Context, req *grpcoin.PlaceOrderRequest) (*grpcoin.PlaceOrderResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	if req.GetTimeInForce() == grpcoin.TimeInForce_UNDEFINED_TIME_IN_FORCE {
+		req.TimeInForce = grpcoin.TimeInForce_GOOD_TILL_CANCELLED
+	}
+	if err := validatePlaceOrderRequest(req, t.supportedTickers.Symbols()); err != nil {
+		return nil, err
+	}
+	if err := validateTradeSize(req.GetQuantity(), t.supportedTickers, req.GetCurrency().GetSymbol()); err != nil {
+		return nil, err
+	}
+	product := req.GetCurrency().GetSymbol()
+
+	quote, err := t.getOrderQuote(ctx, product)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	o := userdb.Order{
+		ID:          uuid.New().String(),
+		UserID:      user.ID,
+		Type:        req.GetType(),
+		Ticker:      product,
+		Action:      req.GetAction(),
+		Size:        toAmount(req.GetQuantity()),
+		LimitPrice:  toAmount(req.GetLimitPrice()),
+		TimeInForce: req.GetTimeInForce(),
+		Status:      grpcoin.OrderStatus_OPEN,
+		CreatedAt:   now,
+
+		TriggerPrice:    toAmount(req.GetTriggerPrice()),
+		StopLossPrice:   toAmount(req.GetStopLossPrice()),
+		TakeProfitPrice: toAmount(req.GetTakeProfitPrice()),
+	}
+	if o.TimeInForce == grpcoin.TimeInForce_DAY {
+		o.ExpiresAt = now.Truncate(time.Hour * 24).Add(time.Hour * 24)
+	}
+	o, err = t.udb.CreateOrder(ctx, o)
+	if status.Code(err) == codes.InvalidArgument {
+		return nil, err
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save order: %v", err)
+	}
+
+	if o.Executable(quote) {
+		subCtx, s := t.tracer.Start(ctx, "fill order")
+		defer s.End()
+		tradeCtx, cancel2 := context.WithTimeout(subCtx, tradeExecutionDeadline)
+		defer cancel2()
+		filled, _, err := t.udb.FillOrder(tradeCtx, o, quote)
+		if err == nil {
+			if t.orderMatcher != nil {
+				t.orderMatcher.addBracket(filled)
+			}
+			return &grpcoin.PlaceOrderResponse{Order: toOrderProto(filled)}, nil
+		} else if status.Code(err) == codes.InvalidArgument {
+			rejected, err := t.udb.CloseOrder(ctx, o.UserID, o.ID, grpcoin.OrderStatus_REJECTED,
+				status.Convert(err).Message())
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to reject order: %v", err)
+			}
+			return &grpcoin.PlaceOrderResponse{Order: toOrderProto(rejected)}, nil
+		} else if !errors.Is(err, context.DeadlineExceeded) {
+			return nil, status.Errorf(codes.Internal, "failed to execute order: %v", err)
+		}
+		// order remains open, and is going to be retried by the matcher
+		// unless it had to be filled immediately
+	}
+	if o.TimeInForce == grpcoin.TimeInForce_IMMEDIATE_OR_CANCEL {
+		cancelled, err := t.udb.CloseOrder(ctx, o.UserID, o.ID, grpcoin.OrderStatus_CANCELLED,
+			"order could not be filled immediately")
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to cancel order: %v", err)
+		}
+		return &grpcoin.PlaceOrderResponse{Order: toOrderProto(cancelled)}, nil
+	}
+	if t.orderMatcher != nil {
+		t.orderMatcher.add(o)
+	}
+	return &grpcoin.PlaceOrderResponse{Order: toOrderProto(o)}, nil
+}
+
+// getOrderQuote returns the current quote of the product to place an order
+// with.
+func (t *tradingService) getOrderQuote(ctx context.Context, product string) (*grpcoin.Amount, error) {
+	subCtx, s := t.tracer.Start(ctx, "get quote")
+	defer s.End()
+	quoteCtx, cancel := context.WithTimeout(subCtx, quoteDeadline)
+	defer cancel()
+	quote, err := t.quoteProvider.GetQuote(quoteCtx, product)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, status.Errorf(codes.Unavailable, "could not get real-time market quote for %s in %v",
+			product, quoteDeadline)
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to retrieve a quote: %v", err)
+	}
+	return quote, nil
+}
+
+func (t *tradingService) ListOrders(ctx context.Context, req *grpcoin.ListOrdersRequest) (*grpcoin.ListOrdersResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	pageSize, err := toPageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	ticker := req.GetCurrency().GetSymbol()
+	if err := validateTickerFilter(ticker, t.supportedTickers.Symbols()); err != nil {
+		return nil, err
+	}
+	orders, next, err := t.udb.ListOrders(ctx, user.ID, userdb.OrderFilter{
+		Status: req.GetStatus(),
+		Ticker: ticker,
+	}, pageSize, req.GetPageToken())
+	if status.Code(err) == codes.InvalidArgument {
+		return nil, err
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list orders: %v", err)
+	}
+	out := &grpcoin.ListOrdersResponse{NextPageToken: next}
+	for _, o := range orders {
+		out.Orders = append(out.Orders, toOrderProto(o))
+	}
+	return out, nil
+}
+
+func (t *tradingService) GetOrder(ctx context.Context, req *grpcoin.GetOrderRequest) (*grpcoin.GetOrderResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id not specified")
+	}
+	o, ok, err := t.udb.GetOrder(ctx, user.ID, req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
+	} else if !ok {
+		return nil, status.Errorf(codes.NotFound, "order %q not found", req.GetId())
+	}
+	return &grpcoin.GetOrderResponse{Order: toOrderProto(o)}, nil
+}
+
+func (t *tradingService) CancelOrder(ctx context.Context, req *grpcoin.CancelOrderRequest) (*grpcoin.CancelOrderResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order id not specified")
+	}
+	o, err := t.udb.CloseOrder(ctx, user.ID, req.GetId(), grpcoin.OrderStatus_CANCELLED, "cancelled by user")
+	if c := status.Code(err); c == codes.NotFound || c == codes.FailedPrecondition {
+		return nil, err
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to cancel order: %v", err)
+	}
+	if t.orderMatcher != nil {
+		t.orderMatcher.remove(o)
+	}
+	return &grpcoin.CancelOrderResponse{Order: toOrderProto(o)}, nil
+}
+
+func validatePlaceOrderRequest(req *grpcoin.PlaceOrderRequest, supportedTickers []string) error {
+	switch req.GetType() {
+	case grpcoin.OrderType_LIMIT, grpcoin.OrderType_STOP, grpcoin.OrderType_TAKE_PROFIT:
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid order type: %s", req.GetType())
+	}
+	if err := validateTradeRequest(&grpcoin.TradeRequest{
+		Action:   req.GetAction(),
+		Currency: req.GetCurrency(),
+		Quantity: req.GetQuantity(),
+	}, supportedTickers); err != nil {
+		return err
+	}
+	switch req.GetTimeInForce() {
+	case grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+		grpcoin.TimeInForce_IMMEDIATE_OR_CANCEL,
+		grpcoin.TimeInForce_DAY:
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid time in force: %s", req.GetTimeInForce())
+	}
+	limit, trigger := toAmount(req.GetLimitPrice()), toAmount(req.GetTriggerPrice())
+	if req.GetType() == grpcoin.OrderType_LIMIT {
+		if limit.IsZero() {
+			return status.Error(codes.InvalidArgument, "limit price not specified")
+		} else if !trigger.IsZero() {
+			return status.Errorf(codes.InvalidArgument, "trigger price cannot be specified for %s orders", req.GetType())
+		}
+	} else {
+		if trigger.IsZero() {
+			return status.Error(codes.InvalidArgument, "trigger price not specified")
+		} else if !limit.IsZero() {
+			return status.Errorf(codes.InvalidArgument, "limit price cannot be specified for %s orders", req.GetType())
+		}
+	}
+	for _, p := range []*grpcoin.Amount{req.GetLimitPrice(), req.GetTriggerPrice(),
+		req.GetStopLossPrice(), req.GetTakeProfitPrice()} {
+		if p.GetUnits() < 0 || p.GetNanos() < 0 {
+			return status.Error(codes.InvalidArgument, "negative price")
+		}
+	}
+
+	stopLoss, takeProfit := toAmount(req.GetStopLossPrice()), toAmount(req.GetTakeProfitPrice())
+	if stopLoss.IsZero() && takeProfit.IsZero() {
+		return nil
+	}
+	if req.GetType() != grpcoin.OrderType_LIMIT || req.GetAction() != grpcoin.TradeAction_BUY {
+		return status.Error(codes.InvalidArgument, "bracket orders can only be attached to LIMIT BUY orders")
+	}
+	if !stopLoss.IsZero() && !stopLoss.Less(limit) {
+		return status.Error(codes.InvalidArgument, "stop loss price must be below the limit price")
+	}
+	if !takeProfit.IsZero() && !limit.Less(takeProfit) {
+		return status.Error(codes.InvalidArgument, "take profit price must be above the limit price")
+	}
+	return nil
+}
+
+func toAmount(a *grpcoin.Amount) userdb.Amount {
+	return userdb.Amount{Units: a.GetUnits(), Nanos: a.GetNanos()}
+}
+
+func toOrderProto(o userdb.Order) *grpcoin.Order {
+	out := &grpcoin.Order{
+		Id:           o.ID,
+		Type:         o.Type,
+		Action:       o.Action,
+		Currency:     &grpcoin.Currency{Symbol: o.Ticker},
+		Quantity:     o.Size.V(),
+		TimeInForce:  o.TimeInForce,
+		Status:       o.Status,
+		StatusReason: o.StatusReason,
+		CreatedAt:    timestamppb.New(o.CreatedAt),
+	}
+	if !o.ExpiresAt.IsZero() {
+		out.ExpiresAt = timestamppb.New(o.ExpiresAt)
+	}
+	if o.Status == grpcoin.OrderStatus_FILLED {
+		out.FilledAt = timestamppb.New(o.FilledAt)
+		out.ExecutedPrice = o.ExecutedPrice.V()
+	}
+	if o.IsTriggered() {
+		out.TriggerPrice = o.TriggerPrice.V()
+	} else {
+		out.LimitPrice = o.LimitPrice.V()
+	}
+	if !o.StopLossPrice.IsZero() {
+		out.StopLossPrice = o.StopLossPrice.V()
+	}
+	if !o.TakeProfitPrice.IsZero() {
+		out.TakeProfitPrice = o.TakeProfitPrice.V()
+	}
+	out.ParentOrderId, out.OcoOrderId = o.ParentID, o.OCOID
+	return out
+}
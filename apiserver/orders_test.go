@@ -0,0 +1,380 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth"
+	"github.com/grpcoin/grpcoin/apiserver/auth/github"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tickers"
+	"github.com/grpcoin/grpcoin/tradecounters"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func Test_validatePlaceOrderRequest(t *testing.T) {
+	valid := func() *grpcoin.PlaceOrderRequest {
+		return &grpcoin.PlaceOrderRequest{
+			Type:        grpcoin.OrderType_LIMIT,
+			Action:      grpcoin.TradeAction_BUY,
+			Currency:    &grpcoin.Currency{Symbol: "BTC"},
+			Quantity:    &grpcoin.Amount{Units: 1},
+			LimitPrice:  &grpcoin.Amount{Units: 30_000},
+			TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(r *grpcoin.PlaceOrderRequest)
+		code   codes.Code
+	}{
+		{name: "valid",
+			modify: func(r *grpcoin.PlaceOrderRequest) {},
+			code:   codes.OK},
+		{name: "no type",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.Type = grpcoin.OrderType_UNDEFINED_ORDER_TYPE },
+			code:   codes.InvalidArgument},
+		{name: "no action",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.Action = grpcoin.TradeAction_UNDEFINED },
+			code:   codes.InvalidArgument},
+		{name: "unsupported ticker",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.Currency.Symbol = "XXX" },
+			code:   codes.InvalidArgument},
+		{name: "no quantity",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.Quantity = nil },
+			code:   codes.InvalidArgument},
+		{name: "no limit price",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.LimitPrice = nil },
+			code:   codes.InvalidArgument},
+		{name: "negative limit price",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.LimitPrice = &grpcoin.Amount{Units: -1} },
+			code:   codes.InvalidArgument},
+		{name: "bad time in force",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.TimeInForce = 100 },
+			code:   codes.InvalidArgument},
+		{name: "stop order",
+			modify: func(r *grpcoin.PlaceOrderRequest) {
+				r.Type, r.Action = grpcoin.OrderType_STOP, grpcoin.TradeAction_SELL
+				r.LimitPrice, r.TriggerPrice = nil, &grpcoin.Amount{Units: 25_000}
+			},
+			code: codes.OK},
+		{name: "stop order without trigger price",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.Type, r.LimitPrice = grpcoin.OrderType_STOP, nil },
+			code:   codes.InvalidArgument},
+		{name: "stop order with limit price",
+			modify: func(r *grpcoin.PlaceOrderRequest) {
+				r.Type, r.TriggerPrice = grpcoin.OrderType_TAKE_PROFIT, &grpcoin.Amount{Units: 25_000}
+			},
+			code: codes.InvalidArgument},
+		{name: "limit order with trigger price",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.TriggerPrice = &grpcoin.Amount{Units: 25_000} },
+			code:   codes.InvalidArgument},
+		{name: "bracket",
+			modify: func(r *grpcoin.PlaceOrderRequest) {
+				r.StopLossPrice, r.TakeProfitPrice = &grpcoin.Amount{Units: 25_000}, &grpcoin.Amount{Units: 35_000}
+			},
+			code: codes.OK},
+		{name: "bracket on sell order",
+			modify: func(r *grpcoin.PlaceOrderRequest) {
+				r.Action, r.StopLossPrice = grpcoin.TradeAction_SELL, &grpcoin.Amount{Units: 25_000}
+			},
+			code: codes.InvalidArgument},
+		{name: "bracket stop loss above limit",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.StopLossPrice = &grpcoin.Amount{Units: 31_000} },
+			code:   codes.InvalidArgument},
+		{name: "bracket take profit below limit",
+			modify: func(r *grpcoin.PlaceOrderRequest) { r.TakeProfitPrice = &grpcoin.Amount{Units: 29_000} },
+			code:   codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+			if err := validatePlaceOrderRequest(req, []string{"BTC"}); status.Code(err) != tt.code {
+				t.Errorf("validatePlaceOrderRequest() error = %v, wantErr %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestPlaceOrder(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
+
+	au := &github.GitHubUser{ID: 3, Username: "ghi"}
+	user, err := udb.EnsureAccountExists(context.TODO(), au)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := auth.WithUser(context.Background(), au)
+	ctx = userdb.WithUserRecord(ctx, user)
+	qp := &mockQuoteProvider{a: &grpcoin.Amount{Units: 30_000}}
+	pt := &tradingService{udb: udb, quoteProvider: qp, tracer: tp, supportedTickers: tickers.FromSymbols("BTC")}
+
+	// executable order fills immediately
+	resp, err := pt.PlaceOrder(ctx, &grpcoin.PlaceOrderRequest{
+		Type:       grpcoin.OrderType_LIMIT,
+		Action:     grpcoin.TradeAction_BUY,
+		Currency:   &grpcoin.Currency{Symbol: "BTC"},
+		Quantity:   &grpcoin.Amount{Units: 1},
+		LimitPrice: &grpcoin.Amount{Units: 31_000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetOrder().GetStatus(); got != grpcoin.OrderStatus_FILLED {
+		t.Fatalf("expected order to be filled, got: %s", got)
+	}
+	if got := resp.GetOrder().GetExecutedPrice().GetUnits(); got != 30_000 {
+		t.Fatalf("expected fill at market price, got: %d", got)
+	}
+
+	// non-executable IOC order is cancelled
+	resp, err = pt.PlaceOrder(ctx, &grpcoin.PlaceOrderRequest{
+		Type:        grpcoin.OrderType_LIMIT,
+		Action:      grpcoin.TradeAction_BUY,
+		Currency:    &grpcoin.Currency{Symbol: "BTC"},
+		Quantity:    &grpcoin.Amount{Units: 1},
+		LimitPrice:  &grpcoin.Amount{Units: 29_000},
+		TimeInForce: grpcoin.TimeInForce_IMMEDIATE_OR_CANCEL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetOrder().GetStatus(); got != grpcoin.OrderStatus_CANCELLED {
+		t.Fatalf("expected order to be cancelled, got: %s", got)
+	}
+
+	// non-executable DAY order remains open
+	resp, err = pt.PlaceOrder(ctx, &grpcoin.PlaceOrderRequest{
+		Type:        grpcoin.OrderType_LIMIT,
+		Action:      grpcoin.TradeAction_SELL,
+		Currency:    &grpcoin.Currency{Symbol: "BTC"},
+		Quantity:    &grpcoin.Amount{Units: 1},
+		LimitPrice:  &grpcoin.Amount{Units: 35_000},
+		TimeInForce: grpcoin.TimeInForce_DAY,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetOrder().GetStatus(); got != grpcoin.OrderStatus_OPEN {
+		t.Fatalf("expected order to be open, got: %s", got)
+	}
+	if resp.GetOrder().GetExpiresAt() == nil {
+		t.Fatal("expected DAY order to have an expiration")
+	}
+}
+
+// slowFillStore fails the transactions run with a deadline (i.e. the order
+// fills) as if they timed out.
+type slowFillStore struct{ userdb.Store }
+
+func (s slowFillStore) RunTx(ctx context.Context, f func(ctx context.Context, tx userdb.Tx) error) error {
+	if _, ok := ctx.Deadline(); ok {
+		return context.DeadlineExceeded
+	}
+	return s.Store.RunTx(ctx, f)
+}
+
+func TestPlaceOrder_fillTimeout(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	udb := &userdb.UserDB{DB: slowFillStore{userdb.NewMemStore()}, T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
+
+	au := &github.GitHubUser{ID: 3, Username: "ghi"}
+	user, err := udb.EnsureAccountExists(context.TODO(), au)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := auth.WithUser(context.Background(), au)
+	ctx = userdb.WithUserRecord(ctx, user)
+	qp := &mockQuoteProvider{a: &grpcoin.Amount{Units: 30_000}}
+	pt := &tradingService{udb: udb, quoteProvider: qp, tracer: tp, supportedTickers: tickers.FromSymbols("BTC")}
+
+	// executable IOC order that could not be filled in time is cancelled
+	resp, err := pt.PlaceOrder(ctx, &grpcoin.PlaceOrderRequest{
+		Type:        grpcoin.OrderType_LIMIT,
+		Action:      grpcoin.TradeAction_BUY,
+		Currency:    &grpcoin.Currency{Symbol: "BTC"},
+		Quantity:    &grpcoin.Amount{Units: 1},
+		LimitPrice:  &grpcoin.Amount{Units: 31_000},
+		TimeInForce: grpcoin.TimeInForce_IMMEDIATE_OR_CANCEL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetOrder().GetStatus(); got != grpcoin.OrderStatus_CANCELLED {
+		t.Fatalf("expected order to be cancelled, got: %s", got)
+	}
+
+	// GTC order is left open for the order matcher to retry
+	resp, err = pt.PlaceOrder(ctx, &grpcoin.PlaceOrderRequest{
+		Type:       grpcoin.OrderType_LIMIT,
+		Action:     grpcoin.TradeAction_BUY,
+		Currency:   &grpcoin.Currency{Symbol: "BTC"},
+		Quantity:   &grpcoin.Amount{Units: 1},
+		LimitPrice: &grpcoin.Amount{Units: 31_000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.GetOrder().GetStatus(); got != grpcoin.OrderStatus_OPEN {
+		t.Fatalf("expected order to be open, got: %s", got)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
+
+	au := &github.GitHubUser{ID: 5, Username: "mno"}
+	user, err := udb.EnsureAccountExists(context.TODO(), au)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := auth.WithUser(context.Background(), au)
+	userCtx := func() context.Context {
+		u, _, err := udb.Get(ctx, user.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return userdb.WithUserRecord(ctx, u)
+	}
+	qp := &mockQuoteProvider{a: &grpcoin.Amount{Units: 30_000}}
+	pt := &tradingService{udb: udb, quoteProvider: qp, tracer: tp, supportedTickers: tickers.FromSymbols("BTC")}
+
+	resp, err := pt.PlaceOrder(userCtx(), &grpcoin.PlaceOrderRequest{
+		Type:       grpcoin.OrderType_LIMIT,
+		Action:     grpcoin.TradeAction_BUY,
+		Currency:   &grpcoin.Currency{Symbol: "BTC"},
+		Quantity:   &grpcoin.Amount{Units: 2},
+		LimitPrice: &grpcoin.Amount{Units: 20_000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := resp.GetOrder().GetId()
+
+	p, err := pt.Portfolio(userCtx(), &grpcoin.PortfolioRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.GetReservedCashUsd().GetUnits(); got != 40_000 {
+		t.Fatalf("wrong reserved cash: %d", got)
+	}
+	if got := p.GetAvailableCashUsd().GetUnits(); got != 60_000 {
+		t.Fatalf("wrong available cash: %d", got)
+	}
+
+	list, err := pt.ListOrders(userCtx(), &grpcoin.ListOrdersRequest{Status: grpcoin.OrderStatus_OPEN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetOrders()) != 1 || list.GetOrders()[0].GetId() != id {
+		t.Fatalf("unexpected open orders: %v", list.GetOrders())
+	}
+
+	cancelled, err := pt.CancelOrder(userCtx(), &grpcoin.CancelOrderRequest{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cancelled.GetOrder().GetStatus(); got != grpcoin.OrderStatus_CANCELLED {
+		t.Fatalf("expected order to be cancelled, got: %s", got)
+	}
+	if _, err := pt.CancelOrder(userCtx(), &grpcoin.CancelOrderRequest{Id: id}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got: %v", err)
+	}
+	if _, err := pt.GetOrder(userCtx(), &grpcoin.GetOrderRequest{Id: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got: %v", err)
+	}
+
+	p, err = pt.Portfolio(userCtx(), &grpcoin.PortfolioRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.GetAvailableCashUsd().GetUnits(); got != 100_000 {
+		t.Fatalf("reservation is not released, available cash: %d", got)
+	}
+}
+
+func TestOrderMatcher(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
+	au := &github.GitHubUser{ID: 4, Username: "jkl"}
+	user, err := udb.EnsureAccountExists(context.TODO(), au)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o := userdb.Order{
+		ID:          "order1",
+		UserID:      user.ID,
+		Type:        grpcoin.OrderType_LIMIT,
+		Ticker:      "BTC",
+		Action:      grpcoin.TradeAction_BUY,
+		Size:        userdb.Amount{Units: 1},
+		LimitPrice:  userdb.Amount{Units: 40_000},
+		TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+		Status:      grpcoin.OrderStatus_OPEN,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if _, err := udb.CreateOrder(context.TODO(), o); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	f := fanout.NewQuoteFanoutService(func(ctx context.Context) (<-chan realtimequote.Quote, error) {
+		return mockQuoteStream{product: "BTC", price: &grpcoin.Amount{Units: 35_000}, n: 100}.Watch(ctx)
+	})
+	m := newOrderMatcher(udb, f, zap.NewNop())
+	go m.run(ctx)
+
+	for {
+		got, _, err := udb.GetOrder(ctx, user.ID, o.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status == grpcoin.OrderStatus_FILLED {
+			if got.ExecutedPrice != (userdb.Amount{Units: 35_000}) {
+				t.Fatalf("wrong executed price: %v", got.ExecutedPrice)
+			}
+			break
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatal("order was not filled in time")
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+}
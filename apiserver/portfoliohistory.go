@@ -0,0 +1,120 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+// valuationBucket summarizes the valuations recorded in an interval.
+type valuationBucket struct {
+	T              time.Time
+	Min, Max, Last userdb.Amount
+}
+
+func (t *tradingService) PortfolioHistory(ctx context.Context, req *grpcoin.PortfolioHistoryRequest) (*grpcoin.PortfolioHistoryResponse, error) {
+	ctx, span := t.tracer.Start(ctx, "portfolio history")
+	defer span.End()
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	res, err := toResolution(req.GetResolution())
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := toTimeRange(req.GetStartTime(), req.GetEndTime())
+	if err != nil {
+		return nil, err
+	}
+
+	vals, err := t.udb.UserValuationHistory(ctx, user.ID, valuationRange(start, time.Now()))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query portfolio history: %v", err)
+	}
+	out := &grpcoin.PortfolioHistoryResponse{}
+	for _, b := range resampleValuations(vals, res, start, end) {
+		out.Values = append(out.Values, &grpcoin.PortfolioValuation{
+			T:    timestamppb.New(b.T),
+			Min:  b.Min.V(),
+			Max:  b.Max.V(),
+			Last: b.Last.V(),
+		})
+	}
+	return out, nil
+}
+
+func toResolution(r grpcoin.HistoryResolution) (time.Duration, error) {
+	switch r {
+	case grpcoin.HistoryResolution_UNDEFINED_RESOLUTION, grpcoin.HistoryResolution_ONE_HOUR:
+		return time.Hour, nil
+	case grpcoin.HistoryResolution_SIX_HOURS:
+		return time.Hour * 6, nil
+	case grpcoin.HistoryResolution_ONE_DAY:
+		return time.Hour * 24, nil
+	}
+	return 0, status.Errorf(codes.InvalidArgument, "invalid resolution: %s", r)
+}
+
+// valuationRange returns the shortest range of the valuation history that
+// covers the valuations since start. Zero start covers the whole history.
+func valuationRange(start, now time.Time) userdb.ValuationRange {
+	switch {
+	case start.IsZero() || start.Before(now.AddDate(-1, 0, 0)):
+		return userdb.ValuationRangeAll
+	case start.Before(now.AddDate(0, -1, 0)):
+		return userdb.ValuationRangeYear
+	}
+	return userdb.ValuationRangeMonth
+}
+
+// resampleValuations groups the valuations within [start, end) into intervals
+// of the resolution (aligned in UTC), in ascending order. Zero start or end
+// times leave the range open on that side.
+func resampleValuations(vals []userdb.ValuationHistory, res time.Duration, start, end time.Time) []valuationBucket {
+	sorted := make([]userdb.ValuationHistory, len(vals))
+	copy(sorted, vals)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	var out []valuationBucket
+	for _, v := range sorted {
+		if (!start.IsZero() && v.Date.Before(start)) || (!end.IsZero() && !v.Date.Before(end)) {
+			continue
+		}
+		t := v.Date.UTC().Truncate(res)
+		if len(out) == 0 || !out[len(out)-1].T.Equal(t) {
+			out = append(out, valuationBucket{T: t, Min: v.Value, Max: v.Value, Last: v.Value})
+			continue
+		}
+		b := &out[len(out)-1]
+		if v.Value.Less(b.Min) {
+			b.Min = v.Value
+		}
+		if b.Max.Less(v.Value) {
+			b.Max = v.Value
+		}
+		b.Last = v.Value
+	}
+	return out
+}
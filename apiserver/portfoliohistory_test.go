@@ -0,0 +1,150 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth"
+	"github.com/grpcoin/grpcoin/apiserver/auth/github"
+	"github.com/grpcoin/grpcoin/tickers"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func Test_resampleValuations(t *testing.T) {
+	t0 := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	v := func(h int, units int64) userdb.ValuationHistory {
+		return userdb.ValuationHistory{Date: t0.Add(time.Duration(h) * time.Hour), Value: userdb.Amount{Units: units}}
+	}
+	a := func(units int64) userdb.Amount { return userdb.Amount{Units: units} }
+	vals := []userdb.ValuationHistory{
+		v(7, 90), // out of order
+		v(0, 100), v(1, 120), v(2, 80), v(3, 110),
+		v(6, 95), v(8, 130),
+		v(24, 150),
+	}
+	tests := []struct {
+		name       string
+		res        time.Duration
+		start, end time.Time
+		want       []valuationBucket
+	}{
+		{name: "hourly",
+			res:   time.Hour,
+			start: t0.Add(time.Hour * 2),
+			end:   t0.Add(time.Hour * 7),
+			want: []valuationBucket{
+				{T: t0.Add(2 * time.Hour), Min: a(80), Max: a(80), Last: a(80)},
+				{T: t0.Add(3 * time.Hour), Min: a(110), Max: a(110), Last: a(110)},
+				{T: t0.Add(6 * time.Hour), Min: a(95), Max: a(95), Last: a(95)},
+			}},
+		{name: "6h",
+			res: time.Hour * 6,
+			want: []valuationBucket{
+				{T: t0, Min: a(80), Max: a(120), Last: a(110)},
+				{T: t0.Add(6 * time.Hour), Min: a(90), Max: a(130), Last: a(130)},
+				{T: t0.Add(24 * time.Hour), Min: a(150), Max: a(150), Last: a(150)},
+			}},
+		{name: "1d",
+			res:   time.Hour * 24,
+			start: t0.Add(time.Hour),
+			want: []valuationBucket{
+				{T: t0, Min: a(80), Max: a(130), Last: a(130)},
+				{T: t0.Add(24 * time.Hour), Min: a(150), Max: a(150), Last: a(150)},
+			}},
+		{name: "empty range",
+			res:   time.Hour,
+			start: t0.Add(time.Hour * 100),
+			want:  nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resampleValuations(vals, tt.res, tt.start, tt.end)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func Test_valuationRange(t *testing.T) {
+	now := time.Date(2021, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		start time.Time
+		want  userdb.ValuationRange
+	}{
+		{time.Time{}, userdb.ValuationRangeAll},
+		{now.AddDate(-2, 0, 0), userdb.ValuationRangeAll},
+		{now.AddDate(0, -6, 0), userdb.ValuationRangeYear},
+		{now.AddDate(0, 0, -7), userdb.ValuationRangeMonth},
+	}
+	for _, tt := range tests {
+		if got := valuationRange(tt.start, now); got != tt.want {
+			t.Errorf("valuationRange(%v) = %q, want %q", tt.start, got, tt.want)
+		}
+	}
+}
+
+func TestPortfolioHistory(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp, Cache: userdb.MockProfileCache{}}
+
+	au := &github.GitHubUser{ID: 7, Username: "stu"}
+	user, err := udb.EnsureAccountExists(context.TODO(), au)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Now().UTC().Truncate(time.Hour * 24).Add(-time.Hour * 24)
+	for i := 0; i < 12; i++ {
+		if err := udb.SetUserValuationHistory(context.TODO(), user.ID, userdb.ValuationHistory{
+			Date:  t0.Add(time.Hour * time.Duration(i)),
+			Value: userdb.Amount{Units: int64(100_000 + i)},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pt := &tradingService{udb: udb, tracer: tp, supportedTickers: tickers.FromSymbols("BTC")}
+	ctx := auth.WithUser(context.Background(), au)
+	ctx = userdb.WithUserRecord(ctx, user)
+
+	resp, err := pt.PortfolioHistory(ctx, &grpcoin.PortfolioHistoryRequest{
+		Resolution: grpcoin.HistoryResolution_SIX_HOURS,
+		StartTime:  timestamppb.New(t0),
+		EndTime:    timestamppb.New(t0.Add(time.Hour * 12)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetValues()) != 2 {
+		t.Fatalf("expected 2 intervals, got: %v", resp.GetValues())
+	}
+	if got := resp.GetValues()[1]; got.GetMin().GetUnits() != 100_006 ||
+		got.GetMax().GetUnits() != 100_011 || got.GetLast().GetUnits() != 100_011 {
+		t.Fatalf("wrong interval summary: %v", got)
+	}
+
+	if _, err := pt.PortfolioHistory(ctx, &grpcoin.PortfolioHistoryRequest{Resolution: 100}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for bad resolution, got: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+const defaultPortfolioWatchInterval = time.Second * 5
+
+func (t *tradingService) WatchPortfolio(_ *grpcoin.WatchPortfolioRequest, stream grpcoin.PaperTrade_WatchPortfolioServer) error {
+	ctx := stream.Context()
+	au := auth.AuthInfoFromContext(ctx)
+	if au == nil {
+		return status.Error(codes.Internal, "req ctx did not have user info")
+	}
+	// subscribe before reading the portfolio, so no changes are missed
+	changes, err := t.portfolioChanges.WatchPortfolio(ctx, au.DBKey())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to watch portfolio: %v", err)
+	}
+	user, err := t.udb.EnsureAccountExists(ctx, au)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to ensure user account: %v", err)
+	}
+
+	interval := t.portfolioWatchInterval
+	if interval == 0 {
+		interval = defaultPortfolioWatchInterval
+	}
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+	for {
+		if err := t.sendPortfolioUpdate(stream, user.Portfolio); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Canceled, fmt.Sprintf("client cancelled request: %v", ctx.Err()))
+		case <-tick.C:
+		case _, ok := <-changes:
+			if !ok {
+				if err := ctx.Err(); err != nil {
+					return status.Error(codes.Canceled, fmt.Sprintf("client cancelled request: %v", err))
+				}
+				return status.Error(codes.Unavailable, "portfolio subscription ended, please retry by reconnecting")
+			}
+			u, found, err := t.udb.Get(ctx, user.ID)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to read portfolio: %v", err)
+			} else if !found {
+				return status.Error(codes.NotFound, "user account not found")
+			}
+			user = u
+		}
+	}
+}
+
+// sendPortfolioUpdate values the portfolio at the current prices and sends
+// it. If the prices are not available, the update is skipped.
+func (t *tradingService) sendPortfolioUpdate(stream grpcoin.PaperTrade_WatchPortfolioServer, p userdb.Portfolio) error {
+	prices, err := positionPrices(stream.Context(), t.quoteProvider, p)
+	if err != nil {
+		ctxzap.Extract(stream.Context()).Warn("failed to get quotes for portfolio valuation", zap.Error(err))
+		return nil
+	}
+	return stream.Send(toPortfolioUpdate(p, prices, time.Now()))
+}
+
+func toPortfolioUpdate(p userdb.Portfolio, prices map[string]userdb.Amount, now time.Time) *grpcoin.PortfolioUpdate {
+	value := userdb.Valuation(p, prices)
+	out := &grpcoin.PortfolioUpdate{
+		T:             timestamppb.New(now),
+		CashUsd:       p.CashUSD.V(),
+		TotalValue:    value.V(),
+		UnrealizedPnl: p.TotalUnrealizedPnL(prices).V(),
+		RealizedPnl:   p.RealizedPnL.V(),
+	}
+	for ticker, amt := range p.Positions {
+		price := prices[ticker]
+		v := &grpcoin.PositionValuation{
+			Currency: &grpcoin.Currency{Symbol: ticker},
+			Amount:   amt.V(),
+			Price:    price.V(),
+			Value:    userdb.ToAmount(amt.F().Mul(price.F())).V(),
+		}
+		if pnl, ok := p.UnrealizedPnL(ticker, price); ok {
+			v.CostBasis = p.CostBasis[ticker].V()
+			v.UnrealizedPnl = pnl.V()
+		}
+		out.Positions = append(out.Positions, v)
+	}
+	sort.Slice(out.Positions, func(i, j int) bool {
+		return out.Positions[i].GetCurrency().GetSymbol() < out.Positions[j].GetCurrency().GetSymbol()
+	})
+	return out
+}
@@ -0,0 +1,185 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth"
+	"github.com/grpcoin/grpcoin/apiserver/auth/github"
+	"github.com/grpcoin/grpcoin/notifications"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tickers"
+	"github.com/grpcoin/grpcoin/tradecounters"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func Test_toPortfolioUpdate(t *testing.T) {
+	now := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	p := userdb.Portfolio{
+		CashUSD: userdb.Amount{Units: 99_000},
+		Positions: map[string]userdb.Amount{
+			"ETH": {Units: -2},
+			"BTC": {Units: 0, Nanos: 500_000_000},
+		},
+		CostBasis:   map[string]userdb.Amount{"ETH": {Units: 350}},
+		RealizedPnL: userdb.Amount{Units: 1000},
+	}
+	prices := map[string]userdb.Amount{"BTC": {Units: 4000}, "ETH": {Units: 300}}
+	got := toPortfolioUpdate(p, prices, now)
+	want := &grpcoin.PortfolioUpdate{
+		T:       timestamppb.New(now),
+		CashUsd: &grpcoin.Amount{Units: 99_000},
+		Positions: []*grpcoin.PositionValuation{
+			{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Amount: &grpcoin.Amount{Nanos: 500_000_000},
+				Price:  &grpcoin.Amount{Units: 4000},
+				Value:  &grpcoin.Amount{Units: 2000}},
+			{Currency: &grpcoin.Currency{Symbol: "ETH"},
+				Amount:        &grpcoin.Amount{Units: -2},
+				Price:         &grpcoin.Amount{Units: 300},
+				Value:         &grpcoin.Amount{Units: -600},
+				CostBasis:     &grpcoin.Amount{Units: 350},
+				UnrealizedPnl: &grpcoin.Amount{Units: 100}},
+		},
+		TotalValue:    &grpcoin.Amount{Units: 100_400},
+		UnrealizedPnl: &grpcoin.Amount{Units: 100},
+		RealizedPnl:   &grpcoin.Amount{Units: 1000},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+type portfolioStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	ch  chan *grpcoin.PortfolioUpdate
+}
+
+func (s *portfolioStream) Context() context.Context { return s.ctx }
+
+func (s *portfolioStream) Send(m *grpcoin.PortfolioUpdate) error {
+	select {
+	case s.ch <- m:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+func TestWatchPortfolio(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	n := &notifications.Notifier{DB: testutil.MockRedis(t)}
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+		Changes:      n}
+	au := &github.GitHubUser{ID: 1, Username: "abc"}
+	pt := &tradingService{udb: udb, tracer: tp,
+		supportedTickers:       tickers.FromSymbols("BTC"),
+		quoteProvider:          &mockQuoteProvider{a: &grpcoin.Amount{Units: 20_000}},
+		portfolioChanges:       n,
+		portfolioWatchInterval: time.Hour}
+
+	ctx, cancel := context.WithTimeout(auth.WithUser(context.Background(), au), time.Second*5)
+	defer cancel()
+	stream := &portfolioStream{ctx: ctx, ch: make(chan *grpcoin.PortfolioUpdate)}
+	go pt.WatchPortfolio(&grpcoin.WatchPortfolioRequest{}, stream)
+
+	recv := func() *grpcoin.PortfolioUpdate {
+		t.Helper()
+		select {
+		case m := <-stream.ch:
+			return m
+		case <-ctx.Done():
+			t.Fatal("portfolio update not received")
+			return nil
+		}
+	}
+	if got := recv(); len(got.GetPositions()) != 0 || got.GetTotalValue().GetUnits() != 100_000 {
+		t.Fatalf("wrong initial portfolio: %v", got)
+	}
+
+	if _, _, err := udb.Trade(ctx, au.DBKey(), "BTC", grpcoin.TradeAction_BUY,
+		&grpcoin.Amount{Units: 10_000}, &grpcoin.Amount{Units: 1}, ""); err != nil {
+		t.Fatal(err)
+	}
+	got := recv()
+	want := &grpcoin.PortfolioUpdate{
+		T:       got.GetT(),
+		CashUsd: &grpcoin.Amount{Units: 90_000},
+		Positions: []*grpcoin.PositionValuation{
+			{Currency: &grpcoin.Currency{Symbol: "BTC"},
+				Amount:        &grpcoin.Amount{Units: 1},
+				Price:         &grpcoin.Amount{Units: 20_000},
+				Value:         &grpcoin.Amount{Units: 20_000},
+				CostBasis:     &grpcoin.Amount{Units: 10_000},
+				UnrealizedPnl: &grpcoin.Amount{Units: 10_000}},
+		},
+		TotalValue:    &grpcoin.Amount{Units: 110_000},
+		UnrealizedPnl: &grpcoin.Amount{Units: 10_000},
+		RealizedPnl:   &grpcoin.Amount{},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestWatchPortfolio_subscriptionEnded(t *testing.T) {
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	rc := testutil.MockRedis(t)
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
+	pt := &tradingService{udb: udb, tracer: tp,
+		supportedTickers:       tickers.FromSymbols("BTC"),
+		quoteProvider:          &mockQuoteProvider{a: &grpcoin.Amount{Units: 20_000}},
+		portfolioChanges:       &notifications.Notifier{DB: rc},
+		portfolioWatchInterval: time.Hour}
+
+	ctx, cancel := context.WithTimeout(auth.WithUser(context.Background(), &github.GitHubUser{ID: 1, Username: "abc"}), time.Second*5)
+	defer cancel()
+	stream := &portfolioStream{ctx: ctx, ch: make(chan *grpcoin.PortfolioUpdate, 1)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- pt.WatchPortfolio(&grpcoin.WatchPortfolioRequest{}, stream)
+	}()
+	select {
+	case <-stream.ch:
+	case <-ctx.Done():
+		t.Fatal("portfolio update not received")
+	}
+	rc.Close()
+	select {
+	case err := <-errCh:
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("expected Unavailable, got: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("stream did not end with the subscription")
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+)
+
+// bookEntry is an entry of a quoteBook (e.g. an open order).
+type bookEntry interface {
+	// key returns the ticker and the unique id of the entry.
+	key() (ticker, id string)
+	// created returns when the entry was created.
+	created() time.Time
+	// triggeredBy reports whether the quote price meets the entry's
+	// condition.
+	triggeredBy(price *grpcoin.Amount) bool
+}
+
+// quoteBook keeps track of entries by ticker and triggers them when the
+// real-time quotes meet their conditions. Each entry is triggered at most
+// once at a time, in its own goroutine.
+type quoteBook struct {
+	fanout          *fanout.QuoteFanoutService
+	log             *zap.Logger
+	refreshInterval time.Duration
+
+	// load returns the entries stored in the database. It's invoked
+	// periodically to learn about the entries changed on other instances.
+	load func(ctx context.Context) ([]bookEntry, error)
+	// trigger is invoked when the quote price meets the entry's condition.
+	// It's responsible for removing the entry from the book.
+	trigger func(ctx context.Context, e bookEntry, price *grpcoin.Amount)
+
+	mu       sync.Mutex
+	entries  map[string]map[string]bookEntry // ticker -> id -> entry
+	inflight map[string]bool                 // ids being triggered
+}
+
+func newQuoteBook(f *fanout.QuoteFanoutService, log *zap.Logger, refreshInterval time.Duration,
+	load func(context.Context) ([]bookEntry, error),
+	trigger func(context.Context, bookEntry, *grpcoin.Amount)) *quoteBook {
+	return &quoteBook{
+		fanout:          f,
+		log:             log,
+		refreshInterval: refreshInterval,
+		load:            load,
+		trigger:         trigger,
+		entries:         make(map[string]map[string]bookEntry),
+		inflight:        make(map[string]bool),
+	}
+}
+
+// add starts tracking an entry.
+func (b *quoteBook) add(e bookEntry) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	addEntry(b.entries, e)
+}
+
+func addEntry(entries map[string]map[string]bookEntry, e bookEntry) {
+	ticker, id := e.key()
+	if entries[ticker] == nil {
+		entries[ticker] = make(map[string]bookEntry)
+	}
+	entries[ticker][id] = e
+}
+
+// remove stops tracking the entry with the id.
+func (b *quoteBook) remove(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, entries := range b.entries {
+		delete(entries, id)
+	}
+}
+
+// run matches the entries against the quote stream until ctx is done. It is
+// meant to be invoked in a goroutine.
+func (b *quoteBook) run(ctx context.Context) {
+	go b.refreshLoop(ctx)
+	for ctx.Err() == nil {
+		watchCtx, cancel := context.WithCancel(ctx)
+		ch, err := b.fanout.RegisterWatch(watchCtx)
+		if err != nil {
+			cancel()
+			b.log.Warn("failed to register quote watch", zap.Error(err))
+			time.Sleep(realtimequote.DefaultReconnectInterval)
+			continue
+		}
+		for q := range ch {
+			b.match(ctx, q)
+		}
+		cancel()
+		if ctx.Err() == nil {
+			b.log.Warn("quote stream closed, re-registering")
+			time.Sleep(realtimequote.DefaultReconnectInterval)
+		}
+	}
+}
+
+func (b *quoteBook) match(ctx context.Context, q realtimequote.Quote) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, e := range b.entries[q.Product] {
+		if b.inflight[id] || !e.triggeredBy(q.Price) {
+			continue
+		}
+		b.inflight[id] = true
+		go b.fire(ctx, id, e, q.Price)
+	}
+}
+
+func (b *quoteBook) fire(ctx context.Context, id string, e bookEntry, price *grpcoin.Amount) {
+	defer func() {
+		b.mu.Lock()
+		delete(b.inflight, id)
+		b.mu.Unlock()
+	}()
+	b.trigger(ctx, e, price)
+}
+
+func (b *quoteBook) refreshLoop(ctx context.Context) {
+	tick := time.NewTicker(b.refreshInterval)
+	defer tick.Stop()
+	for {
+		if err := b.refresh(ctx); err != nil {
+			b.log.Warn("failed to refresh the book", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+// refresh reloads the entries from the database.
+func (b *quoteBook) refresh(ctx context.Context) error {
+	start := time.Now()
+	loaded, err := b.load(ctx)
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]map[string]bookEntry)
+	for _, e := range loaded {
+		addEntry(entries, e)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// retain entries added while the query was running
+	for _, v := range b.entries {
+		for _, e := range v {
+			if e.created().After(start) {
+				addEntry(entries, e)
+			}
+		}
+	}
+	b.entries = entries
+	return nil
+}
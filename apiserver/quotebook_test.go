@@ -0,0 +1,74 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/realtimequote"
+)
+
+type testEntry struct {
+	id        string
+	createdAt time.Time
+	above     int64
+}
+
+func (e testEntry) key() (string, string) { return "BTC", e.id }
+func (e testEntry) created() time.Time    { return e.createdAt }
+
+func (e testEntry) triggeredBy(price *grpcoin.Amount) bool { return price.GetUnits() > e.above }
+
+func TestQuoteBook(t *testing.T) {
+	var b *quoteBook
+	triggered := make(chan bookEntry)
+	release := make(chan struct{})
+	b = newQuoteBook(nil, zap.NewNop(), time.Minute,
+		func(context.Context) ([]bookEntry, error) {
+			// added while the query is running
+			b.add(testEntry{id: "new", createdAt: time.Now(), above: 100})
+			return []bookEntry{testEntry{id: "stored", above: 200}}, nil
+		},
+		func(_ context.Context, e bookEntry, _ *grpcoin.Amount) {
+			triggered <- e
+			<-release
+		})
+	b.add(testEntry{id: "removed", above: 0})
+	if err := b.refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.entries["BTC"]) != 2 || b.entries["BTC"]["removed"] != nil {
+		t.Fatalf("wrong entries after refresh: %v", b.entries)
+	}
+
+	q := realtimequote.Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 150}}
+	b.match(context.Background(), q)
+	if e := <-triggered; e.(testEntry).id != "new" {
+		t.Fatalf("wrong entry triggered: %v", e)
+	}
+	// not triggered again while in flight
+	b.match(context.Background(), q)
+	select {
+	case e := <-triggered:
+		t.Fatalf("entry triggered while in flight: %v", e)
+	case <-time.After(time.Millisecond * 50):
+	}
+	close(release)
+}
@@ -21,27 +21,41 @@ import (
 	"strings"
 	"time"
 
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/grpcoin/grpcoin/api/grpcoin"
 	"github.com/grpcoin/grpcoin/realtimequote"
 	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/realtimequote/pubsub"
+	"github.com/grpcoin/grpcoin/tickers"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type tickerService struct {
-	supportedTickers []string
+	supportedTickers *tickers.Universe
 	maxRate          time.Duration
 	fanout           *fanout.QuoteFanoutService
+	// subOptions configure buffering of the quotes for slow clients.
+	subOptions pubsub.SubOptions
 
 	grpcoin.UnimplementedTickerInfoServer
 }
 
-func filterByProduct(ch <-chan realtimequote.Quote, product string) <-chan realtimequote.Quote {
+// quoteStream is implemented by the server streams of TickerInfo methods.
+type quoteStream interface {
+	Send(*grpcoin.Quote) error
+	Context() context.Context
+}
+
+func filterByProduct(ch <-chan realtimequote.Quote, products map[string]bool) <-chan realtimequote.Quote {
 	outCh := make(chan realtimequote.Quote)
 	go func() {
 		for m := range ch {
-			if m.Product == product {
+			if products[m.Product] {
 				outCh <- m
 			}
 		}
@@ -55,19 +69,47 @@ func (ts *tickerService) Watch(req *grpcoin.TickerWatchRequest, stream grpcoin.T
 
 	req.Currency.Symbol = strings.TrimSuffix(req.GetCurrency().GetSymbol(), "-USD")
 
-	if !realtimequote.IsSupported(ts.supportedTickers, req.Currency.Symbol) {
-		return status.Errorf(codes.InvalidArgument, "only supported tickers are %#v", ts.supportedTickers)
+	if !ts.supportedTickers.IsSupported(req.Currency.Symbol) {
+		return status.Errorf(codes.InvalidArgument, "only supported tickers are %#v", ts.supportedTickers.Symbols())
+	}
+	return ts.watch(stream, map[string]bool{req.Currency.Symbol: true})
+}
+
+func (ts *tickerService) WatchMany(req *grpcoin.TickerWatchManyRequest, stream grpcoin.TickerInfo_WatchManyServer) error {
+	products := make(map[string]bool)
+	for _, c := range req.GetCurrencies() {
+		sym := strings.TrimSuffix(c.GetSymbol(), "-USD")
+		if !ts.supportedTickers.IsSupported(sym) {
+			return status.Errorf(codes.InvalidArgument, "only supported tickers are %#v", ts.supportedTickers.Symbols())
+		}
+		products[sym] = true
+	}
+	if len(products) == 0 {
+		for _, sym := range ts.supportedTickers.Symbols() {
+			products[sym] = true
+		}
 	}
-	ch, err := ts.fanout.RegisterWatch(stream.Context())
+	return ts.watch(stream, products)
+}
+
+// watch streams the quotes of the specified products, throttled separately
+// for each product, until the client disconnects or the quotes stop.
+// The number of quotes dropped for a slow client is reported in the
+// "dropped-quotes" trailer, and a client disconnected for dropping too many
+// quotes gets ResourceExhausted.
+func (ts *tickerService) watch(stream quoteStream, products map[string]bool) error {
+	ch, sub, err := ts.fanout.RegisterWatchWithOptions(stream.Context(), ts.subOptions)
 	if err != nil {
 		return status.Error(codes.Internal, fmt.Sprintf("failed to register ticker watch: %v", err))
 	}
-	ch = filterByProduct(ch, req.Currency.Symbol)
+	defer reportDropped(stream.Context(), sub)
+	ch = filterByProduct(ch, products)
 	ch = realtimequote.RateLimited(ch, ts.maxRate)
 	for m := range ch {
 		err = stream.Send(&grpcoin.Quote{
-			T:     timestamppb.New(m.Time),
-			Price: m.Price,
+			T:        timestamppb.New(m.Time),
+			Price:    m.Price,
+			Currency: &grpcoin.Currency{Symbol: m.Product},
 		})
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
@@ -84,6 +126,22 @@ func (ts *tickerService) Watch(req *grpcoin.TickerWatchRequest, stream grpcoin.T
 		}
 		return status.Error(codes.Internal, fmt.Sprintf("unknown error on ctx: %v", err))
 	default:
+		if sub.Disconnected() {
+			return status.Errorf(codes.ResourceExhausted,
+				"disconnected for falling behind (dropped %d quotes), please retry by reconnecting", sub.Dropped())
+		}
 		return status.Error(codes.Internal, "failed to get prices, please retry by reconnecting")
 	}
 }
+
+// reportDropped logs the number of quotes dropped for the subscriber and sets
+// it to the trailer of the stream.
+func reportDropped(ctx context.Context, sub *pubsub.Subscription) {
+	n := sub.Dropped()
+	if n == 0 {
+		return
+	}
+	ctxzap.Extract(ctx).Info("dropped quotes for slow client",
+		zap.Int64("dropped", n), zap.Bool("disconnected", sub.Disconnected()))
+	_ = grpc.SetTrailer(ctx, metadata.Pairs("dropped-quotes", fmt.Sprint(n)))
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -25,6 +26,8 @@ import (
 	"github.com/grpcoin/grpcoin/api/grpcoin"
 	"github.com/grpcoin/grpcoin/realtimequote"
 	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/realtimequote/pubsub"
+	"github.com/grpcoin/grpcoin/tickers"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -33,18 +36,22 @@ import (
 
 func prepTickerService(t *testing.T) *grpc.ClientConn {
 	t.Helper()
-	l := bufconn.Listen(1024)
-	srv := grpc.NewServer()
-	ts := &tickerService{
+	return serveTickerService(t, &tickerService{
 		maxRate:          time.Millisecond,
-		supportedTickers: []string{"BTC"},
+		supportedTickers: tickers.FromSymbols("BTC"),
 		fanout: fanout.NewQuoteFanoutService(func(ctx context.Context) (<-chan realtimequote.Quote, error) {
 			return (mockQuoteStream{
 				product: "BTC",
 				n:       10,
 				price:   &grpcoin.Amount{Units: 35_000}}).Watch(ctx)
 		}),
-	}
+	})
+}
+
+func serveTickerService(t *testing.T, ts *tickerService, opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+	l := bufconn.Listen(1024)
+	srv := grpc.NewServer(opts...)
 	grpcoin.RegisterTickerInfoServer(srv, ts)
 	go srv.Serve(l)
 	t.Cleanup(func() {
@@ -177,3 +184,137 @@ func TestWatchMulti(t *testing.T) {
 	}
 	t.Logf("%#v", c)
 }
+
+// multiQuoteStream emits n quotes, cycling through the products.
+func multiQuoteStream(ctx context.Context, n int, products ...string) (<-chan realtimequote.Quote, error) {
+	ch := make(chan realtimequote.Quote)
+	go func() {
+		defer close(ch)
+		tick := time.NewTicker(time.Millisecond * 10)
+		defer tick.Stop()
+		for i := 0; i < n; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case t := <-tick.C:
+				ch <- realtimequote.Quote{Product: products[i%len(products)], Price: &grpcoin.Amount{Units: 1}, Time: t}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func TestWatchMany(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *grpcoin.TickerWatchManyRequest
+		want    map[string]bool
+		wantErr codes.Code
+	}{
+		{
+			name:    "unsupported",
+			req:     &grpcoin.TickerWatchManyRequest{Currencies: []*grpcoin.Currency{{Symbol: "BTC"}, {Symbol: "FOO"}}},
+			wantErr: codes.InvalidArgument,
+		},
+		{
+			name: "subset",
+			req:  &grpcoin.TickerWatchManyRequest{Currencies: []*grpcoin.Currency{{Symbol: "BTC"}, {Symbol: "ETH-USD"}}},
+			want: map[string]bool{"BTC": true, "ETH": true},
+		},
+		{
+			name: "all",
+			req:  &grpcoin.TickerWatchManyRequest{},
+			want: map[string]bool{"BTC": true, "ETH": true, "DOGE": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// each subtest gets its own service, so the quotes of the finite
+			// stream are not consumed by the previous subtests
+			client := grpcoin.NewTickerInfoClient(serveTickerService(t, &tickerService{
+				maxRate:          time.Millisecond,
+				supportedTickers: tickers.FromSymbols("BTC", "ETH", "DOGE"),
+				fanout: fanout.NewQuoteFanoutService(func(ctx context.Context) (<-chan realtimequote.Quote, error) {
+					return multiQuoteStream(ctx, 60, "BTC", "ETH", "DOGE")
+				}),
+			}))
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			stream, err := client.WatchMany(ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]bool)
+			for tt.wantErr != codes.OK || len(got) < len(tt.want) {
+				m, err := stream.Recv()
+				if err != nil {
+					if tt.wantErr != codes.OK && status.Code(err) == tt.wantErr {
+						return
+					}
+					t.Fatalf("recv failed (got so far: %v): %v", got, err)
+				}
+				if tt.wantErr != codes.OK {
+					t.Fatalf("expected error %v, got quote", tt.wantErr)
+				}
+				sym := m.GetCurrency().GetSymbol()
+				if !tt.want[sym] {
+					t.Fatalf("received unexpected symbol %q", sym)
+				}
+				got[sym] = true
+			}
+		})
+	}
+}
+
+// slowStream delays sending each message.
+type slowStream struct {
+	grpc.ServerStream
+	delay time.Duration
+}
+
+func (s slowStream) SendMsg(m interface{}) error {
+	time.Sleep(s.delay)
+	return s.ServerStream.SendMsg(m)
+}
+
+func TestWatch_reportsDroppedQuotes(t *testing.T) {
+	slow := grpc.StreamInterceptor(func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, slowStream{ss, time.Millisecond * 50})
+	})
+	client := grpcoin.NewTickerInfoClient(serveTickerService(t, &tickerService{
+		maxRate:          time.Millisecond,
+		supportedTickers: tickers.FromSymbols("BTC"),
+		fanout: fanout.NewQuoteFanoutService(func(ctx context.Context) (<-chan realtimequote.Quote, error) {
+			return multiQuoteStream(ctx, 20, "BTC")
+		}),
+		subOptions: pubsub.SubOptions{Policy: pubsub.KeepLatestPerProduct, MaxDrops: 3},
+	}, slow))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	stream, err := client.Watch(ctx, &grpcoin.TickerWatchRequest{Currency: &grpcoin.Currency{Symbol: "BTC"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if status.Code(err) != codes.ResourceExhausted {
+				t.Fatalf("expected slow client to be disconnected, got: %v", err)
+			}
+			break
+		}
+		n++
+	}
+	v := stream.Trailer().Get("dropped-quotes")
+	if len(v) != 1 {
+		t.Fatalf("expected dropped-quotes trailer, got: %v", stream.Trailer())
+	}
+	dropped, err := strconv.Atoi(v[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dropped != 3 || n+dropped > 20 {
+		t.Fatalf("got %d quotes and %d dropped, out of 20", n, dropped)
+	}
+}
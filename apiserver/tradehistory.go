@@ -0,0 +1,82 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func (t *tradingService) TradeHistory(ctx context.Context, req *grpcoin.TradeHistoryRequest) (*grpcoin.TradeHistoryResponse, error) {
+	user, ok := userdb.UserRecordFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "could not find user record in request context")
+	}
+	f, err := toTradeFilter(req, t.supportedTickers.Symbols())
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := toPageSize(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+	trades, next, err := t.udb.TradeHistory(ctx, user.ID, f, pageSize, req.GetPageToken())
+	if status.Code(err) == codes.InvalidArgument {
+		return nil, err
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query trade history: %v", err)
+	}
+	out := &grpcoin.TradeHistoryResponse{NextPageToken: next}
+	for _, tr := range trades {
+		out.Trades = append(out.Trades, toTradeRecordProto(tr))
+	}
+	return out, nil
+}
+
+func toTradeFilter(req *grpcoin.TradeHistoryRequest, supportedTickers []string) (userdb.TradeFilter, error) {
+	ticker := req.GetCurrency().GetSymbol()
+	if err := validateTickerFilter(ticker, supportedTickers); err != nil {
+		return userdb.TradeFilter{}, err
+	}
+	since, until, err := toTimeRange(req.GetStartTime(), req.GetEndTime())
+	if err != nil {
+		return userdb.TradeFilter{}, err
+	}
+	f := userdb.TradeFilter{Ticker: ticker, Since: since, Until: until}
+	return f, nil
+}
+
+func toTradeRecordProto(tr userdb.TradeRecord) *grpcoin.TradeRecord {
+	out := &grpcoin.TradeRecord{
+		T:             timestamppb.New(tr.Date),
+		Action:        tr.Action,
+		Currency:      &grpcoin.Currency{Symbol: tr.Ticker},
+		Quantity:      tr.Size.V(),
+		ExecutedPrice: tr.Price.V(),
+		OrderId:       tr.OrderID,
+		ClientOrderId: tr.ClientOrderID,
+		Fee:           tr.Fee.V(),
+	}
+	if tr.TriggerPrice != nil {
+		out.TriggerPrice = tr.TriggerPrice.V()
+	}
+	return out
+}
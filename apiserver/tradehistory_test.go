@@ -0,0 +1,150 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/auth"
+	"github.com/grpcoin/grpcoin/apiserver/auth/github"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tickers"
+	"github.com/grpcoin/grpcoin/tradecounters"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func Test_toTradeFilter(t *testing.T) {
+	t1 := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	tests := []struct {
+		name string
+		req  *grpcoin.TradeHistoryRequest
+		want userdb.TradeFilter
+		code codes.Code
+	}{
+		{name: "empty",
+			req:  &grpcoin.TradeHistoryRequest{},
+			want: userdb.TradeFilter{}},
+		{name: "all filters",
+			req: &grpcoin.TradeHistoryRequest{
+				Currency:  &grpcoin.Currency{Symbol: "BTC"},
+				StartTime: timestamppb.New(t1),
+				EndTime:   timestamppb.New(t2)},
+			want: userdb.TradeFilter{Ticker: "BTC", Since: t1, Until: t2}},
+		{name: "unsupported ticker",
+			req:  &grpcoin.TradeHistoryRequest{Currency: &grpcoin.Currency{Symbol: "XXX"}},
+			code: codes.InvalidArgument},
+		{name: "invalid timestamp",
+			req:  &grpcoin.TradeHistoryRequest{StartTime: &timestamppb.Timestamp{Nanos: -1}},
+			code: codes.InvalidArgument},
+		{name: "reverse time range",
+			req:  &grpcoin.TradeHistoryRequest{StartTime: timestamppb.New(t2), EndTime: timestamppb.New(t1)},
+			code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toTradeFilter(tt.req, []string{"BTC"})
+			if status.Code(err) != tt.code {
+				t.Fatalf("toTradeFilter() error = %v, wantErr %s", err, tt.code)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+type countingRateLimiter struct{ hits int }
+
+func (c *countingRateLimiter) Hit(ctx context.Context, id string, max int64) error {
+	c.hits++
+	return nil
+}
+
+func TestTradeHistory(t *testing.T) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tp := trace.NewNoopTracerProvider().Tracer("")
+	au := auth.MockAuthenticator{
+		F: func(c context.Context) (auth.AuthenticatedUser, error) {
+			return &github.GitHubUser{ID: 6, Username: "pqr"}, nil
+		},
+	}
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
+		Cache:        userdb.MockProfileCache{},
+		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
+	rl := &countingRateLimiter{}
+	pt := &tradingService{udb: udb, tracer: tp, supportedTickers: tickers.FromSymbols("BTC"),
+		quoteProvider: &mockQuoteProvider{a: &grpcoin.Amount{Units: 30_000}}}
+	srv := prepServer(zap.NewNop(), au, rl, udb, nil, nil, pt, nil, nil)
+	go srv.Serve(l)
+	defer srv.Stop()
+	defer l.Close()
+
+	cc, err := grpc.Dial(l.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := grpcoin.NewPaperTradeClient(cc)
+	for i := 0; i < 3; i++ {
+		if _, err := client.Trade(context.TODO(), &grpcoin.TradeRequest{
+			Action:   grpcoin.TradeAction_BUY,
+			Currency: &grpcoin.Currency{Symbol: "BTC"},
+			Quantity: &grpcoin.Amount{Units: 1},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resp, err := client.TradeHistory(context.TODO(), &grpcoin.TradeHistoryRequest{PageSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetTrades()) != 2 || resp.GetNextPageToken() == "" {
+		t.Fatalf("expected first page with 2 trades and a next page token, got: %v", resp)
+	}
+	resp, err = client.TradeHistory(context.TODO(), &grpcoin.TradeHistoryRequest{PageSize: 2,
+		PageToken: resp.GetNextPageToken()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetTrades()) != 1 || resp.GetNextPageToken() != "" {
+		t.Fatalf("expected last page with 1 trade, got: %v", resp)
+	}
+	if got := resp.GetTrades()[0].GetExecutedPrice().GetUnits(); got != 30_000 {
+		t.Fatalf("wrong executed price: %d", got)
+	}
+	if expected := 5; rl.hits != expected {
+		t.Fatalf("expected %d rate limiter hits, got %d", expected, rl.hits)
+	}
+
+	if _, err := client.TradeHistory(context.TODO(), &grpcoin.TradeHistoryRequest{PageSize: 1000}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for page size, got: %v", err)
+	}
+}
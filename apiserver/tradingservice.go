@@ -17,16 +17,22 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/notifications"
 	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/tickers"
 	"github.com/grpcoin/grpcoin/userdb"
 )
 
@@ -34,7 +40,13 @@ type tradingService struct {
 	udb              *userdb.UserDB
 	quoteProvider    realtimequote.QuoteProvider
 	tracer           trace.Tracer
-	supportedTickers []string
+	supportedTickers *tickers.Universe
+	orderMatcher     *orderMatcher
+
+	// portfolioChanges and portfolioWatchInterval are used by
+	// WatchPortfolio to send portfolio updates.
+	portfolioChanges       *notifications.Notifier
+	portfolioWatchInterval time.Duration
 
 	grpcoin.UnimplementedPaperTradeServer
 }
@@ -46,22 +58,72 @@ func (t *tradingService) Portfolio(ctx context.Context, req *grpcoin.PortfolioRe
 	if !ok {
 		return nil, status.Error(codes.Internal, "could not find user record in request context")
 	}
-	return &grpcoin.PortfolioResponse{
-		CashUsd:   user.Portfolio.CashUSD.V(),
-		Positions: toPortfolioPositions(user.Portfolio.Positions),
-	}, nil
+	resp := &grpcoin.PortfolioResponse{
+		CashUsd:          user.Portfolio.CashUSD.V(),
+		Positions:        toPortfolioPositionsWithReservations(user.Portfolio),
+		ReservedCashUsd:  user.Portfolio.ReservedCashUSD.V(),
+		AvailableCashUsd: user.Portfolio.AvailableCashUSD().V(),
+		RealizedPnl:      user.Portfolio.RealizedPnL.V(),
+	}
+
+	// valuation is best-effort, so that the portfolio can be read while the
+	// quotes are not available.
+	prices, err := positionPrices(ctx, t.quoteProvider, user.Portfolio)
+	if err != nil {
+		ctxzap.Extract(ctx).Warn("failed to get quotes for portfolio valuation", zap.Error(err))
+		return resp, nil
+	}
+	ms, err := t.udb.Margin.Status(user.Portfolio, prices)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to value portfolio: %v", err)
+	}
+	resp.Equity = ms.Equity.V()
+	resp.MarginUsed = ms.MarginUsed.V()
+	resp.BuyingPower = ms.BuyingPower.V()
+	resp.UnrealizedPnl = user.Portfolio.TotalUnrealizedPnL(prices).V()
+	for _, v := range resp.Positions {
+		ticker := v.GetCurrency().GetSymbol()
+		if pnl, ok := user.Portfolio.UnrealizedPnL(ticker, prices[ticker]); ok {
+			v.UnrealizedPnl = pnl.V()
+		}
+	}
+	return resp, nil
+}
+
+// positionPrices returns the current prices of the positions in the portfolio.
+func positionPrices(ctx context.Context, qp realtimequote.QuoteProvider, p userdb.Portfolio) (map[string]userdb.Amount, error) {
+	ctx, cancel := context.WithTimeout(ctx, quoteDeadline)
+	defer cancel()
+	out := make(map[string]userdb.Amount, len(p.Positions))
+	for ticker := range p.Positions {
+		q, err := qp.GetQuote(ctx, ticker)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s quote: %w", ticker, err)
+		}
+		out[ticker] = userdb.Amount{Units: q.GetUnits(), Nanos: q.GetNanos()}
+	}
+	return out, nil
 }
 
 const (
 	quoteDeadline          = time.Second * 2
 	tradeExecutionDeadline = time.Second * 1
+
+	defaultPageSize = 50
+	maxPageSize     = 100
 )
 
+var clientOrderIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
 func (t *tradingService) ListSupportedCurrencies(ctx context.Context,
 	_ *grpcoin.ListSupportedCurrenciesRequest) (*grpcoin.ListSupportedCurrenciesResponse, error) {
 	var out []*grpcoin.Currency
-	for _, v := range t.supportedTickers {
-		out = append(out, &grpcoin.Currency{Symbol: v})
+	for _, v := range t.supportedTickers.Tickers() {
+		c := &grpcoin.Currency{Symbol: v.Symbol, Name: v.Name, PricePrecision: v.PricePrecision}
+		if !v.MinTradeSize.IsZero() {
+			c.MinTradeSize = userdb.ToAmount(v.MinTradeSize).V()
+		}
+		out = append(out, c)
 	}
 	return &grpcoin.ListSupportedCurrenciesResponse{SupportedCurrencies: out}, nil
 }
@@ -71,7 +133,10 @@ func (t *tradingService) Trade(ctx context.Context, req *grpcoin.TradeRequest) (
 	if !ok {
 		return nil, status.Error(codes.Internal, "could not find user record in request context")
 	}
-	if err := validateTradeRequest(req, t.supportedTickers); err != nil {
+	if err := validateTradeRequest(req, t.supportedTickers.Symbols()); err != nil {
+		return nil, err
+	}
+	if err := validateTradeSize(req.GetQuantity(), t.supportedTickers, req.GetCurrency().GetSymbol()); err != nil {
 		return nil, err
 	}
 	product := req.GetCurrency().GetSymbol()
@@ -95,21 +160,22 @@ func (t *tradingService) Trade(ctx context.Context, req *grpcoin.TradeRequest) (
 	defer s.End()
 	tradeCtx, cancel2 := context.WithTimeout(subCtx, tradeExecutionDeadline)
 	defer cancel2()
-	newPortfolio, err := t.udb.Trade(tradeCtx, user.ID, product, req.Action, quote, req.Quantity)
+	tr, newPortfolio, err := t.udb.Trade(tradeCtx, user.ID, product, req.Action, quote, req.Quantity, req.GetClientOrderId())
 	if errors.Is(err, context.DeadlineExceeded) {
 		return nil, status.Errorf(codes.Unavailable, "could not execute trade in a timely manner: %v", err)
-	} else if status.Code(err) == codes.InvalidArgument {
+	} else if c := status.Code(err); c == codes.InvalidArgument || c == codes.AlreadyExists {
 		return nil, err
 	} else if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to execute trade: %v", err)
 	}
 
 	return &grpcoin.TradeResponse{
-		T:             timestamppb.Now(), // TODO read from tx
-		Action:        req.Action,
-		ExecutedPrice: quote,
-		Currency:      &grpcoin.Currency{Symbol: product},
-		Quantity:      req.Quantity,
+		T:             timestamppb.New(tr.Date),
+		Action:        tr.Action,
+		ExecutedPrice: tr.Price.V(),
+		Currency:      &grpcoin.Currency{Symbol: tr.Ticker},
+		Quantity:      tr.Size.V(),
+		Fee:           tr.Fee.V(),
 		ResultingPortfolio: &grpcoin.TradeResponse_Portfolio{
 			RemainingCash: newPortfolio.CashUSD.V(),
 			Positions:     toPortfolioPositions(newPortfolio.Positions),
@@ -137,6 +203,9 @@ func validateTradeRequest(req *grpcoin.TradeRequest, supportedTickers []string)
 		return status.Errorf(codes.InvalidArgument, "ticker '%s' is not supported, must be [%s]", req.GetCurrency().GetSymbol(),
 			strings.Join(supportedTickers, ", "))
 	}
+	if id := req.GetClientOrderId(); id != "" && !clientOrderIDPattern.MatchString(id) {
+		return status.Errorf(codes.InvalidArgument, "invalid client order id %q, must match %s", id, clientOrderIDPattern)
+	}
 	return nil
 }
 
@@ -150,3 +219,69 @@ func toPortfolioPositions(pos map[string]userdb.Amount) []*grpcoin.PortfolioPosi
 	}
 	return pp
 }
+
+// toPortfolioPositionsWithReservations is like toPortfolioPositions, but also
+// reports the amounts reserved for open orders and the cost basis.
+func toPortfolioPositionsWithReservations(p userdb.Portfolio) []*grpcoin.PortfolioPosition {
+	pp := toPortfolioPositions(p.Positions)
+	for _, v := range pp {
+		ticker := v.GetCurrency().GetSymbol()
+		v.Reserved = p.ReservedPositions[ticker].V()
+		v.Available = p.AvailablePosition(ticker).V()
+		if cb, ok := p.CostBasis[ticker]; ok {
+			v.CostBasis = cb.V()
+		}
+	}
+	return pp
+}
+
+// toPageSize validates the page size specified on a request, and applies
+// the default if it is not specified.
+func toPageSize(v int32) (int, error) {
+	if v < 0 || v > maxPageSize {
+		return 0, status.Errorf(codes.InvalidArgument, "page size must be between 0 and %d", maxPageSize)
+	} else if v == 0 {
+		return defaultPageSize, nil
+	}
+	return int(v), nil
+}
+
+// validateTradeSize validates the quantity of a trade or an order is at least
+// the minimum trade size of the ticker.
+func validateTradeSize(quantity *grpcoin.Amount, u *tickers.Universe, ticker string) error {
+	t, _ := u.Get(ticker)
+	if t.MinTradeSize.IsZero() || !toAmount(quantity).F().LessThan(t.MinTradeSize) {
+		return nil
+	}
+	return status.Errorf(codes.InvalidArgument, "quantity is below the minimum trade size of %s (%s)", ticker, t.MinTradeSize)
+}
+
+// validateTickerFilter validates the optional ticker used to filter results.
+func validateTickerFilter(ticker string, supportedTickers []string) error {
+	if ticker != "" && !realtimequote.IsSupported(supportedTickers, ticker) {
+		return status.Errorf(codes.InvalidArgument, "ticker '%s' is not supported, must be [%s]", ticker,
+			strings.Join(supportedTickers, ", "))
+	}
+	return nil
+}
+
+// toTimeRange validates the optional start (inclusive) and end (exclusive)
+// times specified on a request. Unspecified times are returned as zero.
+func toTimeRange(startTS, endTS *timestamppb.Timestamp) (start, end time.Time, err error) {
+	if startTS != nil {
+		if err := startTS.CheckValid(); err != nil {
+			return start, end, status.Errorf(codes.InvalidArgument, "invalid start time: %v", err)
+		}
+		start = startTS.AsTime()
+	}
+	if endTS != nil {
+		if err := endTS.CheckValid(); err != nil {
+			return start, end, status.Errorf(codes.InvalidArgument, "invalid end time: %v", err)
+		}
+		end = endTS.AsTime()
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return start, end, status.Error(codes.InvalidArgument, "start time must be before end time")
+	}
+	return start, end, nil
+}
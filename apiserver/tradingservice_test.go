@@ -24,14 +24,16 @@ import (
 	"github.com/grpcoin/grpcoin/realtimequote"
 	"github.com/grpcoin/grpcoin/testutil"
 	"github.com/grpcoin/grpcoin/tradecounters"
+	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
 
 	"github.com/grpcoin/grpcoin/api/grpcoin"
 	"github.com/grpcoin/grpcoin/apiserver/auth"
 	"github.com/grpcoin/grpcoin/apiserver/auth/github"
-	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+	"github.com/grpcoin/grpcoin/tickers"
 	"github.com/grpcoin/grpcoin/userdb"
 )
 
@@ -70,16 +72,15 @@ func (m *mockQuoteProvider) GetQuote(_ context.Context, _ string) (*grpcoin.Amou
 }
 
 func TestPortfolio(t *testing.T) {
-	fs := firestoreutil.StartTestEmulator(t, context.TODO())
 	tp := trace.NewNoopTracerProvider().Tracer("")
-	udb := &userdb.UserDB{DB: fs, T: tp, Cache: userdb.MockProfileCache{}}
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp, Cache: userdb.MockProfileCache{}}
 
 	au := &github.GitHubUser{ID: 1, Username: "abc"}
 	user, err := udb.EnsureAccountExists(context.TODO(), au)
 	if err != nil {
 		t.Fatal(err)
 	}
-	pt := &tradingService{udb: udb, tracer: tp, supportedTickers: []string{"BTC"}}
+	pt := &tradingService{udb: udb, tracer: tp, supportedTickers: tickers.FromSymbols("BTC")}
 	ctx := auth.WithUser(context.Background(), au)
 	ctx = userdb.WithUserRecord(ctx, user)
 
@@ -89,8 +90,15 @@ func TestPortfolio(t *testing.T) {
 	}
 
 	expected := &grpcoin.PortfolioResponse{
-		CashUsd:   &grpcoin.Amount{Units: 100_000, Nanos: 0},
-		Positions: nil,
+		CashUsd:          &grpcoin.Amount{Units: 100_000, Nanos: 0},
+		Positions:        nil,
+		ReservedCashUsd:  &grpcoin.Amount{},
+		AvailableCashUsd: &grpcoin.Amount{Units: 100_000},
+		Equity:           &grpcoin.Amount{Units: 100_000},
+		MarginUsed:       &grpcoin.Amount{},
+		BuyingPower:      &grpcoin.Amount{Units: 100_000},
+		RealizedPnl:      &grpcoin.Amount{},
+		UnrealizedPnl:    &grpcoin.Amount{},
 	}
 
 	diff := cmp.Diff(resp, expected, cmpopts.IgnoreUnexported(
@@ -105,32 +113,69 @@ func TestPortfolio(t *testing.T) {
 }
 
 func TestSupportedCurrencies(t *testing.T) {
-	ts := &tradingService{}
+	u := tickers.NewUniverse([]tickers.Ticker{
+		{Symbol: "BTC", Name: "Bitcoin", MinTradeSize: decimal.RequireFromString("0.0001"), PricePrecision: 2},
+		{Symbol: "DOGE", Name: "Dogecoin", PricePrecision: 5},
+	})
+	ts := &tradingService{supportedTickers: u}
 	resp, err := ts.ListSupportedCurrencies(context.TODO(), &grpcoin.ListSupportedCurrenciesRequest{})
 	if err != nil {
 		t.Fatal(err)
-		if len(resp.SupportedCurrencies) != len(realtimequote.SupportedTickers) {
-			t.Fatalf("expected %d currencies, got %#v", len(realtimequote.SupportedTickers), resp.SupportedCurrencies)
-		}
 	}
-	for _, ticker := range resp.SupportedCurrencies {
-		if !realtimequote.IsSupported(realtimequote.SupportedTickers, ticker.GetSymbol()) {
-			t.Fatalf("received unsupported ticker %s", ticker.GetSymbol())
-		}
+	expected := []*grpcoin.Currency{
+		{Symbol: "BTC", Name: "Bitcoin", MinTradeSize: &grpcoin.Amount{Nanos: 100_000}, PricePrecision: 2},
+		{Symbol: "DOGE", Name: "Dogecoin", PricePrecision: 5},
+	}
+	if diff := cmp.Diff(expected, resp.GetSupportedCurrencies(), protocmp.Transform()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// reloaded tickers are listed
+	u.Set([]tickers.Ticker{{Symbol: "ETH"}})
+	resp, err = ts.ListSupportedCurrencies(context.TODO(), &grpcoin.ListSupportedCurrenciesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GetSupportedCurrencies()) != 1 || resp.GetSupportedCurrencies()[0].GetSymbol() != "ETH" {
+		t.Fatalf("expected reloaded tickers, got %v", resp.GetSupportedCurrencies())
+	}
+}
+
+func Test_validateTradeSize(t *testing.T) {
+	u := tickers.NewUniverse([]tickers.Ticker{
+		{Symbol: "BTC", MinTradeSize: decimal.RequireFromString("0.001")},
+		{Symbol: "ETH"},
+	})
+	tests := []struct {
+		name     string
+		ticker   string
+		quantity *grpcoin.Amount
+		code     codes.Code
+	}{
+		{name: "no minimum", ticker: "ETH", quantity: &grpcoin.Amount{Nanos: 1}},
+		{name: "at minimum", ticker: "BTC", quantity: &grpcoin.Amount{Nanos: 1_000_000}},
+		{name: "above minimum", ticker: "BTC", quantity: &grpcoin.Amount{Units: 1}},
+		{name: "below minimum", ticker: "BTC", quantity: &grpcoin.Amount{Nanos: 999_999}, code: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateTradeSize(tt.quantity, u, tt.ticker); status.Code(err) != tt.code {
+				t.Fatalf("expected code %v, got: %v", tt.code, err)
+			}
+		})
 	}
 }
 
 func TestTradeQuotePrices(t *testing.T) {
-	fs := firestoreutil.StartTestEmulator(t, context.TODO())
 	tr := trace.NewNoopTracerProvider().Tracer("")
 	udb := &userdb.UserDB{
-		DB:           fs,
+		DB:           userdb.NewMemStore(),
 		T:            tr,
 		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
 		Cache:        userdb.MockProfileCache{}}
 
 	faultyQuote := &mockQuoteProvider{err: context.DeadlineExceeded}
-	pt := &tradingService{udb: udb, quoteProvider: faultyQuote, tracer: tr, supportedTickers: []string{"BTC"}}
+	pt := &tradingService{udb: udb, quoteProvider: faultyQuote, tracer: tr, supportedTickers: tickers.FromSymbols("BTC")}
 	au := &github.GitHubUser{ID: 1, Username: "abc"}
 	user, err := udb.EnsureAccountExists(context.TODO(), au)
 	if err != nil {
@@ -226,6 +271,26 @@ func Test_validateTradeRequest(t *testing.T) {
 			supportedTickers: []string{"ABC", "BTC"},
 			code:             codes.InvalidArgument,
 		},
+		{
+			name: "invalid client order id",
+			req: &grpcoin.TradeRequest{Action: grpcoin.TradeAction_SELL,
+				Currency:      &grpcoin.Currency{Symbol: "BTC"},
+				Quantity:      &grpcoin.Amount{Units: 1},
+				ClientOrderId: "a/b",
+			},
+			supportedTickers: []string{"ABC", "BTC"},
+			code:             codes.InvalidArgument,
+		},
+		{
+			name: "valid client order id",
+			req: &grpcoin.TradeRequest{Action: grpcoin.TradeAction_SELL,
+				Currency:      &grpcoin.Currency{Symbol: "BTC"},
+				Quantity:      &grpcoin.Amount{Units: 1},
+				ClientOrderId: "bot-1_42",
+			},
+			supportedTickers: []string{"ABC", "BTC"},
+			code:             codes.OK,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -238,8 +303,7 @@ func Test_validateTradeRequest(t *testing.T) {
 
 func TestTrade(t *testing.T) {
 	tp := trace.NewNoopTracerProvider().Tracer("")
-	fs := firestoreutil.StartTestEmulator(t, context.TODO())
-	udb := &userdb.UserDB{DB: fs, T: tp,
+	udb := &userdb.UserDB{DB: userdb.NewMemStore(), T: tp,
 		Cache:        userdb.MockProfileCache{},
 		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)}}
 
@@ -251,7 +315,7 @@ func TestTrade(t *testing.T) {
 	ctx, stop := context.WithCancel(context.Background())
 	t.Cleanup(func() { stop() })
 	qp := &mockQuoteProvider{a: &grpcoin.Amount{Units: 30_000}}
-	pt := &tradingService{udb: udb, quoteProvider: qp, tracer: tp, supportedTickers: []string{"BTC"}}
+	pt := &tradingService{udb: udb, quoteProvider: qp, tracer: tp, supportedTickers: tickers.FromSymbols("BTC")}
 
 	ctx = auth.WithUser(ctx, au)
 	ctx = userdb.WithUserRecord(ctx, user)
@@ -281,6 +345,7 @@ func TestTrade(t *testing.T) {
 		Currency:      &grpcoin.Currency{Symbol: "BTC"},
 		Quantity:      &grpcoin.Amount{Units: 1, Nanos: 500_000_000},
 		ExecutedPrice: &grpcoin.Amount{Units: 30_000},
+		Fee:           &grpcoin.Amount{},
 		ResultingPortfolio: &grpcoin.TradeResponse_Portfolio{
 			RemainingCash: &grpcoin.Amount{Units: 55_000, Nanos: 0},
 			Positions: []*grpcoin.PortfolioPosition{
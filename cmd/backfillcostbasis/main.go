@@ -0,0 +1,101 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command backfillcostbasis reconstructs the cost basis and realized P&L of
+// the positions opened before they were tracked, from users' trade history.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"cloud.google.com/go/firestore"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+var flUseRealDB bool
+var flProjectID string
+var flEmulatorHost string
+
+func init() {
+	flag.BoolVar(&flUseRealDB, "use-real-db", false, "run against production database (requires -project set)")
+	flag.StringVar(&flProjectID, "project", "", "gcp project id")
+	flag.StringVar(&flEmulatorHost, "emulator-addr",
+		net.JoinHostPort(firestoreutil.FirestoreEmulatorHost, firestoreutil.FirestoreEmulatorPort),
+		"emulator addr")
+	flag.Parse()
+}
+
+func main() {
+	var fs *firestore.Client
+	var err error
+	ctx := context.Background()
+	if flUseRealDB {
+		if flProjectID == "" {
+			panic("empty project id")
+		}
+		fs, err = firestore.NewClient(ctx, flProjectID)
+		fmt.Println("using actual firestore db")
+	} else {
+		os.Setenv("FIRESTORE_EMULATOR_HOST", flEmulatorHost)
+		fs, err = firestore.NewClient(ctx, firestoreutil.FirestoreEmulatorProject)
+		os.Unsetenv("FIRESTORE_EMULATOR_HOST")
+		fmt.Println("using local firestore emulator")
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	udb := &userdb.UserDB{DB: &userdb.FirestoreStore{DB: fs}, T: trace.NewNoopTracerProvider().Tracer("")}
+	users, err := udb.GetAll(ctx)
+	if err != nil {
+		panic(err)
+	}
+	var backfilled, failed int
+	for _, u := range users {
+		tickers, realized, err := udb.BackfillCostBasis(ctx, u.ID)
+		if err != nil {
+			// trades made during the backfill fail the transaction, the
+			// command can be re-run for the failed users.
+			fmt.Printf("%s: failed: %v\n", u.ID, err)
+			failed++
+			continue
+		}
+		if len(tickers) > 0 || realized {
+			fmt.Printf("%s: backfilled %v (realized P&L: %v)\n", u.ID, tickers, realized)
+			backfilled++
+		}
+		for ticker := range u.Portfolio.Positions {
+			if _, ok := u.Portfolio.CostBasis[ticker]; !ok && !contains(tickers, ticker) {
+				fmt.Printf("%s: cost basis of %s is unknown (trade history is incomplete)\n", u.ID, ticker)
+			}
+		}
+	}
+	fmt.Printf("done: %d users backfilled, %d failed (of %d)\n", backfilled, failed, len(users))
+}
+
+func contains(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
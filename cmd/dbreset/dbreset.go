@@ -66,8 +66,10 @@ func main() {
 	}
 
 	// clear valuations
-	if err := firestoreutil.BatchDeleteAll(ctx, fs, fs.CollectionGroup("valuations").Documents(ctx)); err != nil {
-		panic(err)
+	for _, col := range []string{"valuations", "valuations_daily", "valuations_weekly"} {
+		if err := firestoreutil.BatchDeleteAll(ctx, fs, fs.CollectionGroup(col).Documents(ctx)); err != nil {
+			panic(err)
+		}
 	}
 
 	// clear orders
@@ -75,6 +77,26 @@ func main() {
 		panic(err)
 	}
 
+	// clear order book
+	if err := firestoreutil.BatchDeleteAll(ctx, fs, fs.CollectionGroup("orderbook").Documents(ctx)); err != nil {
+		panic(err)
+	}
+
+	// clear deduplicated trade requests
+	if err := firestoreutil.BatchDeleteAll(ctx, fs, fs.CollectionGroup("tradereqs").Documents(ctx)); err != nil {
+		panic(err)
+	}
+
+	// clear alerts
+	if err := firestoreutil.BatchDeleteAll(ctx, fs, fs.CollectionGroup("alerts").Documents(ctx)); err != nil {
+		panic(err)
+	}
+
+	// clear portfolio ledger
+	if err := firestoreutil.BatchDeleteAll(ctx, fs, fs.CollectionGroup("ledger").Documents(ctx)); err != nil {
+		panic(err)
+	}
+
 	// reset user portfolio
 	users, err := fs.Collection("users").Documents(ctx).GetAll()
 	if err != nil {
@@ -90,6 +112,7 @@ func main() {
 			CashUSD:   userdb.Amount{Units: 100_000},
 			Positions: nil,
 		}
+		uv.LedgerSeq = 0
 		batch.Set(u.Ref, uv)
 		if i == len(users)-1 || i%100 == 99 {
 			if _, err := batch.Commit(ctx); err != nil {
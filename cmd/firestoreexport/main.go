@@ -43,6 +43,8 @@ func main() {
 		"users",
 		"orders",
 		"valuations",
+		"valuations_daily",
+		"valuations_weekly",
 	}
 	fs, err := firestore.NewClient(ctx, flProj)
 	if err != nil {
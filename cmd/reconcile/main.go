@@ -0,0 +1,129 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command reconcile replays the portfolio ledger of the users and reports the
+// discrepancies of their portfolios and trade history. With -fix, the
+// portfolios are corrected to match the ledger and the missing trades are
+// added to the trade history.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"cloud.google.com/go/firestore"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+	"github.com/grpcoin/grpcoin/serverutil"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+var flUseRealDB bool
+var flProjectID string
+var flEmulatorHost string
+var flPostgresURL string
+var flUserID string
+var flFix bool
+
+func init() {
+	flag.BoolVar(&flUseRealDB, "use-real-db", false, "run against production database (requires -project set)")
+	flag.StringVar(&flProjectID, "project", "", "gcp project id")
+	flag.StringVar(&flEmulatorHost, "emulator-addr",
+		net.JoinHostPort(firestoreutil.FirestoreEmulatorHost, firestoreutil.FirestoreEmulatorPort),
+		"emulator addr")
+	flag.StringVar(&flPostgresURL, "postgres-url", "", "run against the PostgreSQL database at the url instead of firestore")
+	flag.StringVar(&flUserID, "uid", "", "reconcile only the specified user")
+	flag.BoolVar(&flFix, "fix", false, "correct the portfolios and trade history to match the ledger")
+	flag.Parse()
+}
+
+func main() {
+	ctx := context.Background()
+	var db userdb.Store
+	if flPostgresURL != "" {
+		pg, shutdown, err := serverutil.GetPostgresDB(ctx, flPostgresURL)
+		if err != nil {
+			panic(err)
+		}
+		defer shutdown()
+		db = pg
+		fmt.Println("using postgres db")
+	} else {
+		var fs *firestore.Client
+		var err error
+		if flUseRealDB {
+			if flProjectID == "" {
+				panic("empty project id")
+			}
+			fs, err = firestore.NewClient(ctx, flProjectID)
+			fmt.Println("using actual firestore db")
+		} else {
+			os.Setenv("FIRESTORE_EMULATOR_HOST", flEmulatorHost)
+			fs, err = firestore.NewClient(ctx, firestoreutil.FirestoreEmulatorProject)
+			os.Unsetenv("FIRESTORE_EMULATOR_HOST")
+			fmt.Println("using local firestore emulator")
+		}
+		if err != nil {
+			panic(err)
+		}
+		db = &userdb.FirestoreStore{DB: fs}
+	}
+
+	udb := &userdb.UserDB{DB: db, Cache: userdb.MockProfileCache{}, T: trace.NewNoopTracerProvider().Tracer("")}
+	var uids []string
+	if flUserID != "" {
+		uids = []string{flUserID}
+	} else {
+		users, err := udb.GetAll(ctx)
+		if err != nil {
+			panic(err)
+		}
+		for _, u := range users {
+			uids = append(uids, u.ID)
+		}
+	}
+	var inconsistent, fixed, failed int
+	for _, uid := range uids {
+		r, err := udb.Reconcile(ctx, uid, flFix)
+		if err != nil {
+			// trades made during the reconciliation fail the transaction,
+			// the command can be re-run for the failed users.
+			fmt.Printf("%s: failed: %v\n", uid, err)
+			failed++
+			continue
+		}
+		if r.Entries == 0 {
+			continue
+		}
+		for _, d := range r.Discrepancies {
+			fmt.Printf("%s: %s\n", uid, d)
+		}
+		for _, tr := range r.MissingTrades {
+			fmt.Printf("%s: trade missing from history: %s %s %s @ %s (%s)\n", uid,
+				tr.Action, tr.Size.F(), tr.Ticker, tr.Price.F(), tr.Date)
+		}
+		if len(r.Discrepancies) > 0 || len(r.MissingTrades) > 0 {
+			inconsistent++
+		}
+		if r.Fixed {
+			fmt.Printf("%s: fixed\n", uid)
+			fixed++
+		}
+	}
+	fmt.Printf("done: %d users inconsistent, %d fixed, %d failed (of %d)\n", inconsistent, fixed, failed, len(uids))
+}
@@ -28,6 +28,8 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/userdb"
 )
 
 func (fe *frontend) apiPortfolioHistory(w http.ResponseWriter, req *http.Request) error {
@@ -36,8 +38,13 @@ func (fe *frontend) apiPortfolioHistory(w http.ResponseWriter, req *http.Request
 		return status.Error(codes.InvalidArgument, "id is not specified")
 	}
 
+	rng, err := userdb.ParseValuationRange(req.URL.Query().Get("range"))
+	if err != nil {
+		return err
+	}
+
 	log := loggerFrom(req.Context())
-	cacheKey := fmt.Sprintf("pv_%s_%d", id, time.Now().Truncate(time.Hour).Unix())
+	cacheKey := fmt.Sprintf("pv_%s_%s_%d", id, rng, time.Now().Truncate(time.Hour).Unix())
 	expiration := time.Now().Truncate(time.Hour).Add(time.Hour).Sub(time.Now())
 
 	if b, err := fe.Redis.Get(req.Context(), cacheKey).Bytes(); err == nil {
@@ -50,7 +57,7 @@ func (fe *frontend) apiPortfolioHistory(w http.ResponseWriter, req *http.Request
 		}
 	}
 
-	vals, err := fe.DB.UserValuationHistory(req.Context(), id)
+	vals, err := fe.DB.UserValuationHistory(req.Context(), id, rng)
 	if err != nil {
 		if status.Code(err) == codes.NotFound {
 			return status.Error(codes.NotFound, "user not found")
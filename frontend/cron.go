@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -27,10 +28,13 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/grpcoin/grpcoin/leaderboard"
 	"github.com/grpcoin/grpcoin/userdb"
 )
 
 var (
+	// maxPortfolioHistory is how long the hourly valuations are kept before
+	// they are rolled up into daily and weekly valuations.
 	maxPortfolioHistory = time.Hour * 24 * 31
 )
 
@@ -60,12 +64,20 @@ func (fe *frontend) calcPortfolioHistory(w http.ResponseWriter, r *http.Request)
 	var batchSize int64 = 10
 	sem := semaphore.NewWeighted(batchSize)
 
+	var mu sync.Mutex
+	var rankings []leaderboard.Entry
+
 	// TODO process in parallel in batches
 	for _, u := range users {
 		sem.Acquire(context.TODO(), 1)
 		go func(u userdb.User) {
 			defer sem.Release(1)
-			pv := valuation(u.Portfolio, quotes)
+			pv := userdb.Valuation(u.Portfolio, quotes)
+			e := leaderboardEntry(r.Context(), fe.DB, u, pv)
+			mu.Lock()
+			rankings = append(rankings, e)
+			mu.Unlock()
+
 			if err := fe.DB.SetUserValuationHistory(r.Context(), u.ID, userdb.ValuationHistory{
 				Date:  t,
 				Value: pv,
@@ -73,15 +85,44 @@ func (fe *frontend) calcPortfolioHistory(w http.ResponseWriter, r *http.Request)
 				log.Warn("failed to process user", zap.String("id", u.ID), zap.Error(err))
 				return
 			}
-			if err := fe.DB.RotateUserValuationHistory(r.Context(), u.ID,
-				t.Add(-maxPortfolioHistory)); err != nil {
-				log.Warn("rotation history failed", zap.String("id", u.ID), zap.Error(err))
-				return
+			// only whole days are rolled up, so once a day is enough
+			if t.Hour() == 0 {
+				if err := fe.DB.RollupUserValuationHistory(r.Context(), u.ID,
+					t.Add(-maxPortfolioHistory)); err != nil {
+					log.Warn("valuation history rollup failed", zap.String("id", u.ID), zap.Error(err))
+					return
+				}
+				if err := fe.DB.DeleteExpiredTradeRequests(r.Context(), u.ID, t); err != nil {
+					log.Warn("failed to delete expired trade requests", zap.String("id", u.ID), zap.Error(err))
+					return
+				}
 			}
 			log.Debug("processed user", zap.String("id", u.ID))
 		}(u)
 	}
-	return sem.Acquire(r.Context(), batchSize)
+	if err := sem.Acquire(r.Context(), batchSize); err != nil {
+		return err
+	}
+
+	subCtx, s = fe.Trace.Start(r.Context(), "save leaderboard")
+	defer s.End()
+	return fe.Leaderboard.Save(subCtx, rankings, t)
+}
+
+// leaderboardEntry returns the user's leaderboard entry with the current
+// portfolio value pv. Returns are reported as zero if the valuation history
+// cannot be retrieved.
+func leaderboardEntry(ctx context.Context, db *userdb.UserDB, u userdb.User, pv userdb.Amount) leaderboard.Entry {
+	e := leaderboard.Entry{UserID: u.ID, DisplayName: u.DisplayName, Value: pv}
+	hist, err := db.UserValuationHistory(ctx, u.ID, userdb.ValuationRangeMonth)
+	if err != nil {
+		loggerFrom(ctx).Warn("failed to retrieve valuation history", zap.String("id", u.ID), zap.Error(err))
+		return e
+	}
+	e.DayReturn = findReturns(hist, pv, time.Hour*24)
+	e.WeekReturn = findReturns(hist, pv, time.Hour*24*7)
+	e.MonthReturn = findReturns(hist, pv, time.Hour*24*30)
+	return e
 }
 
 func verifyJWT(ctx context.Context, expectedSAEmail, token string) error {
@@ -37,7 +37,7 @@ var (
 		"fmtDuration":  fmtDuration,
 		"fmtPercent":   fmtPercent,
 		"toPercent":    toPercent,
-		"pv":           valuation,
+		"pv":           userdb.Valuation,
 		"isNegative":   isNegative,
 		"isZero":       userdb.Amount.IsZero,
 		"since":        since,
@@ -67,6 +67,9 @@ func trimTrailingZeros(v string) string {
 }
 
 func fmtPrice(a userdb.Amount) string {
+	if a.IsNegative() {
+		return "-" + fmtPrice(userdb.Amount{Units: -a.Units, Nanos: -a.Nanos})
+	}
 	if a.Units == 0 {
 		return fmtPriceFull(a)
 	}
@@ -89,15 +92,6 @@ func fmtPercent(a userdb.Amount) string {
 	return v
 }
 
-func valuation(p userdb.Portfolio, quotes map[string]userdb.Amount) userdb.Amount {
-	total := p.CashUSD.F()
-	for curr, amt := range p.Positions {
-		// TODO we are not returning an error if quotes don't list the held currency
-		total = total.Add(amt.F().Mul(quotes[curr].F()))
-	}
-	return userdb.ToAmount(total)
-}
-
 func mul(a, b userdb.Amount) userdb.Amount    { return userdb.ToAmount(a.F().Mul(b.F())) }
 func div(a, b userdb.Amount) userdb.Amount    { return userdb.ToAmount(a.F().Div(b.F())) }
 func toPercent(a userdb.Amount) userdb.Amount { return mul(a, userdb.Amount{Units: 100}) }
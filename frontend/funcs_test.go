@@ -94,3 +94,20 @@ func TestPortfolioSnaphotAt(t *testing.T) {
 		})
 	}
 }
+
+func TestFmtPrice(t *testing.T) {
+	tests := []struct {
+		in   userdb.Amount
+		want string
+	}{
+		{userdb.Amount{Units: 1234, Nanos: 567_000_000}, "1,234.56"},
+		{userdb.Amount{Units: 0, Nanos: 1_500_000}, "0.0015"},
+		{userdb.Amount{Units: -1234, Nanos: -567_000_000}, "-1,234.56"},
+		{userdb.Amount{Units: 0, Nanos: -1_500_000}, "-0.0015"},
+	}
+	for _, tt := range tests {
+		if got := fmtPrice(tt.in); got != tt.want {
+			t.Errorf("fmtPrice(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
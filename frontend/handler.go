@@ -31,9 +31,11 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/grpcoin/grpcoin/leaderboard"
 	"github.com/grpcoin/grpcoin/ratelimiter"
 	"github.com/grpcoin/grpcoin/realtimequote"
 	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/tickers"
 	"github.com/grpcoin/grpcoin/userdb"
 	"github.com/purini-to/zapmw"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
@@ -52,16 +54,17 @@ var (
 )
 
 type frontend struct {
-	QuoteProvider    realtimequote.QuoteProvider
-	QuoteDeadline    time.Duration
-	QuoteFanout      *fanout.QuoteFanoutService
-	SupportedSymbols []string
+	QuoteProvider realtimequote.QuoteProvider
+	QuoteDeadline time.Duration
+	QuoteFanout   *fanout.QuoteFanoutService
+	Tickers       *tickers.Universe
 
 	CronSAEmail string // email for the SA allowed to run cron endpoints
 
-	Trace trace.Tracer
-	DB    *userdb.UserDB
-	Redis *redis.Client
+	Trace       trace.Tracer
+	DB          *userdb.UserDB
+	Redis       *redis.Client
+	Leaderboard *leaderboard.Leaderboard
 }
 
 func (fe *frontend) Handlers(log *zap.Logger) http.Handler {
@@ -39,7 +39,7 @@ func (fe *frontend) getQuotes(ctx context.Context) (map[string]userdb.Amount, er
 	out := make(map[string]userdb.Amount)
 
 	eg, _ := errgroup.WithContext(ctx)
-	for _, s := range fe.SupportedSymbols {
+	for _, s := range fe.Tickers.Symbols() {
 		quote := s // NB: needed to capture for the closure below
 		eg.Go(func() error {
 			v, err := fe.QuoteProvider.GetQuote(ctx, quote)
@@ -66,6 +66,7 @@ type LeaderboardHandlerData struct {
 	Users            []leaderboardUser
 	TotalTradeCount  int
 	TotalTradeVolume int
+	TotalTradeFees   int
 }
 
 func (fe *frontend) leaderboard(w http.ResponseWriter, r *http.Request) error {
@@ -84,7 +85,7 @@ func (fe *frontend) leaderboard(w http.ResponseWriter, r *http.Request) error {
 	for _, u := range users {
 		out.Users = append(out.Users, leaderboardUser{
 			User:                u,
-			TotalPortfolioValue: valuation(u.Portfolio, quotes)})
+			TotalPortfolioValue: userdb.Valuation(u.Portfolio, quotes)})
 	}
 	sort.Slice(out.Users, func(i, j int) bool {
 		return !out.Users[i].TotalPortfolioValue.Less(out.Users[j].TotalPortfolioValue)
@@ -98,5 +99,10 @@ func (fe *frontend) leaderboard(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 	out.TotalTradeVolume = int(decimal.NewFromFloat(totalTradeVolume).IntPart())
+	totalTradeFees, err := fe.DB.TradeCounter.PastDayTradeFees(r.Context(), time.Now())
+	if err != nil {
+		return err
+	}
+	out.TotalTradeFees = int(decimal.NewFromFloat(totalTradeFees).IntPart())
 	return tpl.ExecuteTemplate(w, "leaderboard.tmpl", out)
 }
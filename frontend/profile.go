@@ -37,6 +37,11 @@ type ProfileHandlerData struct {
 	Positions []portfolioPosition
 	Returns   []returns
 	Trades    []userdb.TradeRecord
+
+	// TradesPaged is set if Trades is not the first page of user's trades,
+	// NextTradesPage is the token of the next page (if any).
+	TradesPaged    bool
+	NextTradesPage string
 }
 
 type returns struct {
@@ -49,6 +54,11 @@ type portfolioPosition struct {
 	Amount userdb.Amount
 	Price  userdb.Amount
 	Value  userdb.Amount
+
+	// CostBasis and UnrealizedPnL are set if HasCostBasis is set.
+	HasCostBasis  bool
+	CostBasis     userdb.Amount
+	UnrealizedPnL userdb.Amount
 }
 
 func (fe *frontend) userProfile(w http.ResponseWriter, r *http.Request) error {
@@ -67,13 +77,11 @@ func (fe *frontend) userProfile(w http.ResponseWriter, r *http.Request) error {
 		return status.Error(codes.NotFound, "user not found")
 	}
 
-	trades, err := fe.DB.UserTrades(r.Context(), uid)
+	tradesPage := r.URL.Query().Get("trades")
+	trades, nextTradesPage, err := fe.DB.UserTrades(r.Context(), uid, tradesPage)
 	if err != nil {
 		return err
 	}
-	for i := 0; i < len(trades)/2; i++ {
-		trades[i], trades[len(trades)-1-i] = trades[len(trades)-1-i], trades[i]
-	}
 
 	quoteCtx, cancel := context.WithTimeout(r.Context(), fe.QuoteDeadline)
 	defer cancel()
@@ -84,27 +92,33 @@ func (fe *frontend) userProfile(w http.ResponseWriter, r *http.Request) error {
 
 	var positions []portfolioPosition
 	for ticker, amount := range u.Portfolio.Positions {
-		positions = append(positions, portfolioPosition{
+		pos := portfolioPosition{
 			Ticker: ticker,
 			Amount: amount,
 			Price:  quotes[ticker],
 			Value:  userdb.ToAmount(amount.F().Mul(quotes[ticker].F())),
-		})
+		}
+		pos.UnrealizedPnL, pos.HasCostBasis = u.Portfolio.UnrealizedPnL(ticker, quotes[ticker])
+		pos.CostBasis = u.Portfolio.CostBasis[ticker]
+		positions = append(positions, pos)
 	}
 	sort.Slice(positions, func(i, j int) bool {
 		return !positions[i].Value.Less(positions[j].Value)
 	})
 
-	hist, err := fe.DB.UserValuationHistory(r.Context(), u.ID)
+	hist, err := fe.DB.UserValuationHistory(r.Context(), u.ID, userdb.ValuationRangeMonth)
 	if err != nil {
 		return err
 	}
-	pv := valuation(u.Portfolio, quotes)
+	pv := userdb.Valuation(u.Portfolio, quotes)
 	out := ProfileHandlerData{
 		Quotes:    quotes,
 		U:         u,
 		Positions: positions,
 		Trades:    trades,
+
+		TradesPaged:    tradesPage != "",
+		NextTradesPage: nextTradesPage,
 		Returns: []returns{
 			{"1 hour", findReturns(hist, pv, time.Hour)},
 			{"6 hours", findReturns(hist, pv, time.Hour*6)},
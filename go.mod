@@ -20,8 +20,8 @@ require (
 	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
 	github.com/grpc-ecosystem/grpc-gateway v1.16.0
 	github.com/hako/durafmt v0.0.0-20210316092057-3a2c319c1acd
+	github.com/lib/pq v1.10.9
 	github.com/pkg/errors v0.9.1 // indirect
-	github.com/preichenberger/go-coinbasepro/v2 v2.0.5
 	github.com/purini-to/zapmw v1.1.0
 	github.com/shopspring/decimal v1.2.0
 	github.com/yuin/goldmark v1.3.9
@@ -0,0 +1,162 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package leaderboard stores the periodically computed user rankings.
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+const (
+	keyRanks   = "leaderboard::ranks"   // sorted set of uid by portfolio value
+	keyEntries = "leaderboard::entries" // hash of uid to entry
+	keyUpdated = "leaderboard::updated" // unix time of the last update
+)
+
+// Entry represents a user's standing on the leaderboard.
+type Entry struct {
+	Rank        int64 `json:"-"` // 1-based, set when read
+	UserID      string
+	DisplayName string
+	Value       userdb.Amount
+
+	// Returns in percent over the past periods.
+	DayReturn   userdb.Amount
+	WeekReturn  userdb.Amount
+	MonthReturn userdb.Amount
+}
+
+type Leaderboard struct {
+	DB *redis.Client
+}
+
+// Save replaces the leaderboard with the specified entries atomically.
+func (l *Leaderboard) Save(ctx context.Context, entries []Entry, now time.Time) error {
+	tmpRanks, tmpEntries := keyRanks+"::tmp", keyEntries+"::tmp"
+	p := l.DB.TxPipeline()
+	defer p.Close()
+	if len(entries) == 0 {
+		p.Del(ctx, keyRanks, keyEntries)
+	} else {
+		ranks := make([]*redis.Z, 0, len(entries))
+		values := make(map[string]interface{}, len(entries))
+		for _, e := range entries {
+			b, err := json.Marshal(e)
+			if err != nil {
+				return fmt.Errorf("failed to encode entry for %s: %w", e.UserID, err)
+			}
+			score, _ := e.Value.F().Float64()
+			ranks = append(ranks, &redis.Z{Score: score, Member: e.UserID})
+			values[e.UserID] = b
+		}
+		p.Del(ctx, tmpRanks, tmpEntries)
+		p.ZAdd(ctx, tmpRanks, ranks...)
+		p.HSet(ctx, tmpEntries, values)
+		p.Rename(ctx, tmpRanks, keyRanks)
+		p.Rename(ctx, tmpEntries, keyEntries)
+	}
+	p.Set(ctx, keyUpdated, now.Unix(), 0)
+	_, err := p.Exec(ctx)
+	return err
+}
+
+// Page returns the entries ranked [offset+1, offset+limit], and the total
+// number of ranked users.
+func (l *Leaderboard) Page(ctx context.Context, offset, limit int64) ([]Entry, int64, error) {
+	p := l.DB.TxPipeline()
+	defer p.Close()
+	uids := p.ZRevRange(ctx, keyRanks, offset, offset+limit-1)
+	total := p.ZCard(ctx, keyRanks)
+	if _, err := p.Exec(ctx); err != nil {
+		return nil, 0, err
+	}
+	if len(uids.Val()) == 0 {
+		return nil, total.Val(), nil
+	}
+	entries, err := l.entries(ctx, uids.Val())
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range entries {
+		entries[i].Rank = offset + int64(i) + 1
+	}
+	return entries, total.Val(), nil
+}
+
+// Rank returns the user's entry, and the total number of ranked users.
+// Returns false if the user is not ranked.
+func (l *Leaderboard) Rank(ctx context.Context, uid string) (Entry, int64, bool, error) {
+	p := l.DB.TxPipeline()
+	defer p.Close()
+	rank := p.ZRevRank(ctx, keyRanks, uid)
+	total := p.ZCard(ctx, keyRanks)
+	if _, err := p.Exec(ctx); errors.Is(err, redis.Nil) {
+		return Entry{}, 0, false, nil
+	} else if err != nil {
+		return Entry{}, 0, false, err
+	}
+	entries, err := l.entries(ctx, []string{uid})
+	if err != nil {
+		return Entry{}, 0, false, err
+	}
+	e := entries[0]
+	e.Rank = rank.Val() + 1
+	return e, total.Val(), true, nil
+}
+
+// UpdatedAt returns the last time the leaderboard is saved, or zero time if
+// it has never been saved.
+func (l *Leaderboard) UpdatedAt(ctx context.Context) (time.Time, error) {
+	v, err := l.DB.Get(ctx, keyUpdated).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+	sec, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse leaderboard update time %q: %w", v, err)
+	}
+	return time.Unix(sec, 0).UTC(), nil
+}
+
+func (l *Leaderboard) entries(ctx context.Context, uids []string) ([]Entry, error) {
+	vals, err := l.DB.HMGet(ctx, keyEntries, uids...).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Entry, 0, len(vals))
+	for i, v := range vals {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("leaderboard entry for %s not found", uids[i])
+		}
+		var e Entry
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			return nil, fmt.Errorf("failed to decode leaderboard entry for %s: %w", uids[i], err)
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
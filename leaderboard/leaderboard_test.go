@@ -0,0 +1,101 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func TestLeaderboard(t *testing.T) {
+	ctx := context.Background()
+	lb := &Leaderboard{DB: testutil.MockRedis(t)}
+
+	if v, err := lb.UpdatedAt(ctx); err != nil {
+		t.Fatal(err)
+	} else if !v.IsZero() {
+		t.Fatalf("expected zero update time, got: %v", v)
+	}
+	if _, _, ok, err := lb.Rank(ctx, "u1"); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("user should not be ranked on empty leaderboard")
+	}
+
+	now := time.Date(2050, 1, 1, 10, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{UserID: "u1", DisplayName: "a", Value: userdb.Amount{Units: 90_000}},
+		{UserID: "u2", DisplayName: "b", Value: userdb.Amount{Units: 120_000, Nanos: 500},
+			DayReturn: userdb.Amount{Units: 20}},
+		{UserID: "u3", DisplayName: "c", Value: userdb.Amount{Units: 100_000}},
+	}
+	if err := lb.Save(ctx, entries, now); err != nil {
+		t.Fatal(err)
+	}
+
+	page, total, err := lb.Page(ctx, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 {
+		t.Fatalf("wrong total: %d", total)
+	}
+	expected := []Entry{
+		{Rank: 1, UserID: "u2", DisplayName: "b", Value: userdb.Amount{Units: 120_000, Nanos: 500},
+			DayReturn: userdb.Amount{Units: 20}},
+		{Rank: 2, UserID: "u3", DisplayName: "c", Value: userdb.Amount{Units: 100_000}},
+	}
+	if diff := cmp.Diff(expected, page); diff != "" {
+		t.Fatal(diff)
+	}
+	page, _, err = lb.Page(ctx, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 1 || page[0].Rank != 3 || page[0].UserID != "u1" {
+		t.Fatalf("unexpected last page: %#v", page)
+	}
+
+	e, total, ok, err := lb.Rank(ctx, "u3")
+	if err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("user not ranked")
+	}
+	if e.Rank != 2 || total != 3 {
+		t.Fatalf("wrong rank: %d/%d", e.Rank, total)
+	}
+
+	// new rankings replace the old ones
+	if err := lb.Save(ctx, entries[:1], now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok, err := lb.Rank(ctx, "u3"); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("user should no longer be ranked")
+	}
+	if v, err := lb.UpdatedAt(ctx); err != nil {
+		t.Fatal(err)
+	} else if !v.Equal(now.Add(time.Hour)) {
+		t.Fatalf("wrong update time: %v", v)
+	}
+}
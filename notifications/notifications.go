@@ -0,0 +1,131 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notifications delivers fired alerts and portfolio changes to the
+// users connected to any of the server instances.
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func channel(uid string) string          { return fmt.Sprintf("notifications::%s", uid) }
+func portfolioChannel(uid string) string { return fmt.Sprintf("portfolio::%s", uid) }
+
+type Notifier struct {
+	DB *redis.Client
+}
+
+// Publish delivers the fired alert to the alert owner's subscriptions.
+func (n *Notifier) Publish(ctx context.Context, a userdb.Alert) error {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return n.DB.Publish(ctx, channel(a.UserID), b).Err()
+}
+
+// Subscribe returns the alerts of the user fired while ctx is active. The
+// returned channel is closed when ctx is done.
+func (n *Notifier) Subscribe(ctx context.Context, uid string) (<-chan userdb.Alert, error) {
+	msgs, err := n.subscribe(ctx, channel(uid))
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan userdb.Alert)
+	go func() {
+		defer close(out)
+		for m := range msgs {
+			var a userdb.Alert
+			if err := json.Unmarshal([]byte(m.Payload), &a); err != nil {
+				ctxzap.Extract(ctx).Warn("failed to unpack notification", zap.Error(err))
+				continue
+			}
+			select {
+			case out <- a:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// PortfolioChanged notifies the watchers of the user's portfolio that it has
+// changed.
+func (n *Notifier) PortfolioChanged(ctx context.Context, uid string) error {
+	return n.DB.Publish(ctx, portfolioChannel(uid), "").Err()
+}
+
+// WatchPortfolio returns a channel that receives a value when the user's
+// portfolio changes while ctx is active. Changes are coalesced if the
+// receiver is busy. The returned channel is closed when ctx is done.
+func (n *Notifier) WatchPortfolio(ctx context.Context, uid string) (<-chan struct{}, error) {
+	msgs, err := n.subscribe(ctx, portfolioChannel(uid))
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan struct{}, 1)
+	go func() {
+		defer close(out)
+		for range msgs {
+			select {
+			case out <- struct{}{}:
+			default: // a change is already pending
+			}
+		}
+	}()
+	return out, nil
+}
+
+// subscribe returns the messages published on the Redis channel while ctx
+// is active. The returned channel is closed when ctx is done.
+func (n *Notifier) subscribe(ctx context.Context, ch string) (<-chan *redis.Message, error) {
+	sub := n.DB.Subscribe(ctx, ch)
+	// wait for the subscription to be confirmed, so no messages are missed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("failed to subscribe to notifications: %w", err)
+	}
+	out := make(chan *redis.Message)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+		msgs := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-msgs:
+				if !ok {
+					return
+				}
+				select {
+				case out <- m:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
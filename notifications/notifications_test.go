@@ -0,0 +1,95 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/userdb"
+)
+
+func TestNotifier(t *testing.T) {
+	n := &Notifier{DB: testutil.MockRedis(t)}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ch, err := n.Subscribe(ctx, "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := userdb.Alert{ID: "a0", UserID: "u2"}
+	want := userdb.Alert{ID: "a1", UserID: "u1", Ticker: "BTC",
+		Condition:  grpcoin.AlertCondition_PRICE_ABOVE,
+		Price:      userdb.Amount{Units: 50_000},
+		FiredAt:    time.Date(2050, 1, 1, 0, 0, 0, 0, time.UTC),
+		FiredPrice: userdb.Amount{Units: 50_001}}
+	for _, a := range []userdb.Alert{other, want} {
+		if err := n.Publish(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case got := <-ch:
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatal(diff)
+		}
+	case <-ctx.Done():
+		t.Fatal("notification not received")
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestNotifier_WatchPortfolio(t *testing.T) {
+	n := &Notifier{DB: testutil.MockRedis(t)}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ch, err := n.WatchPortfolio(ctx, "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.PortfolioChanged(ctx, "u2"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ch:
+		t.Fatal("received change of another user's portfolio")
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := n.PortfolioChanged(ctx, "u1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case <-ch:
+	case <-ctx.Done():
+		t.Fatal("change not received")
+	}
+
+	cancel()
+	for range ch {
+	}
+}
@@ -36,6 +36,9 @@ type ReconnectingQuoteProvider struct {
 
 	lock   sync.RWMutex
 	quotes map[string]quote
+	// updated has the channels of the products with GetQuote calls waiting
+	// for a fresh quote, closed when one arrives.
+	updated map[string]chan struct{}
 }
 
 const DefaultStaleQuotePeriod = time.Second * 10 // fails if quote is older than this
@@ -50,6 +53,8 @@ func NewReconnectingQuoteProvider(ctx context.Context, log *zap.Logger, quoteStr
 		staleQuotePeriod: DefaultStaleQuotePeriod,
 		qs:               quoteStream,
 		products:         products,
+		quotes:           make(map[string]quote),
+		updated:          make(map[string]chan struct{}),
 	}
 	go qp.sync(ctx)
 	return qp
@@ -60,23 +65,32 @@ func (qp *ReconnectingQuoteProvider) GetQuote(ctx context.Context, product strin
 	if stalePeriod == 0 {
 		stalePeriod = DefaultStaleQuotePeriod
 	}
+	qp.lock.RLock()
+	q := qp.quotes[product]
+	qp.lock.RUnlock()
+	if time.Since(q.lastUpdated) <= stalePeriod {
+		return q.amount, nil
+	}
 	for {
+		qp.lock.Lock()
+		q := qp.quotes[product] // may have arrived since the last check
+		if time.Since(q.lastUpdated) <= stalePeriod {
+			qp.lock.Unlock()
+			return q.amount, nil
+		}
+		ch, ok := qp.updated[product]
+		if !ok {
+			ch = make(chan struct{})
+			qp.updated[product] = ch
+		}
+		qp.lock.Unlock()
+
 		select {
 		case <-ctx.Done():
 			qp.logger.Warn("quote request cancelled", zap.Error(ctx.Err()))
 			return nil, ctx.Err()
-		default:
-			qp.lock.RLock()
-			q := qp.quotes[product]
-			if time.Since(q.lastUpdated) > stalePeriod {
-				qp.lock.RUnlock()
-				break
-			}
-			amount := q.amount
-			qp.lock.RUnlock()
-			return amount, nil
+		case <-ch:
 		}
-		time.Sleep(time.Millisecond * 10) // TODO not so great but prevents 100% cpu
 	}
 }
 
@@ -84,9 +98,6 @@ func (qp *ReconnectingQuoteProvider) GetQuote(ctx context.Context, product strin
 // GetQuote. It is meant to be invoked in a goroutine. Closes the underlying
 // quote stream if ctx is done.
 func (qp *ReconnectingQuoteProvider) sync(ctx context.Context) {
-	qp.lock.Lock()
-	qp.quotes = make(map[string]quote)
-	qp.lock.Unlock()
 	for {
 		if ctx.Err() != nil {
 			return
@@ -103,6 +114,10 @@ func (qp *ReconnectingQuoteProvider) sync(ctx context.Context) {
 			q.amount = m.Price
 			q.lastUpdated = time.Now()
 			qp.quotes[m.Product] = q
+			if ch, ok := qp.updated[m.Product]; ok {
+				close(ch) // wake up the waiting GetQuote calls
+				delete(qp.updated, m.Product)
+			}
 			qp.lock.Unlock()
 		}
 		qp.logger.Warn("quote stream broken, reopening")
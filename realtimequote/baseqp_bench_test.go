@@ -0,0 +1,68 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package realtimequote
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func cpuTime(b *testing.B) time.Duration {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		b.Fatal(err)
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+}
+
+// BenchmarkGetQuote_outage measures the CPU time used by the GetQuote calls
+// waiting for a quote while the quote stream is down. Each op is the waiters
+// blocking for 100ms until their deadline.
+func BenchmarkGetQuote_outage(b *testing.B) {
+	for _, waiters := range []int{100, 500} {
+		b.Run(fmt.Sprintf("waiters=%d", waiters), func(b *testing.B) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			qp := NewReconnectingQuoteProvider(ctx, zap.NewNop(), make(chanStream), "BTC")
+
+			start := cpuTime(b)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				reqCtx, reqCancel := context.WithTimeout(ctx, time.Millisecond*100)
+				var wg sync.WaitGroup
+				for j := 0; j < waiters; j++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						qp.GetQuote(reqCtx, "BTC")
+					}()
+				}
+				wg.Wait()
+				reqCancel()
+			}
+			b.StopTimer()
+			b.ReportMetric(float64(cpuTime(b)-start)/float64(b.N), "cpu-ns/op")
+		})
+	}
+}
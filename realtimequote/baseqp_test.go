@@ -0,0 +1,113 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package realtimequote
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"go.uber.org/zap"
+)
+
+// chanStream is a QuoteStream relaying the quotes sent to it.
+type chanStream chan Quote
+
+func (c chanStream) Watch(ctx context.Context, products ...string) (<-chan Quote, error) {
+	out := make(chan Quote)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case q := <-c:
+				select {
+				case <-ctx.Done():
+					return
+				case out <- q:
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func TestReconnectingQuoteProvider_GetQuoteWaitsForQuote(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := make(chanStream)
+	qp := NewReconnectingQuoteProvider(ctx, zap.NewNop(), src, "BTC", "ETH")
+
+	const waiters = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, waiters)
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+			defer cancel()
+			v, err := qp.GetQuote(ctx, "BTC")
+			if err == nil && v.GetUnits() != 100 {
+				err = errors.New("wrong price")
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(time.Millisecond * 50) // let the waiters block
+	src <- Quote{Product: "ETH", Price: &grpcoin.Amount{Units: 1}}
+	src <- Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 100}}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// fresh quotes are returned right away
+	v, err := qp.GetQuote(ctx, "ETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.GetUnits() != 1 {
+		t.Fatalf("got %v, expected 1", v)
+	}
+}
+
+func TestReconnectingQuoteProvider_GetQuoteCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := make(chanStream)
+	qp := NewReconnectingQuoteProvider(ctx, zap.NewNop(), src, "BTC")
+	qp.(*ReconnectingQuoteProvider).staleQuotePeriod = time.Millisecond * 50
+
+	src <- Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 100}}
+	time.Sleep(time.Millisecond * 100) // quote goes stale
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer reqCancel()
+	start := time.Now()
+	if _, err := qp.GetQuote(reqCtx, "BTC"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("GetQuote returned %v after ctx was done", elapsed)
+	}
+}
@@ -26,17 +26,40 @@ import (
 	"github.com/grpcoin/grpcoin/realtimequote/common"
 )
 
+// Stream is a QuoteStream of the aggregate trade prices of the products in
+// USDT.
+type Stream struct {
+	// Symbol returns the symbol of a product on Binance, if it doesn't follow
+	// the PRODUCTUSDT convention (optional).
+	Symbol func(product string) string
+}
+
+// WatchSymbols watches the products with the PRODUCTUSDT symbols.
 func WatchSymbols(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
+	return (&Stream{}).Watch(ctx, products...)
+}
+
+func (s *Stream) Watch(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
 	gobinance.WebsocketKeepalive = true // handle sending pong frames
 	symbols := make([]string, len(products))
-	for i, s := range products {
-		symbols[i] = strings.ToLower(s + "USDT")
+	bySymbol := make(map[string]string, len(products))
+	for i, p := range products {
+		sym := p + "USDT"
+		if s.Symbol != nil && s.Symbol(p) != "" {
+			sym = s.Symbol(p)
+		}
+		symbols[i] = strings.ToLower(sym)
+		bySymbol[strings.ToUpper(sym)] = p
 	}
 
 	out := make(chan realtimequote.Quote)
 	doneC, stopC, err := gobinance.WsCombinedAggTradeServe(symbols, func(event *gobinance.WsAggTradeEvent) {
+		product, ok := bySymbol[event.Symbol]
+		if !ok {
+			product = strings.ToUpper(strings.TrimSuffix(event.Symbol, "USDT"))
+		}
 		out <- realtimequote.Quote{
-			Product: strings.ToUpper(strings.TrimSuffix(event.Symbol, "USDT")),
+			Product: product,
 			Price:   common.ParsePrice(event.Price),
 			Time:    time.Unix(event.Time/1000, event.Time%1000*1_000_000)}
 	}, func(err error) {
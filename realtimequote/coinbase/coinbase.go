@@ -0,0 +1,255 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package coinbase provides quotes from the ticker channel of the Coinbase
+// Advanced Trade WebSocket feed.
+package coinbase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ws "github.com/gorilla/websocket"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/common"
+	"go.uber.org/zap"
+)
+
+const DefaultURL = "wss://advanced-trade-ws.coinbase.com"
+
+var (
+	// pingInterval is how often the connection is checked with a ping
+	// frame, and readTimeout is how long the connection can be idle before
+	// it's considered broken (heartbeats are sent every second).
+	pingInterval = time.Second * 20
+	readTimeout  = time.Second * 30
+	ackTimeout   = time.Second * 10
+)
+
+// Stream is a QuoteStream of the last trade prices of the products in USD.
+type Stream struct {
+	// URL is the WebSocket feed endpoint, DefaultURL if empty.
+	URL    string
+	Logger *zap.Logger
+	// Symbol returns the product id of a product on Coinbase, if it doesn't
+	// follow the PRODUCT-USD convention (optional).
+	Symbol func(product string) string
+}
+
+type subscribe struct {
+	Type       string   `json:"type"`
+	ProductIDs []string `json:"product_ids,omitempty"`
+	Channel    string   `json:"channel"`
+}
+
+type message struct {
+	Type    string    `json:"type"`
+	Message string    `json:"message"`
+	Channel string    `json:"channel"`
+	Time    time.Time `json:"timestamp"`
+	Events  []event   `json:"events"`
+}
+
+type event struct {
+	Subscriptions map[string][]string `json:"subscriptions"`
+	Tickers       []ticker            `json:"tickers"`
+}
+
+type ticker struct {
+	ProductID string `json:"product_id"`
+	Price     string `json:"price"`
+}
+
+func toProductID(product string) string { return product + "-USD" }
+
+// productIDs maps the product ids to the products watched.
+type productIDs map[string]string
+
+func (p productIDs) product(id string) string {
+	if v, ok := p[id]; ok {
+		return v
+	}
+	return fromProductID(id)
+}
+
+func fromProductID(id string) string {
+	// USDC pairs are aliased to USD ones
+	return strings.TrimSuffix(strings.TrimSuffix(id, "-USDC"), "-USD")
+}
+
+// Watch subscribes to the tickers of the products, and to the heartbeats to
+// keep the connection open when there are no trades. err is returned if it
+// fails to connect or the subscription is rejected.
+func (s *Stream) Watch(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
+	url := s.URL
+	if url == "" {
+		url = DefaultURL
+	}
+	log := s.Logger
+	if log == nil {
+		log = zap.NewNop()
+	}
+	ids := make([]string, len(products))
+	byID := make(productIDs, len(products))
+	for i, p := range products {
+		ids[i] = toProductID(p)
+		if s.Symbol != nil && s.Symbol(p) != "" {
+			ids[i] = s.Symbol(p)
+		}
+		byID[ids[i]] = p
+	}
+
+	conn, _, err := ws.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial coinbase: %w", err)
+	}
+	for _, sub := range []subscribe{
+		{Type: "subscribe", ProductIDs: ids, Channel: "ticker"},
+		{Type: "subscribe", Channel: "heartbeats"},
+	} {
+		if err := conn.WriteJSON(sub); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to subscribe to %s: %w", sub.Channel, err)
+		}
+	}
+
+	ping, timeout := pingInterval, readTimeout
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(timeout))
+	})
+	// quotes can arrive before the acks, so they are kept until then
+	buffered, err := waitForAck(conn, ids)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ch := make(chan realtimequote.Quote)
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		conn.Close()
+	}()
+	go func() {
+		t := time.NewTicker(ping)
+		defer t.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				if err := conn.WriteControl(ws.PingMessage, nil, time.Now().Add(timeout)); err != nil {
+					log.Debug("failed to send ping", zap.Error(err))
+					return
+				}
+			}
+		}
+	}()
+	go func() {
+		defer close(ch)
+		defer close(stop)
+		send := func(msgs []message) bool {
+			for _, q := range quotes(msgs, byID) {
+				select {
+				case <-ctx.Done():
+					return false
+				case ch <- q:
+				}
+			}
+			return true
+		}
+		if !send(buffered) {
+			return
+		}
+		for {
+			conn.SetReadDeadline(time.Now().Add(timeout))
+			var m message
+			if err := conn.ReadJSON(&m); err != nil {
+				if ctx.Err() == nil {
+					log.Warn("coinbase read failed", zap.Error(err))
+				}
+				return
+			}
+			if m.Type == "error" {
+				log.Warn("coinbase sent error", zap.String("message", m.Message))
+				return
+			}
+			if !send([]message{m}) {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// waitForAck reads the messages until the ticker subscription of all product
+// ids is acknowledged, and returns the ticker messages read until then.
+func waitForAck(conn *ws.Conn, ids []string) ([]message, error) {
+	var buffered []message
+	conn.SetReadDeadline(time.Now().Add(ackTimeout))
+	for {
+		var m message
+		if err := conn.ReadJSON(&m); err != nil {
+			return nil, fmt.Errorf("failed to read subscription status: %w", err)
+		}
+		switch {
+		case m.Type == "error":
+			return nil, fmt.Errorf("coinbase subscription failed: %s", m.Message)
+		case m.Channel == "ticker":
+			buffered = append(buffered, m)
+		case m.Channel == "subscriptions":
+			for _, e := range m.Events {
+				if subscribed(e.Subscriptions["ticker"], ids) {
+					return buffered, nil
+				}
+			}
+		}
+	}
+}
+
+func subscribed(have, want []string) bool {
+	m := make(map[string]bool, len(have))
+	for _, v := range have {
+		m[v] = true
+	}
+	for _, v := range want {
+		if !m[v] {
+			return false
+		}
+	}
+	return true
+}
+
+func quotes(msgs []message, byID productIDs) []realtimequote.Quote {
+	var out []realtimequote.Quote
+	for _, m := range msgs {
+		if m.Channel != "ticker" {
+			continue // heartbeats, subscriptions
+		}
+		for _, e := range m.Events {
+			for _, t := range e.Tickers {
+				out = append(out, realtimequote.Quote{
+					Product: byID.product(t.ProductID),
+					Price:   common.ParsePrice(t.Price),
+					Time:    m.Time})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,175 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coinbase
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ws "github.com/gorilla/websocket"
+	"github.com/grpcoin/grpcoin/testutil"
+)
+
+// serve checks the subscription requests and replays the messages.
+func serve(t *testing.T, messages []string, then func(conn *ws.Conn)) func(conn *ws.Conn) {
+	return func(conn *ws.Conn) {
+		expected := []subscribe{
+			{Type: "subscribe", ProductIDs: []string{"BTC-USD", "DOGE-USD"}, Channel: "ticker"},
+			{Type: "subscribe", Channel: "heartbeats"},
+		}
+		for _, e := range expected {
+			var sub subscribe
+			if err := conn.ReadJSON(&sub); err != nil {
+				t.Errorf("failed to read subscription: %v", err)
+				return
+			}
+			if !reflect.DeepEqual(sub, e) {
+				t.Errorf("subscription=%#v, expected=%#v", sub, e)
+				return
+			}
+		}
+		for _, m := range messages {
+			if err := conn.WriteMessage(ws.TextMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+		if then != nil {
+			then(conn)
+		}
+	}
+}
+
+func TestStream_Watch(t *testing.T) {
+	url := testutil.MockWebSocket(t, serve(t, testutil.ReadMessages(t, "testdata/ticker.jsonl"), nil))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	ch, err := (&Stream{URL: url}).Watch(ctx, "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type quote struct {
+		product string
+		units   int64
+		nanos   int32
+		time    string
+	}
+	var got []quote
+	for q := range ch {
+		got = append(got, quote{q.Product, q.Price.Units, q.Price.Nanos, q.Time.Format(time.RFC3339Nano)})
+	}
+	expected := []quote{
+		{"BTC", 21932, 980_000_000, "2023-02-09T20:30:37.167359596Z"},
+		{"DOGE", 0, 85_130_000, "2023-02-09T20:30:37.167359596Z"},
+		{"BTC", 21933, 500_000_000, "2023-02-09T20:30:38.512944817Z"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("quotes=%v, expected=%v", got, expected)
+	}
+}
+
+func TestStream_WatchQuotesBeforeAck(t *testing.T) {
+	msgs := testutil.ReadMessages(t, "testdata/ticker.jsonl")
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	url := testutil.MockWebSocket(t, serve(t, msgs, nil))
+
+	ch, err := (&Stream{URL: url}).Watch(context.Background(), "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := 0
+	for range ch {
+		count++
+	}
+	if count != 3 {
+		t.Fatalf("got %d quotes, expected 3", count)
+	}
+}
+
+func TestStream_WatchSubscriptionFails(t *testing.T) {
+	url := testutil.MockWebSocket(t, serve(t, []string{
+		`{"type":"error","message":"failure to subscribe"}`,
+	}, nil))
+	_, err := (&Stream{URL: url}).Watch(context.Background(), "BTC", "DOGE")
+	if err == nil || !strings.Contains(err.Error(), "failure to subscribe") {
+		t.Fatalf("expected subscription error, got: %v", err)
+	}
+}
+
+func TestStream_WatchPing(t *testing.T) {
+	defer func(p, r time.Duration) { pingInterval, readTimeout = p, r }(pingInterval, readTimeout)
+	pingInterval, readTimeout = time.Millisecond*10, time.Millisecond*100
+
+	// the server only answers pings (as the default ping handler does while
+	// reading), so the connection stays open past the read timeout
+	url := testutil.MockWebSocket(t, serve(t, testutil.ReadMessages(t, "testdata/ticker.jsonl")[:1], func(conn *ws.Conn) {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*500)
+	defer cancel()
+	ch, err := (&Stream{URL: url}).Watch(ctx, "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for range ch {
+		t.Fatal("unexpected quote")
+	}
+	if elapsed := time.Since(start); elapsed < time.Millisecond*400 {
+		t.Fatalf("stream closed after %v, before ctx was done", elapsed)
+	}
+}
+
+func TestStream_WatchIdleTimeout(t *testing.T) {
+	defer func(p, r time.Duration) { pingInterval, readTimeout = p, r }(pingInterval, readTimeout)
+	pingInterval, readTimeout = time.Hour, time.Millisecond*50
+
+	done := make(chan struct{})
+	defer close(done)
+	url := testutil.MockWebSocket(t, serve(t, testutil.ReadMessages(t, "testdata/ticker.jsonl")[:1], func(conn *ws.Conn) {
+		<-done // stay connected but silent
+	}))
+	ch, err := (&Stream{URL: url}).Watch(context.Background(), "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("unexpected quote")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed after the connection went idle")
+	}
+}
+
+func Test_fromProductID(t *testing.T) {
+	for in, expected := range map[string]string{
+		"BTC-USD":  "BTC",
+		"BTC-USDC": "BTC",
+		"DOGE-USD": "DOGE",
+	} {
+		if got := fromProductID(in); got != expected {
+			t.Errorf("fromProductID(%q)=%q, expected=%q", in, got, expected)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package realtimequote
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/realtimequote/common"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// AggregationMode decides how the price of a product is picked from the
+// quotes of the sources of a CompositeQuoteStream.
+type AggregationMode string
+
+const (
+	// AggregateMedian emits the median price of the active sources.
+	AggregateMedian AggregationMode = "median"
+	// AggregatePriority emits the price of the first active source in the
+	// order the sources are given, and fails over to the next one when it
+	// goes stale or deviates from the others.
+	AggregatePriority AggregationMode = "priority"
+)
+
+// DefaultMaxQuoteDeviation is the ratio a source's price can deviate from the
+// median of all sources before it's dropped as an outlier.
+const DefaultMaxQuoteDeviation = 0.02
+
+// QuoteSource is a named QuoteStream (e.g. an exchange).
+type QuoteSource struct {
+	Name   string
+	Stream QuoteStream
+}
+
+// CompositeQuoteStream is a QuoteStream that watches multiple sources and
+// emits a single price per product aggregated from the sources that are
+// active: the sources that have sent a quote in the stale quote period and
+// that don't deviate from the majority of the others.
+type CompositeQuoteStream struct {
+	logger           *zap.Logger
+	sources          []QuoteSource
+	mode             AggregationMode
+	maxDeviation     decimal.Decimal
+	staleQuotePeriod time.Duration
+	now              func() time.Time
+
+	lock   sync.RWMutex
+	active map[string]activeSources
+}
+
+type activeSources struct {
+	names []string
+	at    time.Time
+}
+
+// NewCompositeQuoteStream returns a stream aggregating the quotes of the
+// sources with mode. Sources deviating from the median more than the
+// maxDeviation ratio are dropped (0 disables the check).
+func NewCompositeQuoteStream(log *zap.Logger, mode AggregationMode, maxDeviation float64, sources ...QuoteSource) (*CompositeQuoteStream, error) {
+	if mode != AggregateMedian && mode != AggregatePriority {
+		return nil, fmt.Errorf("unknown quote aggregation mode %q", mode)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no quote sources given")
+	}
+	if maxDeviation < 0 {
+		return nil, fmt.Errorf("max quote deviation cannot be negative: %v", maxDeviation)
+	}
+	return &CompositeQuoteStream{
+		logger:           log,
+		sources:          sources,
+		mode:             mode,
+		maxDeviation:     decimal.NewFromFloat(maxDeviation),
+		staleQuotePeriod: DefaultStaleQuotePeriod,
+		now:              time.Now,
+		active:           make(map[string]activeSources),
+	}, nil
+}
+
+// ActiveSources returns the names of the sources the latest quote of the
+// product was aggregated from, or none if there hasn't been a quote in the
+// stale quote period.
+func (c *CompositeQuoteStream) ActiveSources(product string) []string {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	a := c.active[product]
+	if c.now().Sub(a.at) > c.staleQuotePeriod {
+		return nil
+	}
+	return append([]string(nil), a.names...)
+}
+
+type sourceQuote struct {
+	source int
+	q      Quote
+}
+
+type receivedQuote struct {
+	q     Quote
+	price decimal.Decimal
+	at    time.Time
+}
+
+// Watch connects to all sources and emits the aggregated quotes of the
+// products. Sources that disconnect are reconnected in the background, so
+// the returned channel is only closed when ctx is done. err is returned if
+// none of the sources can be connected.
+func (c *CompositeQuoteStream) Watch(ctx context.Context, products ...string) (<-chan Quote, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var (
+		wg        sync.WaitGroup
+		errs      []string
+		connected int
+	)
+	in := make(chan sourceQuote)
+	for i, src := range c.sources {
+		ch, err := src.Stream.Watch(ctx, products...)
+		if err != nil {
+			c.logger.Warn("failed to connect to quote source", zap.String("source", src.Name), zap.Error(err))
+			errs = append(errs, fmt.Sprintf("%s: %v", src.Name, err))
+		} else {
+			connected++
+		}
+		wg.Add(1)
+		go func(i int, ch <-chan Quote) {
+			defer wg.Done()
+			c.watchSource(ctx, i, ch, products, in)
+		}(i, ch)
+	}
+	if connected == 0 {
+		cancel()
+		wg.Wait()
+		return nil, fmt.Errorf("failed to connect to any quote source: %s", strings.Join(errs, "; "))
+	}
+
+	out := make(chan Quote)
+	go func() {
+		defer close(out)
+		defer cancel()
+		latest := make(map[string][]receivedQuote)
+		prevActive := make(map[string]string)
+		for {
+			var sq sourceQuote
+			select {
+			case <-ctx.Done():
+				return
+			case sq = <-in:
+			}
+			if sq.q.Price == nil {
+				continue
+			}
+			p := sq.q.Product
+			if latest[p] == nil {
+				latest[p] = make([]receivedQuote, len(c.sources))
+			}
+			now := c.now()
+			latest[p][sq.source] = receivedQuote{q: sq.q, price: toDecimal(sq.q.Price), at: now}
+			q, active, ok := c.aggregate(latest[p], now)
+			if !ok {
+				continue
+			}
+			names := make([]string, len(active))
+			for i, v := range active {
+				names[i] = c.sources[v].Name
+			}
+			c.lock.Lock()
+			c.active[p] = activeSources{names: names, at: now}
+			c.lock.Unlock()
+			if s := strings.Join(names, ","); s != prevActive[p] {
+				c.logger.Info("active quote sources changed", zap.String("product", p), zap.Strings("sources", names))
+				prevActive[p] = s
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- q:
+			}
+		}
+	}()
+	return out, nil
+}
+
+// watchSource relays the quotes of the i-th source to out, and reconnects to
+// the source when its stream is broken (or ch is nil) until ctx is done.
+func (c *CompositeQuoteStream) watchSource(ctx context.Context, i int, ch <-chan Quote, products []string, out chan<- sourceQuote) {
+	src := c.sources[i]
+	defer func() {
+		if ch != nil {
+			go func() {
+				for range ch { // unblock the source until it sees ctx is done
+				}
+			}()
+		}
+	}()
+	for {
+		if ch != nil {
+			for q := range ch {
+				select {
+				case <-ctx.Done():
+					return
+				case out <- sourceQuote{source: i, q: q}:
+				}
+			}
+			ch = nil
+			if ctx.Err() == nil {
+				c.logger.Warn("quote source disconnected", zap.String("source", src.Name))
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(DefaultReconnectInterval):
+		}
+		var err error
+		ch, err = src.Stream.Watch(ctx, products...)
+		if err != nil {
+			c.logger.Debug("failed to reconnect to quote source", zap.String("source", src.Name), zap.Error(err))
+			ch = nil
+		}
+	}
+}
+
+// aggregate returns the quote picked from the latest quotes of the sources
+// at now, and the indexes of the sources that were considered active.
+func (c *CompositeQuoteStream) aggregate(latest []receivedQuote, now time.Time) (Quote, []int, bool) {
+	var fresh []int
+	for i, r := range latest {
+		if r.q.Price != nil && now.Sub(r.at) <= c.staleQuotePeriod {
+			fresh = append(fresh, i)
+		}
+	}
+	if len(fresh) == 0 {
+		return Quote{}, nil, false
+	}
+	if c.maxDeviation.IsPositive() {
+		fresh = dropOutliers(latest, fresh, c.maxDeviation)
+	}
+	if c.mode == AggregatePriority {
+		return latest[fresh[0]].q, fresh, true
+	}
+	prices := make([]decimal.Decimal, len(fresh))
+	var t time.Time
+	for i, v := range fresh {
+		prices[i] = latest[v].price
+		if latest[v].q.Time.After(t) {
+			t = latest[v].q.Time
+		}
+	}
+	return Quote{
+		Product: latest[fresh[0]].q.Product,
+		Price:   common.ParsePrice(median(prices).StringFixed(9)),
+		Time:    t,
+	}, fresh, true
+}
+
+// dropOutliers returns the sources (indexes of latest) whose prices are within
+// maxDeviation of their median. An outlier can only be told apart when the
+// remaining sources are the majority, otherwise all sources are kept.
+func dropOutliers(latest []receivedQuote, sources []int, maxDeviation decimal.Decimal) []int {
+	prices := make([]decimal.Decimal, len(sources))
+	for i, v := range sources {
+		prices[i] = latest[v].price
+	}
+	m := median(prices)
+	if m.IsZero() {
+		return sources
+	}
+	var out []int
+	for _, v := range sources {
+		if latest[v].price.Sub(m).Abs().Div(m.Abs()).LessThanOrEqual(maxDeviation) {
+			out = append(out, v)
+		}
+	}
+	if len(out)*2 <= len(sources) {
+		return sources
+	}
+	return out
+}
+
+func median(v []decimal.Decimal) decimal.Decimal {
+	s := append([]decimal.Decimal(nil), v...)
+	sort.Slice(s, func(i, j int) bool { return s[i].LessThan(s[j]) })
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return s[len(s)/2-1].Add(s[len(s)/2]).Div(decimal.NewFromInt(2))
+}
+
+func toDecimal(a *grpcoin.Amount) decimal.Decimal {
+	return decimal.New(a.Units, 0).Add(decimal.New(int64(a.Nanos), -9))
+}
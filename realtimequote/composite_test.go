@@ -0,0 +1,328 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package realtimequote
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"go.uber.org/zap"
+)
+
+// fakeStream is a QuoteStream whose quotes are sent by the test. Each Watch
+// creates a new connection, and disconnect breaks the current one.
+type fakeStream struct {
+	mu    sync.Mutex
+	ch    chan Quote
+	err   error
+	conns int
+}
+
+func (f *fakeStream) Watch(ctx context.Context, products ...string) (<-chan Quote, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.conns++
+	f.ch = make(chan Quote)
+	return f.ch, nil
+}
+
+func (f *fakeStream) send(t *testing.T, q Quote) {
+	t.Helper()
+	f.mu.Lock()
+	ch := f.ch
+	f.mu.Unlock()
+	select {
+	case ch <- q:
+	case <-time.After(time.Second):
+		t.Fatalf("timed out sending quote %v", q)
+	}
+}
+
+func (f *fakeStream) disconnect() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	close(f.ch)
+	f.ch = nil
+}
+
+func (f *fakeStream) connections() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.conns
+}
+
+func recvQuote(t *testing.T, ch <-chan Quote) Quote {
+	t.Helper()
+	select {
+	case q, ok := <-ch:
+		if !ok {
+			t.Fatal("quote stream closed")
+		}
+		return q
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for quote")
+	}
+	return Quote{}
+}
+
+func TestCompositeQuoteStream_aggregate(t *testing.T) {
+	now := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	fresh := func(units int64) receivedQuote {
+		q := Quote{Product: "BTC", Price: &grpcoin.Amount{Units: units}, Time: now}
+		return receivedQuote{q: q, price: toDecimal(q.Price), at: now}
+	}
+	stale := func(units int64) receivedQuote {
+		r := fresh(units)
+		r.at = now.Add(-DefaultStaleQuotePeriod - time.Second)
+		return r
+	}
+	tests := []struct {
+		name       string
+		mode       AggregationMode
+		latest     []receivedQuote
+		wantOK     bool
+		wantPrice  *grpcoin.Amount
+		wantActive []int
+	}{
+		{
+			name:   "no quotes",
+			mode:   AggregateMedian,
+			latest: []receivedQuote{{}, {}},
+		},
+		{
+			name:   "all stale",
+			mode:   AggregateMedian,
+			latest: []receivedQuote{stale(100), stale(101)},
+		},
+		{
+			name:       "median of odd count",
+			mode:       AggregateMedian,
+			latest:     []receivedQuote{fresh(101), fresh(100), fresh(102)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 101},
+			wantActive: []int{0, 1, 2},
+		},
+		{
+			name:       "median of even count",
+			mode:       AggregateMedian,
+			latest:     []receivedQuote{fresh(100), fresh(101)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 100, Nanos: 500_000_000},
+			wantActive: []int{0, 1},
+		},
+		{
+			name:       "stale source dropped",
+			mode:       AggregateMedian,
+			latest:     []receivedQuote{stale(90), fresh(100), fresh(101)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 100, Nanos: 500_000_000},
+			wantActive: []int{1, 2},
+		},
+		{
+			name:       "missing source",
+			mode:       AggregateMedian,
+			latest:     []receivedQuote{{}, fresh(100)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 100},
+			wantActive: []int{1},
+		},
+		{
+			name:       "outlier dropped",
+			mode:       AggregateMedian,
+			latest:     []receivedQuote{fresh(100), fresh(150), fresh(101)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 100, Nanos: 500_000_000},
+			wantActive: []int{0, 2},
+		},
+		{
+			name:       "no majority to tell the outlier",
+			mode:       AggregateMedian,
+			latest:     []receivedQuote{fresh(100), fresh(150)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 125},
+			wantActive: []int{0, 1},
+		},
+		{
+			name:       "priority picks first",
+			mode:       AggregatePriority,
+			latest:     []receivedQuote{fresh(100), fresh(101), fresh(102)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 100},
+			wantActive: []int{0, 1, 2},
+		},
+		{
+			name:       "priority fails over from stale",
+			mode:       AggregatePriority,
+			latest:     []receivedQuote{stale(100), fresh(101), fresh(102)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 101},
+			wantActive: []int{1, 2},
+		},
+		{
+			name:       "priority fails over from outlier",
+			mode:       AggregatePriority,
+			latest:     []receivedQuote{fresh(50), fresh(101), fresh(102)},
+			wantOK:     true,
+			wantPrice:  &grpcoin.Amount{Units: 101},
+			wantActive: []int{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := make([]QuoteSource, len(tt.latest))
+			c, err := NewCompositeQuoteStream(zap.NewNop(), tt.mode, DefaultMaxQuoteDeviation, sources...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			q, active, ok := c.aggregate(tt.latest, now)
+			if ok != tt.wantOK {
+				t.Fatalf("ok=%v, expected=%v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if q.Price.Units != tt.wantPrice.Units || q.Price.Nanos != tt.wantPrice.Nanos {
+				t.Fatalf("price=%v, expected=%v", q.Price, tt.wantPrice)
+			}
+			if q.Product != "BTC" {
+				t.Fatalf("product=%q", q.Product)
+			}
+			if !reflect.DeepEqual(active, tt.wantActive) {
+				t.Fatalf("active=%v, expected=%v", active, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestNewCompositeQuoteStream_validation(t *testing.T) {
+	src := QuoteSource{Name: "a", Stream: &fakeStream{}}
+	if _, err := NewCompositeQuoteStream(zap.NewNop(), "average", 0, src); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+	if _, err := NewCompositeQuoteStream(zap.NewNop(), AggregateMedian, 0); err == nil {
+		t.Fatal("expected error for no sources")
+	}
+	if _, err := NewCompositeQuoteStream(zap.NewNop(), AggregateMedian, -1, src); err == nil {
+		t.Fatal("expected error for negative deviation")
+	}
+}
+
+func TestCompositeQuoteStream_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a, b := &fakeStream{}, &fakeStream{}
+	c, err := NewCompositeQuoteStream(zap.NewNop(), AggregatePriority, DefaultMaxQuoteDeviation,
+		QuoteSource{Name: "a", Stream: a},
+		QuoteSource{Name: "b", Stream: b})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var skew int64
+	c.now = func() time.Time { return time.Now().Add(time.Duration(atomic.LoadInt64(&skew))) }
+	ch, err := c.Watch(ctx, "BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.send(t, Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 100}})
+	if q := recvQuote(t, ch); q.Price.Units != 100 {
+		t.Fatalf("got price %v, expected 100", q.Price)
+	}
+	b.send(t, Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 101}})
+	if q := recvQuote(t, ch); q.Price.Units != 100 {
+		t.Fatalf("got price %v from the lower priority source, expected 100", q.Price)
+	}
+	if got, expected := c.ActiveSources("BTC"), []string{"a", "b"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("active sources=%v, expected=%v", got, expected)
+	}
+	if got := c.ActiveSources("ETH"); len(got) != 0 {
+		t.Fatalf("expected no active sources for ETH, got %v", got)
+	}
+
+	// a goes quiet, b takes over once a's quote is stale
+	atomic.StoreInt64(&skew, int64(DefaultStaleQuotePeriod+time.Second))
+	b.send(t, Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 102}})
+	if q := recvQuote(t, ch); q.Price.Units != 102 {
+		t.Fatalf("got price %v, expected failover to 102", q.Price)
+	}
+	if got, expected := c.ActiveSources("BTC"), []string{"b"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("active sources=%v, expected=%v", got, expected)
+	}
+
+	// disconnected source is reconnected
+	a.disconnect()
+	deadline := time.Now().Add(time.Second)
+	for a.connections() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("source was not reconnected")
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	a.send(t, Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 103}})
+	if q := recvQuote(t, ch); q.Price.Units != 103 {
+		t.Fatalf("got price %v, expected 103 from the reconnected source", q.Price)
+	}
+
+	cancel()
+	for range ch {
+	}
+}
+
+func TestCompositeQuoteStream_WatchFailsOver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	down := &fakeStream{err: errors.New("connection refused")}
+	up := &fakeStream{}
+	c, err := NewCompositeQuoteStream(zap.NewNop(), AggregateMedian, DefaultMaxQuoteDeviation,
+		QuoteSource{Name: "down", Stream: down},
+		QuoteSource{Name: "up", Stream: up})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := c.Watch(ctx, "BTC")
+	if err != nil {
+		t.Fatalf("expected to connect with one of the sources up: %v", err)
+	}
+	up.send(t, Quote{Product: "BTC", Price: &grpcoin.Amount{Units: 100}})
+	if q := recvQuote(t, ch); q.Price.Units != 100 {
+		t.Fatalf("got price %v, expected 100", q.Price)
+	}
+	if got, expected := c.ActiveSources("BTC"), []string{"up"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("active sources=%v, expected=%v", got, expected)
+	}
+}
+
+func TestCompositeQuoteStream_WatchAllDown(t *testing.T) {
+	c, err := NewCompositeQuoteStream(zap.NewNop(), AggregateMedian, 0,
+		QuoteSource{Name: "a", Stream: &fakeStream{err: errors.New("a is down")}},
+		QuoteSource{Name: "b", Stream: &fakeStream{err: errors.New("b is down")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Watch(context.Background(), "BTC"); err == nil {
+		t.Fatal("expected error when no source can be connected")
+	}
+}
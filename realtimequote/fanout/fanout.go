@@ -16,6 +16,7 @@ package fanout
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"github.com/grpcoin/grpcoin/realtimequote"
@@ -39,33 +40,53 @@ func (q *QuoteFanoutService) initWatch() error {
 		return nil
 	}
 
-	ctx, stop := context.WithCancel(context.Background())
-	go func() {
-		<-ctx.Done()
-		q.lock.Lock()
-		q.bus = nil
-		q.lock.Unlock()
-	}()
+	ctx, cancel := context.WithCancel(context.Background())
 	quotes, err := q.quoteStreamInitializer(ctx)
 	if err != nil {
-		stop()
+		cancel()
 		q.lock.Unlock()
 		return err
 	}
-	q.bus = pubsub.NewPubSub(quotes, stop)
+	var bus *pubsub.PubSub
+	stop := func() {
+		cancel()
+		// reset right away, so new watches don't subscribe to a stopped bus
+		q.lock.Lock()
+		if q.bus == bus {
+			q.bus = nil
+		}
+		q.lock.Unlock()
+	}
+	bus = pubsub.NewPubSub(quotes, stop)
+	q.bus = bus
 	q.lock.Unlock()
 	return nil
 }
 
+// RegisterWatch returns the quotes until ctx is done, with the default
+// subscription options.
 func (q *QuoteFanoutService) RegisterWatch(ctx context.Context) (<-chan realtimequote.Quote, error) {
+	ch, _, err := q.RegisterWatchWithOptions(ctx, pubsub.SubOptions{})
+	return ch, err
+}
+
+// RegisterWatchWithOptions returns the quotes until ctx is done, buffered for
+// a slow receiver with opts. The subscription reports the dropped quotes.
+func (q *QuoteFanoutService) RegisterWatchWithOptions(ctx context.Context, opts pubsub.SubOptions) (<-chan realtimequote.Quote, *pubsub.Subscription, error) {
 	ch := make(chan realtimequote.Quote)
 	if err := q.initWatch(); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	q.lock.Lock()
+	bus := q.bus // bus can be reset to nil when the quote stream closes
+	q.lock.Unlock()
+	if bus == nil {
+		return nil, nil, errors.New("quote stream closed while registering watch")
 	}
-	q.bus.Sub(ch)
+	sub := bus.SubWithOptions(ch, opts)
 	go func() {
 		<-ctx.Done()
-		q.bus.Unsub(ch)
+		bus.Unsub(ch)
 	}()
-	return ch, nil
+	return ch, sub, nil
 }
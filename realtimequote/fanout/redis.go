@@ -0,0 +1,211 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/common"
+	"go.uber.org/zap"
+)
+
+const (
+	quotesChannel = "quotes"
+	leaderLockKey = "quotes::leader"
+
+	// DefaultLeaderLockTTL is how long the leader holds the lock without
+	// refreshing it, i.e. how long it takes to fail over a dead leader.
+	DefaultLeaderLockTTL = time.Second * 3
+)
+
+var (
+	// refreshLock extends the lock if it's still held by the instance.
+	refreshLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+	// releaseLock deletes the lock if it's still held by the instance.
+	releaseLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+type relayedQuote struct {
+	Product string    `json:"product"`
+	Price   string    `json:"price"`
+	Time    time.Time `json:"time"`
+}
+
+// RedisRelay shares a single quote stream across the server instances. The
+// instance holding the leader lock in Redis watches the stream and publishes
+// its quotes to a Redis channel, and all instances get the quotes from
+// there. If the leader dies, another instance takes over once the lock
+// expires.
+type RedisRelay struct {
+	rc       *redis.Client
+	log      *zap.Logger
+	qs       realtimequote.QuoteStream
+	products []string
+	id       string
+	lockTTL  time.Duration
+}
+
+// NewRedisRelay returns a relay of the products' quotes from qs. The relay
+// only watches qs while Run is active and the instance is the leader.
+func NewRedisRelay(rc *redis.Client, log *zap.Logger, qs realtimequote.QuoteStream, products ...string) *RedisRelay {
+	return &RedisRelay{
+		rc:       rc,
+		log:      log,
+		qs:       qs,
+		products: products,
+		id:       uuid.New().String(),
+		lockTTL:  DefaultLeaderLockTTL,
+	}
+}
+
+// Run competes for the leader lock, and publishes the quotes while it's the
+// leader until ctx is done. It's meant to be invoked in a goroutine.
+func (r *RedisRelay) Run(ctx context.Context) {
+	t := time.NewTicker(r.lockTTL / 3)
+	defer t.Stop()
+	for {
+		ok, err := r.rc.SetNX(ctx, leaderLockKey, r.id, r.lockTTL).Result()
+		if err != nil && ctx.Err() == nil {
+			r.log.Warn("failed to acquire quote relay lock", zap.Error(err))
+		}
+		if ok {
+			r.log.Info("became the quote relay leader", zap.String("id", r.id))
+			r.lead(ctx, t.C)
+			r.log.Info("stopped leading the quote relay", zap.String("id", r.id))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// lead publishes the quotes and refreshes the lock on every tick, until the
+// lock is lost or ctx is done.
+func (r *RedisRelay) lead(ctx context.Context, tick <-chan time.Time) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go r.publish(leaderCtx)
+	defer func() {
+		// released on a new ctx as ctx is likely done
+		if err := releaseLock.Run(context.Background(), r.rc, []string{leaderLockKey}, r.id).Err(); err != nil {
+			r.log.Warn("failed to release quote relay lock", zap.Error(err))
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		}
+		held, err := refreshLock.Run(ctx, r.rc, []string{leaderLockKey}, r.id, r.lockTTL.Milliseconds()).Int()
+		if err != nil {
+			r.log.Warn("failed to refresh quote relay lock", zap.Error(err))
+			return
+		}
+		if held == 0 {
+			r.log.Warn("lost quote relay lock")
+			return
+		}
+	}
+}
+
+// publish watches the quote stream and publishes its quotes until ctx is
+// done, reconnecting to the stream if it breaks.
+func (r *RedisRelay) publish(ctx context.Context) {
+	for ctx.Err() == nil {
+		ch, err := r.qs.Watch(ctx, r.products...)
+		if err != nil {
+			r.log.Warn("failed to watch quotes to relay", zap.Error(err))
+			time.Sleep(realtimequote.DefaultReconnectInterval)
+			continue
+		}
+		for q := range ch {
+			b, err := json.Marshal(relayedQuote{
+				Product: q.Product,
+				Price:   fmt.Sprintf("%d.%09d", q.Price.GetUnits(), q.Price.GetNanos()),
+				Time:    q.Time})
+			if err != nil {
+				r.log.Warn("failed to pack quote", zap.Error(err))
+				continue
+			}
+			if err := r.rc.Publish(ctx, quotesChannel, b).Err(); err != nil && ctx.Err() == nil {
+				r.log.Warn("failed to publish quote", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Watch returns the quotes of the products published by the leader. The
+// returned channel is closed when ctx is done.
+func (r *RedisRelay) Watch(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
+	sub := r.rc.Subscribe(ctx, quotesChannel)
+	// wait for the subscription to be confirmed, so no messages are missed
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("failed to subscribe to relayed quotes: %w", err)
+	}
+	want := make(map[string]bool, len(products))
+	for _, p := range products {
+		want[p] = true
+	}
+	out := make(chan realtimequote.Quote)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+		msgs := sub.Channel()
+		for {
+			var m *redis.Message
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-msgs:
+				if !ok {
+					return
+				}
+				m = v
+			}
+			var q relayedQuote
+			if err := json.Unmarshal([]byte(m.Payload), &q); err != nil {
+				r.log.Warn("failed to unpack relayed quote", zap.Error(err))
+				continue
+			}
+			if !want[q.Product] {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- realtimequote.Quote{Product: q.Product, Price: common.ParsePrice(q.Price), Time: q.Time}:
+			}
+		}
+	}()
+	return out, nil
+}
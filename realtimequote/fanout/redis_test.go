@@ -0,0 +1,204 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanout
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"go.uber.org/zap"
+)
+
+// upstream is a quote stream emitting a quote every few milliseconds while
+// it's watched.
+type upstream struct {
+	mu       sync.Mutex
+	watchers int
+}
+
+func (u *upstream) Watch(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
+	u.mu.Lock()
+	u.watchers++
+	u.mu.Unlock()
+	ch := make(chan realtimequote.Quote)
+	go func() {
+		defer close(ch)
+		defer func() {
+			u.mu.Lock()
+			u.watchers--
+			u.mu.Unlock()
+		}()
+		for i := int64(1); ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Millisecond * 5):
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- realtimequote.Quote{Product: "BTC", Price: &grpcoin.Amount{Units: i, Nanos: 5}, Time: time.Now()}:
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (u *upstream) watching() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.watchers > 0
+}
+
+func newRelay(t *testing.T, s *miniredis.Miniredis) (*RedisRelay, *upstream, *redis.Client) {
+	rc := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { rc.Close() })
+	u := &upstream{}
+	r := NewRedisRelay(rc, zap.NewNop(), u, "BTC")
+	r.lockTTL = time.Millisecond * 300
+	return r, u, rc
+}
+
+func waitFor(t *testing.T, msg string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 3)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for: %s", msg)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func recvQuote(t *testing.T, ch <-chan realtimequote.Quote) realtimequote.Quote {
+	t.Helper()
+	select {
+	case q, ok := <-ch:
+		if !ok {
+			t.Fatal("relayed quotes channel closed")
+		}
+		return q
+	case <-time.After(time.Second * 3):
+		t.Fatal("timed out waiting for a relayed quote")
+	}
+	return realtimequote.Quote{}
+}
+
+func TestRedisRelay(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r1, u1, _ := newRelay(t, s)
+	r2, u2, _ := newRelay(t, s)
+
+	ch1, err := r1.Watch(ctx, "BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch2, err := r2.Watch(ctx, "BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx1, cancel1 := context.WithCancel(ctx)
+	defer cancel1()
+	go r1.Run(ctx1)
+	waitFor(t, "r1 to lead", u1.watching)
+	go r2.Run(ctx)
+
+	for _, ch := range []<-chan realtimequote.Quote{ch1, ch2} {
+		q := recvQuote(t, ch)
+		if q.Product != "BTC" || q.Price.GetNanos() != 5 || q.Time.IsZero() {
+			t.Fatalf("wrong relayed quote: %#v", q)
+		}
+	}
+	time.Sleep(r1.lockTTL * 2) // lock is refreshed while leading
+	if !u1.watching() || u2.watching() {
+		t.Fatalf("expected only r1 to watch the upstream, r1=%v r2=%v", u1.watching(), u2.watching())
+	}
+
+	// leader steps down
+	cancel1()
+	waitFor(t, "r2 to take over", u2.watching)
+	waitFor(t, "r1 to stop watching", func() bool { return !u1.watching() })
+	recvQuote(t, ch2)
+}
+
+func TestRedisRelay_leaderDies(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r1, u1, rc1 := newRelay(t, s)
+	r2, u2, _ := newRelay(t, s)
+	go r1.Run(ctx)
+	waitFor(t, "r1 to lead", u1.watching)
+	go r2.Run(ctx)
+
+	// r1 loses its connection without releasing the lock
+	rc1.Close()
+	waitFor(t, "r1 to stop watching", func() bool { return !u1.watching() })
+	if u2.watching() {
+		t.Fatal("r2 took over before the lock expired")
+	}
+	s.FastForward(r1.lockTTL) // miniredis expires keys only when told
+	waitFor(t, "r2 to take over", u2.watching)
+
+	ch, err := r2.Watch(ctx, "BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recvQuote(t, ch)
+}
+
+func TestRedisRelay_WatchFiltersProducts(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r, _, _ := newRelay(t, s)
+	ch, err := r.Watch(ctx, "ETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go r.Run(ctx)
+	select {
+	case q := <-ch:
+		t.Fatalf("unexpected quote: %#v", q)
+	case <-time.After(time.Millisecond * 100):
+	}
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Fatal("channel should be closed when ctx is done")
+	}
+}
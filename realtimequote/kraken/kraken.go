@@ -0,0 +1,249 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kraken provides quotes from the ticker channel of Kraken's
+// WebSocket API (v2).
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	ws "github.com/gorilla/websocket"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/common"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const DefaultURL = "wss://ws.kraken.com/v2"
+
+var (
+	// pingInterval is how often the connection is checked with a ping
+	// request, and readTimeout is how long the connection can be idle
+	// before it's considered broken (Kraken sends heartbeats every second).
+	pingInterval = time.Second * 20
+	readTimeout  = time.Second * 30
+	ackTimeout   = time.Second * 10
+)
+
+// aliases are the legacy Kraken asset codes of the tickers.
+var aliases = map[string]string{
+	"XBT": "BTC",
+	"XDG": "DOGE",
+}
+
+// Stream is a QuoteStream of the last trade prices of the products in USD.
+type Stream struct {
+	// URL is the WebSocket API endpoint, DefaultURL if empty.
+	URL    string
+	Logger *zap.Logger
+	// Symbol returns the symbol of a product on Kraken, if it doesn't follow
+	// the PRODUCT/USD convention (optional).
+	Symbol func(product string) string
+}
+
+type request struct {
+	Method string  `json:"method"`
+	Params *params `json:"params,omitempty"`
+	ReqID  int     `json:"req_id,omitempty"`
+}
+
+type params struct {
+	Channel string   `json:"channel"`
+	Symbol  []string `json:"symbol"`
+}
+
+type message struct {
+	// responses to requests
+	Method  string          `json:"method"`
+	Success bool            `json:"success"`
+	Error   string          `json:"error"`
+	Result  json.RawMessage `json:"result"`
+	Symbol  string          `json:"symbol"`
+
+	// channel messages
+	Channel string   `json:"channel"`
+	Type    string   `json:"type"`
+	Data    []ticker `json:"data"`
+}
+
+type subscribeResult struct {
+	Symbol string `json:"symbol"`
+}
+
+type ticker struct {
+	Symbol    string      `json:"symbol"`
+	Last      json.Number `json:"last"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+func toSymbol(product string) string { return product + "/USD" }
+
+// productsBySymbol maps the symbols to the products watched.
+type productsBySymbol map[string]string
+
+func (s productsBySymbol) product(symbol string) string {
+	if v, ok := s[symbol]; ok {
+		return v
+	}
+	return fromSymbol(symbol)
+}
+
+func fromSymbol(symbol string) string {
+	base := strings.TrimSuffix(symbol, "/USD")
+	if v, ok := aliases[base]; ok {
+		return v
+	}
+	return base
+}
+
+// Watch subscribes to the tickers of the products. err is returned if it
+// fails to connect or a subscription is rejected.
+func (s *Stream) Watch(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
+	url := s.URL
+	if url == "" {
+		url = DefaultURL
+	}
+	log := s.Logger
+	if log == nil {
+		log = zap.NewNop()
+	}
+	syms := make([]string, len(products))
+	bySymbol := make(productsBySymbol, len(products))
+	for i, p := range products {
+		syms[i] = toSymbol(p)
+		if s.Symbol != nil && s.Symbol(p) != "" {
+			syms[i] = s.Symbol(p)
+		}
+		bySymbol[syms[i]] = p
+	}
+
+	conn, _, err := ws.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial kraken: %w", err)
+	}
+	if err := conn.WriteJSON(request{Method: "subscribe", Params: &params{Channel: "ticker", Symbol: syms}}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+	if err := waitForAcks(conn, syms); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ping, timeout := pingInterval, readTimeout
+	ch := make(chan realtimequote.Quote)
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		conn.Close()
+	}()
+	go func() {
+		t := time.NewTicker(ping)
+		defer t.Stop()
+		for id := 1; ; id++ {
+			select {
+			case <-stop:
+				return
+			case <-t.C:
+				// reads and writes are concurrent, but this is the only writer
+				if err := conn.WriteJSON(request{Method: "ping", ReqID: id}); err != nil {
+					log.Debug("failed to send ping", zap.Error(err))
+					return
+				}
+			}
+		}
+	}()
+	go func() {
+		defer close(ch)
+		defer close(stop)
+		for {
+			conn.SetReadDeadline(time.Now().Add(timeout))
+			var m message
+			if err := conn.ReadJSON(&m); err != nil {
+				if ctx.Err() == nil {
+					log.Warn("kraken read failed", zap.Error(err))
+				}
+				return
+			}
+			if m.Channel != "ticker" {
+				continue // heartbeat, status or pong
+			}
+			for _, t := range m.Data {
+				q, err := toQuote(t, bySymbol)
+				if err != nil {
+					log.Warn("failed to parse kraken ticker", zap.String("symbol", t.Symbol), zap.Error(err))
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- q:
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// waitForAcks reads the messages until the subscriptions of all symbols are
+// acknowledged.
+func waitForAcks(conn *ws.Conn, symbols []string) error {
+	pending := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		pending[s] = true
+	}
+	conn.SetReadDeadline(time.Now().Add(ackTimeout))
+	for len(pending) > 0 {
+		var m message
+		if err := conn.ReadJSON(&m); err != nil {
+			return fmt.Errorf("failed to read subscription status: %w", err)
+		}
+		if m.Method != "subscribe" {
+			continue
+		}
+		if !m.Success {
+			return fmt.Errorf("kraken subscription failed for %s: %s", m.Symbol, m.Error)
+		}
+		var r subscribeResult
+		if err := json.Unmarshal(m.Result, &r); err != nil {
+			return fmt.Errorf("failed to parse subscription result: %w", err)
+		}
+		delete(pending, r.Symbol)
+	}
+	return nil
+}
+
+func toQuote(t ticker, bySymbol productsBySymbol) (realtimequote.Quote, error) {
+	// prices are json numbers, which may be in exponent notation
+	p, err := decimal.NewFromString(t.Last.String())
+	if err != nil {
+		return realtimequote.Quote{}, err
+	}
+	ts := t.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return realtimequote.Quote{
+		Product: bySymbol.product(t.Symbol),
+		Price:   common.ParsePrice(p.StringFixed(9)),
+		Time:    ts}, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kraken
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ws "github.com/gorilla/websocket"
+	"github.com/grpcoin/grpcoin/testutil"
+)
+
+// serve checks the subscription request and replays the messages.
+func serve(t *testing.T, messages []string, then func(conn *ws.Conn)) func(conn *ws.Conn) {
+	return func(conn *ws.Conn) {
+		var req request
+		if err := conn.ReadJSON(&req); err != nil {
+			t.Errorf("failed to read subscription: %v", err)
+			return
+		}
+		expected := request{Method: "subscribe", Params: &params{Channel: "ticker", Symbol: []string{"BTC/USD", "DOGE/USD"}}}
+		if !reflect.DeepEqual(req, expected) {
+			t.Errorf("subscription=%#v, expected=%#v", req, expected)
+			return
+		}
+		for _, m := range messages {
+			if err := conn.WriteMessage(ws.TextMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+		if then != nil {
+			then(conn)
+		}
+	}
+}
+
+func TestStream_Watch(t *testing.T) {
+	url := testutil.MockWebSocket(t, serve(t, testutil.ReadMessages(t, "testdata/ticker.jsonl"), nil))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	ch, err := (&Stream{URL: url}).Watch(ctx, "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type quote struct {
+		product string
+		units   int64
+		nanos   int32
+	}
+	var got []quote
+	for q := range ch {
+		got = append(got, quote{q.Product, q.Price.Units, q.Price.Nanos})
+		if q.Time.IsZero() {
+			t.Fatalf("quote has no time: %#v", q)
+		}
+	}
+	expected := []quote{
+		{"BTC", 26371, 500_000_000},
+		{"DOGE", 0, 61_234_000},
+		{"BTC", 26372, 100_000_000},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("quotes=%v, expected=%v", got, expected)
+	}
+}
+
+func TestStream_WatchSymbol(t *testing.T) {
+	var messages []string
+	for _, m := range testutil.ReadMessages(t, "testdata/ticker.jsonl") {
+		messages = append(messages, strings.ReplaceAll(m, "BTC/USD", "BTC/USDT"))
+	}
+	url := testutil.MockWebSocket(t, func(conn *ws.Conn) {
+		var req request
+		if err := conn.ReadJSON(&req); err != nil {
+			t.Errorf("failed to read subscription: %v", err)
+			return
+		}
+		if expected := []string{"BTC/USDT", "DOGE/USD"}; !reflect.DeepEqual(req.Params.Symbol, expected) {
+			t.Errorf("subscribed symbols=%v, expected=%v", req.Params.Symbol, expected)
+			return
+		}
+		for _, m := range messages {
+			if err := conn.WriteMessage(ws.TextMessage, []byte(m)); err != nil {
+				return
+			}
+		}
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	symbol := func(product string) string {
+		if product == "BTC" {
+			return "BTC/USDT"
+		}
+		return ""
+	}
+	ch, err := (&Stream{URL: url, Symbol: symbol}).Watch(ctx, "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for q := range ch {
+		got = append(got, q.Product)
+	}
+	if expected := []string{"BTC", "DOGE", "BTC"}; !reflect.DeepEqual(got, expected) {
+		t.Fatalf("products=%v, expected=%v", got, expected)
+	}
+}
+
+func TestStream_WatchSubscriptionFails(t *testing.T) {
+	url := testutil.MockWebSocket(t, serve(t, []string{
+		`{"method":"subscribe","result":{"channel":"ticker","symbol":"BTC/USD"},"success":true}`,
+		`{"error":"Currency pair not supported DOGE/USD","method":"subscribe","success":false,"symbol":"DOGE/USD","time_in":"2023-09-25T09:04:31.742599Z","time_out":"2023-09-25T09:04:31.742648Z"}`,
+	}, nil))
+	_, err := (&Stream{URL: url}).Watch(context.Background(), "BTC", "DOGE")
+	if err == nil || !strings.Contains(err.Error(), "DOGE/USD") {
+		t.Fatalf("expected subscription error for DOGE/USD, got: %v", err)
+	}
+}
+
+func TestStream_WatchPing(t *testing.T) {
+	defer func(v time.Duration) { pingInterval = v }(pingInterval)
+	pingInterval = time.Millisecond * 10
+
+	pings := make(chan int, 10)
+	url := testutil.MockWebSocket(t, serve(t, testutil.ReadMessages(t, "testdata/ticker.jsonl")[:3], func(conn *ws.Conn) {
+		for {
+			var req request
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			if req.Method != "ping" {
+				t.Errorf("unexpected request: %#v", req)
+				return
+			}
+			pong, _ := json.Marshal(map[string]interface{}{"method": "pong", "req_id": req.ReqID})
+			if err := conn.WriteMessage(ws.TextMessage, pong); err != nil {
+				return
+			}
+			pings <- req.ReqID
+		}
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := (&Stream{URL: url}).Watch(ctx, "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 2; i++ {
+		select {
+		case id := <-pings:
+			if id != i {
+				t.Fatalf("ping req_id=%d, expected=%d", id, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("no ping received")
+		}
+	}
+	cancel()
+	for range ch {
+		t.Fatal("pongs should not be emitted as quotes")
+	}
+}
+
+func TestStream_WatchIdleTimeout(t *testing.T) {
+	defer func(v time.Duration) { readTimeout = v }(readTimeout)
+	readTimeout = time.Millisecond * 50
+
+	done := make(chan struct{})
+	defer close(done)
+	url := testutil.MockWebSocket(t, serve(t, testutil.ReadMessages(t, "testdata/ticker.jsonl")[:3], func(conn *ws.Conn) {
+		<-done // stay connected but silent
+	}))
+	ch, err := (&Stream{URL: url}).Watch(context.Background(), "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("unexpected quote")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream was not closed after the connection went idle")
+	}
+}
+
+func Test_fromSymbol(t *testing.T) {
+	for in, expected := range map[string]string{
+		"BTC/USD":  "BTC",
+		"XBT/USD":  "BTC",
+		"XDG/USD":  "DOGE",
+		"DOGE/USD": "DOGE",
+		"DOT/USD":  "DOT",
+	} {
+		if got := fromSymbol(in); got != expected {
+			t.Errorf("fromSymbol(%q)=%q, expected=%q", in, got, expected)
+		}
+	}
+}
@@ -15,6 +15,7 @@
 package pubsub
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/grpcoin/grpcoin/realtimequote"
@@ -28,8 +29,74 @@ type PubSub struct {
 	// stop is called when the last client unregisters.
 	stop func()
 
-	mu   sync.Mutex
-	subs map[chan<- realtimequote.Quote]bool
+	mu     sync.Mutex
+	subs   map[chan<- realtimequote.Quote]*Subscription
+	closed bool // src is closed
+}
+
+// Policy decides which messages are dropped when a subscriber falls behind
+// and its buffer is full.
+type Policy int
+
+const (
+	// DropNewest drops the incoming messages.
+	DropNewest Policy = iota
+	// DropOldest drops the oldest buffered message to make room.
+	DropOldest
+	// KeepLatestPerProduct replaces the buffered message of the same product,
+	// so the subscriber gets the latest quote of each product once it catches
+	// up. The buffer is only bounded by the number of products.
+	KeepLatestPerProduct
+)
+
+var policyNames = map[Policy]string{
+	DropNewest:           "drop-newest",
+	DropOldest:           "drop-oldest",
+	KeepLatestPerProduct: "keep-latest",
+}
+
+func (p Policy) String() string {
+	if s, ok := policyNames[p]; ok {
+		return s
+	}
+	return fmt.Sprintf("Policy(%d)", int(p))
+}
+
+// Set parses the policy from its name, so that it can be used as a flag.
+func (p *Policy) Set(s string) error {
+	for v, name := range policyNames {
+		if name == s {
+			*p = v
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown policy %q (must be drop-newest, drop-oldest or keep-latest)", s)
+}
+
+// SubOptions configure how messages are buffered for a subscriber that isn't
+// ready to receive them.
+type SubOptions struct {
+	// Buffer is the number of messages kept for the subscriber, at least 1.
+	Buffer int
+	Policy Policy
+	// MaxDrops is the number of dropped messages after which the subscriber
+	// is disconnected (0 for never).
+	MaxDrops int64
+}
+
+// Subscription delivers the messages to a subscriber.
+type Subscription struct {
+	ch   chan<- realtimequote.Quote
+	opts SubOptions
+
+	ready chan struct{} // signalled when a message is queued
+	done  chan struct{} // closed to stop delivering
+	exit  chan struct{} // closed when delivery is stopped and ch is closed
+
+	mu           sync.Mutex
+	queue        []realtimequote.Quote
+	dropped      int64
+	disconnected bool
 }
 
 // NewPubSub returns an in-memory pubsub topic.
@@ -37,30 +104,60 @@ type PubSub struct {
 // When the last subscriber is unsubscribed, stop is called.
 func NewPubSub(src <-chan realtimequote.Quote, stop func()) *PubSub {
 	p := &PubSub{src: src, stop: stop,
-		subs: make(map[chan<- realtimequote.Quote]bool)}
+		subs: make(map[chan<- realtimequote.Quote]*Subscription)}
 	go p.fanout()
 	return p
 }
 
-// Sub creates a subscription that pushes to ch.
+// Sub creates a subscription that pushes to ch with the default options.
 // If src closes, ch will be closed.
-// If message blocks from being sent on ch, it will be dropped.
-func (p *PubSub) Sub(ch chan<- realtimequote.Quote) {
+// If ch is not ready to receive a message while another one is buffered, the
+// message will be dropped.
+func (p *PubSub) Sub(ch chan<- realtimequote.Quote) *Subscription {
+	return p.SubWithOptions(ch, SubOptions{})
+}
+
+// SubWithOptions creates a subscription that pushes to ch, buffering the
+// messages while ch is not ready with opts.
+// If src closes, or the subscriber is disconnected for dropping too many
+// messages, ch will be closed.
+func (p *PubSub) SubWithOptions(ch chan<- realtimequote.Quote, opts SubOptions) *Subscription {
+	if opts.Buffer < 1 {
+		opts.Buffer = 1
+	}
+	s := &Subscription{
+		ch:    ch,
+		opts:  opts,
+		ready: make(chan struct{}, 1),
+		done:  make(chan struct{}),
+		exit:  make(chan struct{})}
 	p.mu.Lock()
-	p.subs[ch] = true
-	p.mu.Unlock()
+	defer p.mu.Unlock()
+	if p.closed {
+		close(ch)
+		return s
+	}
+	go s.deliver()
+	p.subs[ch] = s
+	return s
 }
 
 // Unsub removes subscription and closes ch.
 func (p *PubSub) Unsub(ch chan<- realtimequote.Quote) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	_, ok := p.subs[ch]
+	s, ok := p.subs[ch]
 	if !ok {
 		return
 	}
-	delete(p.subs, ch)
-	close(ch)
+	p.remove(s)
+}
+
+// remove stops the subscription and closes its ch. p.mu must be held.
+func (p *PubSub) remove(s *Subscription) {
+	delete(p.subs, s.ch)
+	close(s.done)
+	<-s.exit
 	if len(p.subs) == 0 {
 		p.stop()
 	}
@@ -69,20 +166,103 @@ func (p *PubSub) Unsub(ch chan<- realtimequote.Quote) {
 func (p *PubSub) fanout() {
 	for m := range p.src {
 		p.mu.Lock()
-		for c := range p.subs {
-			select {
-			case c <- m:
-			default: // drop message
+		for _, s := range p.subs {
+			if s.offer(m) {
+				s.mu.Lock()
+				s.disconnected = true
+				s.mu.Unlock()
+				p.remove(s)
 			}
 		}
 		p.mu.Unlock()
 	}
 	// if pub ch closes, we close subscribers
 	p.mu.Lock()
-	for c := range p.subs {
-		delete(p.subs, c)
-		close(c)
-	}
+	p.closed = true
 	p.stop()
+	for _, s := range p.subs {
+		delete(p.subs, s.ch)
+		close(s.done)
+		<-s.exit
+	}
 	p.mu.Unlock()
 }
+
+// Dropped returns the number of messages dropped for the subscriber.
+func (s *Subscription) Dropped() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Disconnected reports whether the subscriber was disconnected for dropping
+// too many messages.
+func (s *Subscription) Disconnected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.disconnected
+}
+
+// offer queues m with the subscription's policy, and reports whether the
+// subscriber should be disconnected.
+func (s *Subscription) offer(m realtimequote.Quote) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.opts.Policy == KeepLatestPerProduct && s.replace(m):
+		s.dropped++
+	case s.opts.Policy == KeepLatestPerProduct || len(s.queue) < s.opts.Buffer:
+		s.queue = append(s.queue, m)
+	case s.opts.Policy == DropOldest:
+		copy(s.queue, s.queue[1:])
+		s.queue[len(s.queue)-1] = m
+		s.dropped++
+	default:
+		s.dropped++
+	}
+	select {
+	case s.ready <- struct{}{}:
+	default: // already signalled
+	}
+	return s.opts.MaxDrops > 0 && s.dropped >= s.opts.MaxDrops
+}
+
+// replace replaces the queued message of m's product with m, if any.
+func (s *Subscription) replace(m realtimequote.Quote) bool {
+	for i, v := range s.queue {
+		if v.Product == m.Product {
+			s.queue[i] = m
+			return true
+		}
+	}
+	return false
+}
+
+// deliver sends the queued messages to ch until the subscription is stopped,
+// and closes ch.
+func (s *Subscription) deliver() {
+	defer close(s.exit)
+	defer close(s.ch)
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ready:
+		}
+		for {
+			s.mu.Lock()
+			if len(s.queue) == 0 {
+				s.mu.Unlock()
+				break
+			}
+			m := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			select {
+			case <-s.done:
+				return
+			case s.ch <- m:
+			}
+		}
+	}
+}
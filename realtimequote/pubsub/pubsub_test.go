@@ -15,11 +15,13 @@
 package pubsub
 
 import (
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/grpcoin/grpcoin/api/grpcoin"
 	"github.com/grpcoin/grpcoin/realtimequote"
 )
 
@@ -114,3 +116,118 @@ func TestPubSubOnSourceClose(t *testing.T) {
 		t.Fatalf("stop() should have been called")
 	}
 }
+
+func TestSubscription_offer(t *testing.T) {
+	q := func(product string, units int64) realtimequote.Quote {
+		return realtimequote.Quote{Product: product, Price: &grpcoin.Amount{Units: units}}
+	}
+	tests := []struct {
+		name           string
+		opts           SubOptions
+		in             []realtimequote.Quote
+		want           []realtimequote.Quote
+		wantDropped    int64
+		wantDisconnect bool
+	}{
+		{
+			name:        "drop newest",
+			opts:        SubOptions{Buffer: 2},
+			in:          []realtimequote.Quote{q("A", 1), q("A", 2), q("A", 3), q("B", 4)},
+			want:        []realtimequote.Quote{q("A", 1), q("A", 2)},
+			wantDropped: 2,
+		},
+		{
+			name:        "default buffer",
+			opts:        SubOptions{},
+			in:          []realtimequote.Quote{q("A", 1), q("A", 2)},
+			want:        []realtimequote.Quote{q("A", 1)},
+			wantDropped: 1,
+		},
+		{
+			name:        "drop oldest",
+			opts:        SubOptions{Buffer: 2, Policy: DropOldest},
+			in:          []realtimequote.Quote{q("A", 1), q("A", 2), q("A", 3), q("B", 4)},
+			want:        []realtimequote.Quote{q("A", 3), q("B", 4)},
+			wantDropped: 2,
+		},
+		{
+			name:        "keep latest per product",
+			opts:        SubOptions{Buffer: 1, Policy: KeepLatestPerProduct},
+			in:          []realtimequote.Quote{q("A", 1), q("B", 2), q("A", 3), q("C", 4), q("B", 5)},
+			want:        []realtimequote.Quote{q("A", 3), q("B", 5), q("C", 4)},
+			wantDropped: 2,
+		},
+		{
+			name:           "disconnect after max drops",
+			opts:           SubOptions{Buffer: 1, MaxDrops: 2},
+			in:             []realtimequote.Quote{q("A", 1), q("A", 2), q("A", 3)},
+			want:           []realtimequote.Quote{q("A", 1)},
+			wantDropped:    2,
+			wantDisconnect: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			if opts.Buffer < 1 {
+				opts.Buffer = 1
+			}
+			s := &Subscription{opts: opts, ready: make(chan struct{}, 1)}
+			var disconnect bool
+			for _, m := range tt.in {
+				disconnect = s.offer(m)
+			}
+			if !reflect.DeepEqual(s.queue, tt.want) {
+				t.Fatalf("queue=%v, expected=%v", s.queue, tt.want)
+			}
+			if s.Dropped() != tt.wantDropped {
+				t.Fatalf("dropped=%d, expected=%d", s.Dropped(), tt.wantDropped)
+			}
+			if disconnect != tt.wantDisconnect {
+				t.Fatalf("disconnect=%v, expected=%v", disconnect, tt.wantDisconnect)
+			}
+		})
+	}
+}
+
+func TestPubSubDisconnectsSlowSubscriber(t *testing.T) {
+	src := make(chan realtimequote.Quote)
+	stopped := make(chan struct{})
+	bus := NewPubSub(src, func() { close(stopped) })
+
+	slow := make(chan realtimequote.Quote) // never read until disconnected
+	sub := bus.SubWithOptions(slow, SubOptions{Buffer: 1, MaxDrops: 3})
+	for i := 0; i < 10 && !sub.Disconnected(); i++ {
+		src <- realtimequote.Quote{Product: "A"}
+	}
+	if !sub.Disconnected() {
+		t.Fatal("slow subscriber was not disconnected")
+	}
+	if n := sub.Dropped(); n != 3 {
+		t.Fatalf("dropped=%d, expected 3", n)
+	}
+	for range slow {
+		// may receive the message in flight, if any
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("stop should have been called after the last subscriber was disconnected")
+	}
+}
+
+func TestPolicy_Set(t *testing.T) {
+	for _, want := range []Policy{DropNewest, DropOldest, KeepLatestPerProduct} {
+		var got Policy
+		if err := got.Set(want.String()); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("Set(%q)=%v, expected=%v", want.String(), got, want)
+		}
+	}
+	var p Policy
+	if err := p.Set("drop-all"); err == nil {
+		t.Fatal("expected error for unknown policy")
+	}
+}
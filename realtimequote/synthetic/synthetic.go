@@ -0,0 +1,147 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package synthetic provides a QuoteStream of simulated prices for running
+// the servers without a connection to an exchange.
+package synthetic
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/common"
+)
+
+// Params are the parameters of the geometric Brownian motion of a ticker's
+// price. Drift and Volatility are annualized.
+type Params struct {
+	StartPrice float64
+	Drift      float64
+	Volatility float64
+}
+
+const DefaultTickInterval = time.Millisecond * 250
+
+const year = time.Hour * 24 * 365
+
+// Stream is a QuoteStream emitting a quote per product every tick, following
+// the price paths generated from a seed. The price of a product at a tick is
+// the same for all watchers, and across runs with the same seed.
+type Stream struct {
+	seed   int64
+	tick   time.Duration
+	params func(product string) (Params, bool)
+	start  time.Time
+	now    func() time.Time
+
+	lock  sync.Mutex
+	paths map[string]*path
+}
+
+type path struct {
+	rng    *rand.Rand
+	params Params
+	tick   int64
+	price  float64
+}
+
+// New returns a stream whose prices start at the time it's created and move
+// every tick with the parameters of the product returned by params. The
+// parameters are looked up when a product is first watched.
+func New(seed int64, tick time.Duration, params func(product string) (Params, bool)) *Stream {
+	if tick <= 0 {
+		tick = DefaultTickInterval
+	}
+	return &Stream{
+		seed:   seed,
+		tick:   tick,
+		params: params,
+		start:  time.Now(),
+		now:    time.Now,
+		paths:  make(map[string]*path),
+	}
+}
+
+// Watch emits the current prices of the products every tick, until ctx is
+// done. It fails if any of the products has no parameters.
+func (s *Stream) Watch(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
+	for _, p := range products {
+		if _, ok := s.params(p); !ok {
+			return nil, fmt.Errorf("no synthetic params for %q", p)
+		}
+	}
+	ch := make(chan realtimequote.Quote)
+	go func() {
+		defer close(ch)
+		t := time.NewTicker(s.tick)
+		defer t.Stop()
+		for {
+			k := int64(s.now().Sub(s.start) / s.tick)
+			for _, p := range products {
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- s.quoteAt(p, k):
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// quoteAt returns the quote of the product at the k-th tick, or at the tick
+// the price is already at if it's a later one (e.g. for a lagging watcher).
+func (s *Stream) quoteAt(product string, k int64) realtimequote.Quote {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p, ok := s.paths[product]
+	if !ok {
+		params, _ := s.params(product)
+		h := fnv.New64a()
+		h.Write([]byte(product))
+		p = &path{
+			rng:    rand.New(rand.NewSource(s.seed ^ int64(h.Sum64()))),
+			params: params,
+			price:  params.StartPrice}
+		s.paths[product] = p
+	}
+	p.advance(k, s.tick)
+	return realtimequote.Quote{
+		Product: product,
+		Price:   common.ParsePrice(strconv.FormatFloat(p.price, 'f', 9, 64)),
+		Time:    s.start.Add(time.Duration(p.tick) * s.tick),
+	}
+}
+
+// advance moves the price to the k-th tick. Prices of the past ticks are
+// not kept, so it's a no-op for those.
+func (p *path) advance(k int64, tick time.Duration) {
+	dt := float64(tick) / float64(year)
+	mu, sigma := p.params.Drift, p.params.Volatility
+	for ; p.tick < k; p.tick++ {
+		p.price *= math.Exp((mu-sigma*sigma/2)*dt + sigma*math.Sqrt(dt)*p.rng.NormFloat64())
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synthetic
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grpcoin/grpcoin/realtimequote"
+)
+
+func price(q realtimequote.Quote) float64 {
+	return float64(q.Price.Units) + float64(q.Price.Nanos)/1e9
+}
+
+func lookup(m map[string]Params) func(string) (Params, bool) {
+	return func(product string) (Params, bool) {
+		p, ok := m[product]
+		return p, ok
+	}
+}
+
+var testParams = lookup(map[string]Params{
+	"BTC":  {StartPrice: 50000, Volatility: 0.8},
+	"ETH":  {StartPrice: 3000, Volatility: 1.0},
+	"DOGE": {StartPrice: 0.3, Volatility: 1.5},
+	"DOT":  {StartPrice: 30, Volatility: 1.2},
+})
+
+func TestStream_deterministic(t *testing.T) {
+	s1 := New(42, time.Second, testParams)
+	s2 := New(42, time.Second, testParams)
+	s3 := New(43, time.Second, testParams)
+	s2.start = s1.start
+
+	// s2 is asked for fewer ticks and products, and in a different order
+	for k := int64(0); k < 100; k++ {
+		s1.quoteAt("ETH", k)
+		s1.quoteAt("BTC", k)
+	}
+	q1, q2, q3 := s1.quoteAt("BTC", 100), s2.quoteAt("BTC", 100), s3.quoteAt("BTC", 100)
+	if price(q1) != price(q2) {
+		t.Fatalf("prices with the same seed differ: %v != %v", q1.Price, q2.Price)
+	}
+	if !q1.Time.Equal(q2.Time) || !q1.Time.Equal(s1.start.Add(time.Second*100)) {
+		t.Fatalf("wrong quote times: %s %s", q1.Time, q2.Time)
+	}
+	if price(q1) == price(q3) {
+		t.Fatalf("prices with different seeds are the same: %v", q1.Price)
+	}
+	if price(s1.quoteAt("ETH", 100)) == price(s1.quoteAt("DOT", 100)) {
+		t.Fatal("different tickers have the same price")
+	}
+}
+
+func TestStream_drift(t *testing.T) {
+	s := New(1, time.Hour*24, lookup(map[string]Params{
+		"X": {StartPrice: 100, Drift: 0.1}}))
+	if got := price(s.quoteAt("X", 0)); got != 100 {
+		t.Fatalf("start price=%v, expected 100", got)
+	}
+	expected := 100 * math.Exp(0.1)
+	if got := price(s.quoteAt("X", 365)); math.Abs(got-expected) > 1e-6 {
+		t.Fatalf("price after a year=%v, expected=%v", got, expected)
+	}
+}
+
+func TestStream_lagging(t *testing.T) {
+	s := New(1, time.Second, testParams)
+	ahead := s.quoteAt("BTC", 10)
+	lagging := s.quoteAt("BTC", 5)
+	if price(lagging) != price(ahead) {
+		t.Fatalf("lagging price=%v, expected the current %v", price(lagging), price(ahead))
+	}
+	if expected := s.start.Add(time.Second * 10); !lagging.Time.Equal(expected) {
+		t.Fatalf("lagging quote time=%s, expected the time of the price %s", lagging.Time, expected)
+	}
+}
+
+func TestStream_Watch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := New(1, time.Millisecond*10, testParams)
+	if _, err := s.Watch(ctx, "BTC", "SOL"); err == nil {
+		t.Fatal("expected error for a product without params")
+	}
+	ch, err := s.Watch(ctx, "BTC", "DOGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	count := map[string]int{}
+	for q := range ch {
+		if price(q) <= 0 {
+			t.Fatalf("non-positive price: %#v", q)
+		}
+		count[q.Product]++
+		if count["BTC"] >= 3 && count["DOGE"] >= 3 {
+			cancel()
+		}
+	}
+	if len(count) != 2 {
+		t.Fatalf("unexpected products: %v", count)
+	}
+}
@@ -14,12 +14,6 @@
 
 package realtimequote
 
-var SupportedTickers = []string{ // make sure to update the grpcoin.proto for documentation as well
-	"BTC",
-	"ETH",
-	"DOGE",
-	"DOT"}
-
 func IsSupported(arr []string, t string) bool {
 	for _, v := range arr {
 		if v == t {
@@ -25,11 +25,24 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+	"github.com/grpcoin/grpcoin/apiserver/pgutil"
+	"github.com/grpcoin/grpcoin/userdb"
 )
 
-func DetectDatabase(ctx context.Context, datasetFile string, onCloud, useProdDB bool) (client *firestore.Client, shutdown func(), err error) {
+// DetectDatabase returns the PostgreSQL database at postgresURL if it is set
+// (e.g. when self-hosting), or the production database when running on the
+// cloud or useProdDB is set. Otherwise, it returns a local database loaded
+// with the test data: an in-memory database if useMemDB is set, or the
+// Firestore emulator.
+func DetectDatabase(ctx context.Context, datasetFile string, onCloud, useProdDB, useMemDB bool, postgresURL string) (db userdb.Store, shutdown func(), err error) {
 	log := ctxzap.Extract(ctx)
+	if postgresURL != "" {
+		return GetPostgresDB(ctx, postgresURL)
+	}
 	if !onCloud && !useProdDB {
+		if useMemDB {
+			return GetMemDB(ctx, datasetFile)
+		}
 		return GetLocalDB(ctx, datasetFile)
 	}
 	proj := firestore.DetectProjectID
@@ -44,14 +57,14 @@ func DetectDatabase(ctx context.Context, datasetFile string, onCloud, useProdDB
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect firestore: %w", err)
 	}
-	return fs, func() { fs.Close() }, nil
+	return &userdb.FirestoreStore{DB: fs}, func() { fs.Close() }, nil
 }
 
 func GetProdDB(ctx context.Context, project string) (*firestore.Client, error) {
 	return firestore.NewClient(ctx, project)
 }
 
-func GetLocalDB(ctx context.Context, datasetFile string) (client *firestore.Client, shutdown func(), err error) {
+func GetLocalDB(ctx context.Context, datasetFile string) (db userdb.Store, shutdown func(), err error) {
 	log := ctxzap.Extract(ctx)
 	log.Info("starting a local firestore emulator")
 	f, shutdownEmulator, err := firestoreutil.StartEmulator(ctx)
@@ -68,9 +81,45 @@ func GetLocalDB(ctx context.Context, datasetFile string) (client *firestore.Clie
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open local test data file: %w", err)
 	}
+	defer td.Close()
 	if err := firestoreutil.ImportData(td, f); err != nil {
 		return nil, nil, fmt.Errorf("failed to load test data: %w", err)
 	}
 	log.Info("loaded test data into the local firestore emulator")
-	return f, closeFn, nil
+	return &userdb.FirestoreStore{DB: f}, closeFn, nil
+}
+
+// GetPostgresDB connects to the PostgreSQL database at the url, and migrates
+// its schema to the latest version.
+func GetPostgresDB(ctx context.Context, url string) (db userdb.Store, shutdown func(), err error) {
+	log := ctxzap.Extract(ctx)
+	pg, err := pgutil.Connect(ctx, url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect postgres: %w", err)
+	}
+	p := &userdb.PostgresStore{DB: pg}
+	if err := p.Migrate(ctx); err != nil {
+		pg.Close()
+		return nil, nil, fmt.Errorf("failed to migrate postgres schema: %w", err)
+	}
+	log.Info("connected to postgres")
+	return p, func() { pg.Close() }, nil
+}
+
+// GetMemDB returns an in-memory database loaded with the test data, which
+// does not require the Firestore emulator.
+func GetMemDB(ctx context.Context, datasetFile string) (db userdb.Store, shutdown func(), err error) {
+	log := ctxzap.Extract(ctx)
+	log.Debug("loading test data", zap.String("file", datasetFile))
+	td, err := os.Open(datasetFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open local test data file: %w", err)
+	}
+	defer td.Close()
+	m := userdb.NewMemStore()
+	if err := m.Import(td); err != nil {
+		return nil, nil, fmt.Errorf("failed to load test data: %w", err)
+	}
+	log.Info("loaded test data into the in-memory database")
+	return m, func() {}, nil
 }
@@ -0,0 +1,155 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/grpcoin/grpcoin/realtimequote/binance"
+	"github.com/grpcoin/grpcoin/realtimequote/coinbase"
+	"github.com/grpcoin/grpcoin/realtimequote/fanout"
+	"github.com/grpcoin/grpcoin/realtimequote/kraken"
+	"github.com/grpcoin/grpcoin/realtimequote/synthetic"
+	"github.com/grpcoin/grpcoin/tickers"
+	"go.uber.org/zap"
+)
+
+// DefaultQuoteSources are the exchanges quotes are aggregated from, in the
+// order of priority.
+const DefaultQuoteSources = "binance,coinbase,kraken"
+
+const (
+	syntheticSource = "synthetic"
+
+	localFanout = "local"
+	redisFanout = "redis"
+)
+
+// quoteSources are the exchanges, constructed with the func returning the
+// symbol of a product on the exchange.
+var quoteSources = map[string]func(log *zap.Logger, symbol func(string) string) realtimequote.QuoteStream{
+	"binance": func(_ *zap.Logger, symbol func(string) string) realtimequote.QuoteStream {
+		return &binance.Stream{Symbol: symbol}
+	},
+	"coinbase": func(log *zap.Logger, symbol func(string) string) realtimequote.QuoteStream {
+		return &coinbase.Stream{Logger: log, Symbol: symbol}
+	},
+	"kraken": func(log *zap.Logger, symbol func(string) string) realtimequote.QuoteStream {
+		return &kraken.Stream{Logger: log, Symbol: symbol}
+	},
+}
+
+// QuoteOptions configure where the servers get the quotes from.
+type QuoteOptions struct {
+	Sources     string
+	Aggregation string
+
+	SyntheticSeed       int64
+	SyntheticTick       time.Duration
+	SyntheticDrift      float64
+	SyntheticVolatility float64
+
+	Fanout string
+}
+
+// RegisterFlags registers the flags of the options to fs.
+func (o *QuoteOptions) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.Sources, "quote-sources", DefaultQuoteSources, "comma-separated quote sources in the order of priority: exchanges (binance, coinbase, kraken) or \"synthetic\" for simulated prices")
+	fs.StringVar(&o.Aggregation, "quote-aggregation", string(realtimequote.AggregateMedian), "how quotes of the sources are aggregated (median or priority)")
+	fs.Int64Var(&o.SyntheticSeed, "synthetic-seed", 1, "seed of the synthetic quote prices")
+	fs.DurationVar(&o.SyntheticTick, "synthetic-tick", synthetic.DefaultTickInterval, "how often synthetic quote prices change")
+	fs.Float64Var(&o.SyntheticDrift, "synthetic-drift", 0, "annualized drift of synthetic quote prices (overrides the drift of each ticker if set)")
+	fs.Float64Var(&o.SyntheticVolatility, "synthetic-volatility", 0, "annualized volatility of synthetic quote prices (overrides the volatility of each ticker if set)")
+	fs.StringVar(&o.Fanout, "quote-fanout", localFanout, "how instances share the quote sources: \"local\" for each instance to connect to the sources, \"redis\" for a leader instance to relay the quotes to the others over redis")
+}
+
+// GetQuoteStream returns a stream aggregating the quotes of the sources in
+// the options. The exchanges use the symbols of the tickers in u.
+func GetQuoteStream(log *zap.Logger, o QuoteOptions, u *tickers.Universe) (*realtimequote.CompositeQuoteStream, error) {
+	var srcs []realtimequote.QuoteSource
+	for _, name := range strings.Split(o.Sources, ",") {
+		name = strings.TrimSpace(name)
+		var qs realtimequote.QuoteStream
+		switch {
+		case name == "":
+			continue
+		case name == syntheticSource:
+			for _, t := range u.Tickers() {
+				if t.Synthetic == nil {
+					return nil, fmt.Errorf("ticker %s has no synthetic params in the tickers config", t.Symbol)
+				}
+			}
+			qs = synthetic.New(o.SyntheticSeed, o.SyntheticTick, o.syntheticParams(u))
+		default:
+			newStream, ok := quoteSources[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown quote source %q", name)
+			}
+			exchange := name
+			qs = newStream(log.With(zap.String("source", name)), func(product string) string {
+				return u.ExchangeSymbol(exchange, product)
+			})
+		}
+		srcs = append(srcs, realtimequote.QuoteSource{Name: name, Stream: qs})
+	}
+	return realtimequote.NewCompositeQuoteStream(log, realtimequote.AggregationMode(o.Aggregation),
+		realtimequote.DefaultMaxQuoteDeviation, srcs...)
+}
+
+// RelayQuotes returns the quote stream the instance gets the quotes of the
+// tickers in u from qs with the fanout mode in the options. The stream
+// follows the changes of the tickers, and the quotes are relayed until ctx is
+// done.
+func RelayQuotes(ctx context.Context, log *zap.Logger, rc *redis.Client, o QuoteOptions, qs realtimequote.QuoteStream, u *tickers.Universe) (realtimequote.QuoteStream, error) {
+	switch o.Fanout {
+	case localFanout, "":
+		return u.Stream(qs), nil
+	case redisFanout:
+		relay := fanout.NewRedisRelay(rc, log, u.Stream(qs))
+		go relay.Run(ctx)
+		return u.Stream(relay), nil
+	default:
+		return nil, fmt.Errorf("unknown quote fanout mode %q", o.Fanout)
+	}
+}
+
+// syntheticParams returns the synthetic params of the tickers in u, with
+// the drift and volatility overridden by the options if set.
+func (o QuoteOptions) syntheticParams(u *tickers.Universe) func(string) (synthetic.Params, bool) {
+	return func(product string) (synthetic.Params, bool) {
+		t, ok := u.Get(product)
+		if !ok || t.Synthetic == nil {
+			return synthetic.Params{}, false
+		}
+		p := synthetic.Params{
+			StartPrice: t.Synthetic.StartPrice,
+			Drift:      t.Synthetic.Drift,
+			Volatility: t.Synthetic.Volatility,
+		}
+		if o.SyntheticDrift != 0 {
+			p.Drift = o.SyntheticDrift
+		}
+		if o.SyntheticVolatility != 0 {
+			p.Volatility = o.SyntheticVolatility
+		}
+		return p, true
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverutil
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/grpcoin/grpcoin/tickers"
+	"go.uber.org/zap"
+)
+
+// TickerOptions configure where the supported tickers are loaded from.
+type TickerOptions struct {
+	ConfigPath     string
+	ReloadInterval time.Duration
+}
+
+// RegisterFlags registers the flags of the options to fs.
+func (o *TickerOptions) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.ConfigPath, "tickers-config", os.Getenv("TICKERS_CONFIG"), "json file of the supported tickers, reloaded when it changes (defaults to $TICKERS_CONFIG, or the built-in tickers if empty)")
+	fs.DurationVar(&o.ReloadInterval, "tickers-reload-interval", tickers.DefaultReloadInterval, "how often the tickers config is checked for changes")
+}
+
+// GetTickers returns the supported tickers in the options, which are
+// reloaded from the config file until ctx is done.
+func GetTickers(ctx context.Context, log *zap.Logger, o TickerOptions) (*tickers.Universe, error) {
+	if o.ConfigPath == "" {
+		return tickers.NewUniverse(tickers.Default), nil
+	}
+	t, err := tickers.Load(o.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	u := tickers.NewUniverse(t)
+	go u.WatchFile(ctx, log, o.ConfigPath, o.ReloadInterval)
+	return u, nil
+}
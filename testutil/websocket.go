@@ -0,0 +1,52 @@
+package testutil
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	ws "github.com/gorilla/websocket"
+)
+
+// MockWebSocket starts a WebSocket server serving each connection with
+// handler, and returns its ws:// URL.
+func MockWebSocket(t *testing.T, handler func(conn *ws.Conn)) string {
+	t.Helper()
+	var upgrader ws.Upgrader
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("websocket upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		handler(conn)
+	}))
+	t.Cleanup(s.Close)
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// ReadMessages returns the non-empty lines of the file with the captured
+// messages of a WebSocket.
+func ReadMessages(t *testing.T, fn string) []string {
+	t.Helper()
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if l := strings.TrimSpace(sc.Text()); l != "" {
+			out = append(out, l)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tickers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultReloadInterval is how often the config file is checked for changes.
+const DefaultReloadInterval = time.Second * 10
+
+// config is the format of the config file, e.g.:
+//
+//	{"tickers": [
+//	  {"symbol": "BTC", "name": "Bitcoin", "min_trade_size": "0.00001",
+//	   "price_precision": 2, "exchanges": {"kraken": "XBT/USD"},
+//	   "synthetic": {"start_price": 50000, "drift": 0, "volatility": 0.8}}
+//	]}
+type config struct {
+	Tickers []Ticker `json:"tickers"`
+}
+
+// Load reads the tickers from the config file at path.
+func Load(path string) ([]Ticker, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tickers config: %w", err)
+	}
+	var c config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse tickers config: %w", err)
+	}
+	if err := validate(c.Tickers); err != nil {
+		return nil, fmt.Errorf("invalid tickers config: %w", err)
+	}
+	return c.Tickers, nil
+}
+
+func validate(tickers []Ticker) error {
+	if len(tickers) == 0 {
+		return errors.New("no tickers")
+	}
+	seen := make(map[string]bool, len(tickers))
+	for _, t := range tickers {
+		if t.Symbol == "" || t.Symbol != strings.ToUpper(t.Symbol) || strings.ContainsAny(t.Symbol, " ,-/") {
+			return fmt.Errorf("symbol %q must be non-empty, uppercase and without separators", t.Symbol)
+		}
+		if seen[t.Symbol] {
+			return fmt.Errorf("duplicate symbol %q", t.Symbol)
+		}
+		seen[t.Symbol] = true
+		if t.MinTradeSize.IsNegative() {
+			return fmt.Errorf("negative min_trade_size of %s", t.Symbol)
+		}
+		if t.PricePrecision < 0 || t.PricePrecision > 9 {
+			return fmt.Errorf("price_precision of %s must be between 0 and 9", t.Symbol)
+		}
+		if s := t.Synthetic; s != nil && (s.StartPrice <= 0 || s.Volatility < 0) {
+			return fmt.Errorf("synthetic params of %s must have a positive start_price and non-negative volatility", t.Symbol)
+		}
+	}
+	return nil
+}
+
+// WatchFile reloads the tickers from the config file at path when it's
+// modified, checking every interval until ctx is done. Invalid configs, and
+// configs removing any of the current tickers (which may be held in the
+// portfolios), are logged and ignored. It's meant to be invoked in a
+// goroutine.
+func (u *Universe) WatchFile(ctx context.Context, log *zap.Logger, path string, interval time.Duration) {
+	var last os.FileInfo // the first check loads the file, unchanged tickers are ignored
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			log.Warn("failed to check tickers config", zap.Error(err))
+			continue
+		}
+		if last != nil && fi.ModTime().Equal(last.ModTime()) && fi.Size() == last.Size() {
+			continue
+		}
+		last = fi
+		tickers, err := Load(path)
+		if err != nil {
+			log.Error("failed to reload tickers, keeping the current ones", zap.Error(err))
+			continue
+		}
+		if removed := removedSymbols(u.Symbols(), tickers); len(removed) > 0 {
+			log.Error("tickers config removes tickers that may be held in portfolios, keeping the current ones",
+				zap.Strings("removed", removed))
+			continue
+		}
+		if u.Set(tickers) {
+			log.Info("reloaded tickers", zap.Strings("symbols", u.Symbols()))
+		}
+	}
+}
+
+// removedSymbols returns the symbols that are not in tickers.
+func removedSymbols(symbols []string, tickers []Ticker) []string {
+	kept := make(map[string]bool, len(tickers))
+	for _, t := range tickers {
+		kept[t.Symbol] = true
+	}
+	var out []string
+	for _, s := range symbols {
+		if !kept[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tickers
+
+import (
+	"context"
+
+	"github.com/grpcoin/grpcoin/realtimequote"
+)
+
+// Stream returns a QuoteStream of the supported tickers from qs. Its Watch
+// ignores the products, and when the tickers change, it watches qs again
+// with the new tickers without closing the channel.
+func (u *Universe) Stream(qs realtimequote.QuoteStream) realtimequote.QuoteStream {
+	return realtimequote.QuoteStreamFunc(func(ctx context.Context, _ ...string) (<-chan realtimequote.Quote, error) {
+		return u.watch(ctx, qs)
+	})
+}
+
+func (u *Universe) watch(ctx context.Context, qs realtimequote.QuoteStream) (<-chan realtimequote.Quote, error) {
+	changed := u.Changed()
+	watchCtx, cancel := context.WithCancel(ctx)
+	ch, err := qs.Watch(watchCtx, u.Symbols()...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	out := make(chan realtimequote.Quote)
+	go func() {
+		defer close(out)
+		defer func() { cancel() }()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				changed = u.Changed()
+				// start watching the new tickers before stopping the old
+				// watch, so there's no gap in the quotes
+				newCtx, newCancel := context.WithCancel(ctx)
+				newCh, err := qs.Watch(newCtx, u.Symbols()...)
+				if err != nil {
+					newCancel()
+					return
+				}
+				cancel()
+				ch, cancel = newCh, newCancel
+			case q, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case out <- q:
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tickers keeps the tickers supported by the servers, which can be
+// reloaded from a config file while the servers are running.
+package tickers
+
+import (
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// Ticker is a supported currency.
+type Ticker struct {
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+
+	// Exchanges maps the exchange names to the symbols of the ticker on the
+	// exchanges, if they don't follow the exchange's naming convention (e.g.
+	// "kraken": "XBT/USD").
+	Exchanges map[string]string `json:"exchanges,omitempty"`
+
+	// MinTradeSize is the smallest quantity of a trade or an order (zero for
+	// no minimum).
+	MinTradeSize decimal.Decimal `json:"min_trade_size"`
+	// PricePrecision is the number of decimal places the prices are shown
+	// with.
+	PricePrecision int32 `json:"price_precision"`
+
+	// Synthetic is how the simulated prices of the ticker move, required
+	// only when the servers use the synthetic quote source.
+	Synthetic *Synthetic `json:"synthetic,omitempty"`
+}
+
+// Synthetic are the parameters of the geometric Brownian motion of a
+// ticker's simulated price. Drift and Volatility are annualized.
+type Synthetic struct {
+	StartPrice float64 `json:"start_price"`
+	Drift      float64 `json:"drift"`
+	Volatility float64 `json:"volatility"`
+}
+
+// Default are the tickers supported when no config is given.
+var Default = []Ticker{
+	{Symbol: "BTC", Name: "Bitcoin", PricePrecision: 2,
+		Synthetic: &Synthetic{StartPrice: 50000, Volatility: 0.8}},
+	{Symbol: "ETH", Name: "Ethereum", PricePrecision: 2,
+		Synthetic: &Synthetic{StartPrice: 3000, Volatility: 1.0}},
+	{Symbol: "DOGE", Name: "Dogecoin", PricePrecision: 5,
+		Synthetic: &Synthetic{StartPrice: 0.3, Volatility: 1.5}},
+	{Symbol: "DOT", Name: "Polkadot", PricePrecision: 3,
+		Synthetic: &Synthetic{StartPrice: 30, Volatility: 1.2}},
+}
+
+// Universe is the set of the supported tickers. It's safe for concurrent use,
+// and the tickers can be replaced at any time.
+type Universe struct {
+	mu      sync.RWMutex
+	tickers []Ticker
+	changed chan struct{} // closed when the tickers change
+}
+
+// NewUniverse returns a universe of the tickers.
+func NewUniverse(tickers []Ticker) *Universe {
+	return &Universe{tickers: tickers, changed: make(chan struct{})}
+}
+
+// FromSymbols returns a universe of the tickers with the symbols and no
+// metadata.
+func FromSymbols(symbols ...string) *Universe {
+	t := make([]Ticker, len(symbols))
+	for i, s := range symbols {
+		t[i] = Ticker{Symbol: s}
+	}
+	return NewUniverse(t)
+}
+
+// Tickers returns the supported tickers.
+func (u *Universe) Tickers() []Ticker {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return append([]Ticker(nil), u.tickers...)
+}
+
+// Symbols returns the symbols of the supported tickers.
+func (u *Universe) Symbols() []string {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	out := make([]string, len(u.tickers))
+	for i, t := range u.tickers {
+		out[i] = t.Symbol
+	}
+	return out
+}
+
+// Get returns the ticker with the symbol, if it's supported.
+func (u *Universe) Get(symbol string) (Ticker, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	for _, t := range u.tickers {
+		if t.Symbol == symbol {
+			return t, true
+		}
+	}
+	return Ticker{}, false
+}
+
+// IsSupported reports whether the symbol is of a supported ticker.
+func (u *Universe) IsSupported(symbol string) bool {
+	_, ok := u.Get(symbol)
+	return ok
+}
+
+// ExchangeSymbol returns the symbol of the ticker on the exchange, or empty
+// if it follows the exchange's naming convention.
+func (u *Universe) ExchangeSymbol(exchange, symbol string) string {
+	t, _ := u.Get(symbol)
+	return t.Exchanges[exchange]
+}
+
+// Changed returns a channel that's closed when the tickers change.
+func (u *Universe) Changed() <-chan struct{} {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.changed
+}
+
+// Set replaces the supported tickers, and reports whether they're changed.
+func (u *Universe) Set(tickers []Ticker) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if equal(u.tickers, tickers) {
+		return false
+	}
+	u.tickers = tickers
+	close(u.changed)
+	u.changed = make(chan struct{})
+	return true
+}
+
+func equal(a, b []Ticker) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Symbol != b[i].Symbol ||
+			a[i].Name != b[i].Name ||
+			!a[i].MinTradeSize.Equal(b[i].MinTradeSize) ||
+			a[i].PricePrecision != b[i].PricePrecision ||
+			(a[i].Synthetic == nil) != (b[i].Synthetic == nil) ||
+			(a[i].Synthetic != nil && *a[i].Synthetic != *b[i].Synthetic) ||
+			len(a[i].Exchanges) != len(b[i].Exchanges) {
+			return false
+		}
+		for k, v := range a[i].Exchanges {
+			if b[i].Exchanges[k] != v {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,241 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tickers
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/realtimequote"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+func TestUniverse(t *testing.T) {
+	u := NewUniverse([]Ticker{
+		{Symbol: "BTC", Name: "Bitcoin", Exchanges: map[string]string{"kraken": "XBT/USD"}},
+		{Symbol: "ETH"},
+	})
+	if got := u.Symbols(); !reflect.DeepEqual(got, []string{"BTC", "ETH"}) {
+		t.Fatalf("wrong symbols: %v", got)
+	}
+	if !u.IsSupported("BTC") || u.IsSupported("DOGE") {
+		t.Fatal("wrong supported tickers")
+	}
+	if got := u.ExchangeSymbol("kraken", "BTC"); got != "XBT/USD" {
+		t.Fatalf("wrong exchange symbol: %q", got)
+	}
+	if got := u.ExchangeSymbol("binance", "BTC"); got != "" {
+		t.Fatalf("expected no exchange symbol, got: %q", got)
+	}
+
+	changed := u.Changed()
+	if u.Set([]Ticker{
+		{Symbol: "BTC", Name: "Bitcoin", Exchanges: map[string]string{"kraken": "XBT/USD"}},
+		{Symbol: "ETH"},
+	}) {
+		t.Fatal("same tickers reported as changed")
+	}
+	select {
+	case <-changed:
+		t.Fatal("notified without a change")
+	default:
+	}
+	if !u.Set([]Ticker{{Symbol: "BTC"}, {Symbol: "ETH"}, {Symbol: "SOL"}}) {
+		t.Fatal("changed tickers not reported")
+	}
+	select {
+	case <-changed:
+	default:
+		t.Fatal("not notified of the change")
+	}
+	if !u.IsSupported("SOL") || u.ExchangeSymbol("kraken", "BTC") != "" {
+		t.Fatal("tickers not replaced")
+	}
+}
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := ioutil.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    []Ticker
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			config: `{"tickers": [
+				{"symbol": "BTC", "name": "Bitcoin", "min_trade_size": "0.0001", "price_precision": 2,
+				 "exchanges": {"kraken": "XBT/USD"}, "synthetic": {"start_price": 50000, "drift": 0.1, "volatility": 0.8}},
+				{"symbol": "SOL", "min_trade_size": 0.01}]}`,
+			want: []Ticker{
+				{Symbol: "BTC", Name: "Bitcoin", MinTradeSize: decimal.RequireFromString("0.0001"), PricePrecision: 2,
+					Exchanges: map[string]string{"kraken": "XBT/USD"},
+					Synthetic: &Synthetic{StartPrice: 50000, Drift: 0.1, Volatility: 0.8}},
+				{Symbol: "SOL", MinTradeSize: decimal.RequireFromString("0.01")},
+			},
+		},
+		{name: "malformed", config: `{"tickers": [`, wantErr: true},
+		{name: "no tickers", config: `{"tickers": []}`, wantErr: true},
+		{name: "lowercase symbol", config: `{"tickers": [{"symbol": "btc"}]}`, wantErr: true},
+		{name: "pair symbol", config: `{"tickers": [{"symbol": "BTC-USD"}]}`, wantErr: true},
+		{name: "duplicate symbol", config: `{"tickers": [{"symbol": "BTC"}, {"symbol": "BTC"}]}`, wantErr: true},
+		{name: "negative min trade size", config: `{"tickers": [{"symbol": "BTC", "min_trade_size": "-1"}]}`, wantErr: true},
+		{name: "too precise", config: `{"tickers": [{"symbol": "BTC", "price_precision": 10}]}`, wantErr: true},
+		{name: "no synthetic start price", config: `{"tickers": [{"symbol": "BTC", "synthetic": {"volatility": 1}}]}`, wantErr: true},
+		{name: "negative synthetic volatility", config: `{"tickers": [{"symbol": "BTC", "synthetic": {"start_price": 1, "volatility": -1}}]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tickers.json")
+			writeConfig(t, path, tt.config)
+			got, err := Load(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() err=%v, wantErr=%v", err, tt.wantErr)
+			}
+			if !equal(got, tt.want) {
+				t.Fatalf("Load()=%#v, expected=%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tickers.json")
+	writeConfig(t, path, `{"tickers": [{"symbol": "BTC"}]}`)
+	tickers, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := NewUniverse(tickers)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go u.WatchFile(ctx, zap.NewNop(), path, time.Millisecond*10)
+
+	changed := u.Changed()
+	writeConfig(t, path, `{"tickers": [{"symbol": "BTC"}, {"symbol": "ETH"}]}`)
+	// make sure the modification is detected on file systems with coarse
+	// modification times
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second))
+	select {
+	case <-changed:
+	case <-time.After(time.Second * 3):
+		t.Fatal("config was not reloaded")
+	}
+	if got := u.Symbols(); !reflect.DeepEqual(got, []string{"BTC", "ETH"}) {
+		t.Fatalf("wrong reloaded symbols: %v", got)
+	}
+
+	// invalid configs are ignored
+	writeConfig(t, path, `{"tickers": []}`)
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second*2))
+	time.Sleep(time.Millisecond * 100)
+	if got := u.Symbols(); !reflect.DeepEqual(got, []string{"BTC", "ETH"}) {
+		t.Fatalf("invalid config was not ignored: %v", got)
+	}
+
+	// configs removing tickers are ignored
+	writeConfig(t, path, `{"tickers": [{"symbol": "BTC"}, {"symbol": "SOL"}]}`)
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second*3))
+	time.Sleep(time.Millisecond * 100)
+	if got := u.Symbols(); !reflect.DeepEqual(got, []string{"BTC", "ETH"}) {
+		t.Fatalf("config removing a ticker was not ignored: %v", got)
+	}
+}
+
+// recordingStream emits a quote of each watched product every few
+// milliseconds, and records the products of each Watch.
+type recordingStream struct {
+	mu      sync.Mutex
+	watches [][]string
+}
+
+func (s *recordingStream) Watch(ctx context.Context, products ...string) (<-chan realtimequote.Quote, error) {
+	s.mu.Lock()
+	s.watches = append(s.watches, products)
+	s.mu.Unlock()
+	ch := make(chan realtimequote.Quote)
+	go func() {
+		defer close(ch)
+		for {
+			for _, p := range products {
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- realtimequote.Quote{Product: p, Price: &grpcoin.Amount{Units: 1}, Time: time.Now()}:
+				}
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	return ch, nil
+}
+
+func TestStream(t *testing.T) {
+	u := FromSymbols("BTC")
+	qs := &recordingStream{}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := u.Stream(qs).Watch(ctx, "ignored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q := <-ch; q.Product != "BTC" {
+		t.Fatalf("unexpected quote: %#v", q)
+	}
+
+	u.Set([]Ticker{{Symbol: "BTC"}, {Symbol: "ETH"}})
+	seen := make(map[string]bool)
+	deadline := time.After(time.Second * 3)
+	for !seen["ETH"] {
+		select {
+		case q, ok := <-ch:
+			if !ok {
+				t.Fatal("channel closed on ticker change")
+			}
+			seen[q.Product] = true
+		case <-deadline:
+			t.Fatal("did not get quotes of the new ticker")
+		}
+	}
+
+	qs.mu.Lock()
+	watches := qs.watches
+	qs.mu.Unlock()
+	if len(watches) != 2 {
+		t.Fatalf("expected 2 watches, got: %v", watches)
+	}
+	sort.Strings(watches[1])
+	if !reflect.DeepEqual(watches[1], []string{"BTC", "ETH"}) {
+		t.Fatalf("wrong products of the new watch: %v", watches[1])
+	}
+
+	cancel()
+	for range ch {
+	}
+}
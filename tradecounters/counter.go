@@ -15,14 +15,20 @@ type TradeCounter struct {
 	DB *redis.Client
 }
 
-// IncrTrades increments trade count and volume by specified amounts.
-func (tc TradeCounter) IncrTrades(ctx context.Context, now time.Time, volume float64) error {
+// IncrTrades increments trade count, volume and fees collected by specified
+// amounts.
+func (tc TradeCounter) IncrTrades(ctx context.Context, now time.Time, volume, fees float64) error {
 	p := tc.DB.Pipeline()
 	defer p.Close()
 	p.Incr(ctx, keyHourlyTradeCount(now))
 	p.ExpireAt(ctx, keyHourlyTradeCount(now), keyExpiration(now))
 	p.IncrByFloat(ctx, keyHourlyTradeVolume(now), volume)
 	p.ExpireAt(ctx, keyHourlyTradeVolume(now), keyExpiration(now))
+	if fees != 0 {
+		p.IncrByFloat(ctx, keyHourlyTradeFees(now), fees)
+		p.ExpireAt(ctx, keyHourlyTradeFees(now), keyExpiration(now))
+		p.IncrByFloat(ctx, keyTotalTradeFees, fees)
+	}
 	cmds, err := p.Exec(ctx)
 	_ = cmds
 	return err
@@ -44,7 +50,22 @@ func (tc TradeCounter) PastDayTradeCounts(ctx context.Context, now time.Time) (i
 }
 
 func (tc TradeCounter) PastDayTradeVolume(ctx context.Context, now time.Time) (float64, error) {
-	res, err := tc.DB.MGet(ctx, genCacheKeys(now, 24, keyHourlyTradeVolume)...).Result()
+	return tc.sumFloats(ctx, genCacheKeys(now, 24, keyHourlyTradeVolume))
+}
+
+// PastDayTradeFees returns the fees collected from the trades in the past 24
+// hours.
+func (tc TradeCounter) PastDayTradeFees(ctx context.Context, now time.Time) (float64, error) {
+	return tc.sumFloats(ctx, genCacheKeys(now, 24, keyHourlyTradeFees))
+}
+
+// TotalTradeFees returns the fees collected from all trades.
+func (tc TradeCounter) TotalTradeFees(ctx context.Context) (float64, error) {
+	return tc.sumFloats(ctx, []string{keyTotalTradeFees})
+}
+
+func (tc TradeCounter) sumFloats(ctx context.Context, keys []string) (float64, error) {
+	res, err := tc.DB.MGet(ctx, keys...).Result()
 	var total float64
 	for _, c := range res {
 		if v, ok := c.(string); ok {
@@ -73,6 +94,12 @@ func keyHourlyTradeVolume(t time.Time) string {
 	return fmt.Sprintf("tradevolume_hr::%s", t.Truncate(time.Hour).Format(time.RFC3339))
 }
 
+func keyHourlyTradeFees(t time.Time) string {
+	return fmt.Sprintf("tradefees_hr::%s", t.Truncate(time.Hour).Format(time.RFC3339))
+}
+
+const keyTotalTradeFees = "tradefees_total"
+
 func keyHourlyTradeCount(t time.Time) string {
 	return fmt.Sprintf("trades_hr::%s", t.Truncate(time.Hour).Format(time.RFC3339))
 }
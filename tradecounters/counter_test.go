@@ -21,22 +21,22 @@ func TestTradeCounters(t *testing.T) {
 	minusHr := func(i int) time.Time { return now.Add(-time.Duration(i) * time.Hour) }
 	minusMin := func(i int) time.Time { return now.Add(-time.Duration(i) * time.Minute) }
 
-	if err := counter.IncrTrades(ctx, minusHr(30), 100_000); err != nil {
+	if err := counter.IncrTrades(ctx, minusHr(30), 100_000, 100); err != nil {
 		t.Fatal(err)
 	}
-	if err := counter.IncrTrades(ctx, minusHr(25), 10_000); err != nil {
+	if err := counter.IncrTrades(ctx, minusHr(25), 10_000, 10); err != nil {
 		t.Fatal(err)
 	}
-	if err := counter.IncrTrades(ctx, minusHr(10), 15_000); err != nil {
+	if err := counter.IncrTrades(ctx, minusHr(10), 15_000, 15); err != nil {
 		t.Fatal(err)
 	}
-	if err := counter.IncrTrades(ctx, minusHr(1), 3_000); err != nil {
+	if err := counter.IncrTrades(ctx, minusHr(1), 3_000, 3); err != nil {
 		t.Fatal(err)
 	}
-	if err := counter.IncrTrades(ctx, minusMin(1), 50); err != nil {
+	if err := counter.IncrTrades(ctx, minusMin(1), 50, 0); err != nil {
 		t.Fatal(err)
 	}
-	if err := counter.IncrTrades(ctx, minusHr(-1), 1); err != nil {
+	if err := counter.IncrTrades(ctx, minusHr(-1), 1, 0.5); err != nil {
 		t.Fatal(err)
 	}
 
@@ -56,4 +56,19 @@ func TestTradeCounters(t *testing.T) {
 	if cmp.Equal(expectedTradeVolume, tradeVolume, cmpopts.EquateApprox(0, 0.0001)) {
 		t.Errorf("Expected %f trade volume, got %f", expectedTradeVolume, tradeVolume)
 	}
+
+	tradeFees, err := counter.PastDayTradeFees(ctx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := 18.0; !cmp.Equal(expected, tradeFees, cmpopts.EquateApprox(0, 0.0001)) {
+		t.Errorf("Expected %f trade fees, got %f", expected, tradeFees)
+	}
+	totalFees, err := counter.TotalTradeFees(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := 128.5; !cmp.Equal(expected, totalFees, cmpopts.EquateApprox(0, 0.0001)) {
+		t.Errorf("Expected %f total trade fees, got %f", expected, totalFees)
+	}
 }
@@ -0,0 +1,143 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+// MaxActiveAlerts is the number of alerts a user can have waiting to fire.
+const MaxActiveAlerts = 20
+
+// Alert is a price alert stored under the user until it is deleted. It fires
+// once when its condition is met.
+type Alert struct {
+	ID        string                 `firestore:"id"`
+	UserID    string                 `firestore:"uid"`
+	Ticker    string                 `firestore:"ticker"`
+	Condition grpcoin.AlertCondition `firestore:"condition"`
+	Price     Amount                 `firestore:"price"`
+	Percent   float64                `firestore:"percent"`
+
+	// RefPrice is the price when the alert is created, PERCENT_MOVE alerts
+	// fire relative to it.
+	RefPrice  Amount    `firestore:"refPrice"`
+	CreatedAt time.Time `firestore:"createdAt"`
+
+	// Active is set until the alert fires.
+	Active     bool      `firestore:"active"`
+	FiredAt    time.Time `firestore:"firedAt"`
+	FiredPrice Amount    `firestore:"firedPrice"`
+}
+
+// Triggered reports whether the alert's condition is met at the price.
+func (a Alert) Triggered(price *grpcoin.Amount) bool {
+	p := toDecimal(price)
+	switch a.Condition {
+	case grpcoin.AlertCondition_PRICE_ABOVE:
+		return p.GreaterThanOrEqual(a.Price.F())
+	case grpcoin.AlertCondition_PRICE_BELOW:
+		return p.LessThanOrEqual(a.Price.F())
+	case grpcoin.AlertCondition_PERCENT_MOVE:
+		ref := a.RefPrice.F()
+		if ref.IsZero() {
+			return false
+		}
+		move := p.Sub(ref).Abs().Div(ref).Mul(decimal.NewFromInt(100))
+		return move.GreaterThanOrEqual(decimal.NewFromFloat(a.Percent))
+	default:
+		return false
+	}
+}
+
+// CreateAlert stores a new alert. Fails with ResourceExhausted if the user
+// has too many active alerts.
+func (u *UserDB) CreateAlert(ctx context.Context, a Alert) error {
+	ctx, s := u.T.Start(ctx, "create alert")
+	defer s.End()
+	alerts, err := u.DB.ListAlerts(ctx, a.UserID)
+	if err != nil {
+		return err
+	}
+	var active int
+	for _, v := range alerts {
+		if v.Active {
+			active++
+		}
+	}
+	if active >= MaxActiveAlerts {
+		return status.Errorf(codes.ResourceExhausted, "cannot have more than %d active alerts", MaxActiveAlerts)
+	}
+	return u.DB.CreateAlert(ctx, a)
+}
+
+// ListAlerts returns user's alerts (most recent first).
+func (u *UserDB) ListAlerts(ctx context.Context, uid string) ([]Alert, error) {
+	ctx, s := u.T.Start(ctx, "list alerts")
+	defer s.End()
+	return u.DB.ListAlerts(ctx, uid)
+}
+
+// DeleteAlert deletes the user's alert. Fails with NotFound if it does not
+// exist.
+func (u *UserDB) DeleteAlert(ctx context.Context, uid, alertID string) error {
+	ctx, s := u.T.Start(ctx, "delete alert")
+	defer s.End()
+	err := u.DB.DeleteAlert(ctx, uid, alertID)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.NotFound, "alert %q not found", alertID)
+	}
+	return err
+}
+
+// ActiveAlerts returns the alerts of all users that have not fired yet.
+func (u *UserDB) ActiveAlerts(ctx context.Context) ([]Alert, error) {
+	ctx, s := u.T.Start(ctx, "active alerts")
+	defer s.End()
+	return u.DB.ActiveAlerts(ctx)
+}
+
+// FireAlert marks the active alert as fired at the price. Fails with
+// FailedPrecondition if the alert has already fired, so that an alert is
+// not delivered more than once, or NotFound if it is deleted.
+func (u *UserDB) FireAlert(ctx context.Context, a Alert, price *grpcoin.Amount) (Alert, error) {
+	ctx, s := u.T.Start(ctx, "fire alert")
+	defer s.End()
+	var out Alert
+	err := u.DB.RunTx(ctx, func(ctx context.Context, tx Tx) error {
+		v, err := tx.GetAlert(a.UserID, a.ID)
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "alert %q not found", a.ID)
+		} else if err != nil {
+			return err
+		}
+		if !v.Active {
+			return status.Errorf(codes.FailedPrecondition, "alert %q has already fired", a.ID)
+		}
+		v.Active = false
+		v.FiredAt = time.Now().UTC()
+		v.FiredPrice = ToAmount(toDecimal(price))
+		out = v
+		return tx.SetAlert(v)
+	})
+	return out, err
+}
@@ -0,0 +1,167 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tradecounters"
+)
+
+func TestAlert_Triggered(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     Alert
+		price *grpcoin.Amount
+		want  bool
+	}{
+		{name: "above, below price",
+			a:     Alert{Condition: grpcoin.AlertCondition_PRICE_ABOVE, Price: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 99, Nanos: 999_999_999},
+			want:  false},
+		{name: "above, at price",
+			a:     Alert{Condition: grpcoin.AlertCondition_PRICE_ABOVE, Price: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 100},
+			want:  true},
+		{name: "below, at price",
+			a:     Alert{Condition: grpcoin.AlertCondition_PRICE_BELOW, Price: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 100},
+			want:  true},
+		{name: "below, above price",
+			a:     Alert{Condition: grpcoin.AlertCondition_PRICE_BELOW, Price: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 100, Nanos: 1},
+			want:  false},
+		{name: "percent move up",
+			a:     Alert{Condition: grpcoin.AlertCondition_PERCENT_MOVE, Percent: 5, RefPrice: Amount{Units: 200}},
+			price: &grpcoin.Amount{Units: 210},
+			want:  true},
+		{name: "percent move down",
+			a:     Alert{Condition: grpcoin.AlertCondition_PERCENT_MOVE, Percent: 5, RefPrice: Amount{Units: 200}},
+			price: &grpcoin.Amount{Units: 190},
+			want:  true},
+		{name: "percent move too small",
+			a:     Alert{Condition: grpcoin.AlertCondition_PERCENT_MOVE, Percent: 5, RefPrice: Amount{Units: 200}},
+			price: &grpcoin.Amount{Units: 209, Nanos: 990_000_000},
+			want:  false},
+		{name: "percent move without reference price",
+			a:     Alert{Condition: grpcoin.AlertCondition_PERCENT_MOVE, Percent: 5},
+			price: &grpcoin.Amount{Units: 100},
+			want:  false},
+		{name: "undefined condition",
+			a:     Alert{Price: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 100},
+			want:  false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Triggered(tt.price); got != tt.want {
+				t.Errorf("Triggered() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserDB_alerts(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+
+		a := Alert{
+			ID:        "alert1",
+			UserID:    tu.DBKey(),
+			Ticker:    "BTC",
+			Condition: grpcoin.AlertCondition_PRICE_ABOVE,
+			Price:     Amount{Units: 50_000},
+			RefPrice:  Amount{Units: 40_000},
+			CreatedAt: time.Now().UTC(),
+			Active:    true,
+		}
+		if err := udb.CreateAlert(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+		active, err := udb.ActiveAlerts(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]Alert{a}, active, cmpopts.EquateApproxTime(time.Millisecond)); diff != "" {
+			t.Fatal(diff)
+		}
+
+		fired, err := udb.FireAlert(ctx, a, &grpcoin.Amount{Units: 50_001})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fired.Active || fired.FiredPrice != (Amount{Units: 50_001}) {
+			t.Fatalf("alert not fired: %#v", fired)
+		}
+		// cannot fire twice
+		if _, err := udb.FireAlert(ctx, a, &grpcoin.Amount{Units: 50_001}); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition, got: %v", err)
+		}
+		active, err = udb.ActiveAlerts(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(active) != 0 {
+			t.Fatalf("expected no active alerts, got: %#v", active)
+		}
+		list, err := udb.ListAlerts(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]Alert{fired}, list, cmpopts.EquateApproxTime(time.Millisecond)); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if err := udb.DeleteAlert(ctx, tu.DBKey(), a.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := udb.DeleteAlert(ctx, tu.DBKey(), a.ID); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound, got: %v", err)
+		}
+		if _, err := udb.FireAlert(ctx, a, &grpcoin.Amount{Units: 50_001}); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound, got: %v", err)
+		}
+
+		for i := 0; i < MaxActiveAlerts; i++ {
+			a.ID = fmt.Sprintf("alert%d", i)
+			if err := udb.CreateAlert(ctx, a); err != nil {
+				t.Fatal(err)
+			}
+		}
+		a.ID = "one-too-many"
+		if err := udb.CreateAlert(ctx, a); status.Code(err) != codes.ResourceExhausted {
+			t.Fatalf("expected ResourceExhausted, got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+// updateCostBasis updates the average cost basis of the position and the
+// realized P&L after a trade of size at the executed price with the fee
+// charged. before is the position before the trade.
+//
+// The fee is added to the cost of opening a position (or deducted from the
+// proceeds of a short sale), and deducted from the P&L realized by closing a
+// position. If the cost basis of an existing position is not known (e.g. it
+// was opened before the cost basis was tracked), it remains unknown until
+// the position is closed.
+func (p *Portfolio) updateCostBasis(ticker string, action grpcoin.TradeAction, before Amount, size, price, fee Amount) {
+	pos := before.F()
+	delta := size.F()
+	if action == grpcoin.TradeAction_SELL {
+		delta = delta.Neg()
+	}
+	after := pos.Add(delta)
+	avg, known := p.CostBasis[ticker]
+	if pos.IsZero() {
+		known = true
+	}
+
+	qty := delta.Abs()
+	opened := qty // amount of the trade that opens or increases the position
+	if !pos.IsZero() && pos.Sign() != delta.Sign() {
+		closed := decimal.Min(qty, pos.Abs())
+		opened = qty.Sub(closed)
+		if known {
+			// long positions profit if the price is above the cost, short
+			// positions if it is below.
+			pnl := closed.Mul(price.F().Sub(avg.F()))
+			if pos.IsNegative() {
+				pnl = pnl.Neg()
+			}
+			pnl = pnl.Sub(fee.F().Mul(closed).Div(qty))
+			p.RealizedPnL = ToAmount(p.RealizedPnL.F().Add(pnl))
+		}
+		if opened.IsZero() {
+			if after.IsZero() {
+				p.setCostBasis(ticker, nil)
+			}
+			return
+		}
+		// position is reversed, the rest of the trade opens a new position
+		pos, avg, known = decimal.Zero, Amount{}, true
+	}
+	if !known {
+		return
+	}
+	openFee := fee.F().Mul(opened).Div(qty)
+	if delta.IsNegative() {
+		openFee = openFee.Neg()
+	}
+	total := pos.Abs().Mul(avg.F()).Add(opened.Mul(price.F())).Add(openFee)
+	v := ToAmount(total.Div(pos.Abs().Add(opened)))
+	p.setCostBasis(ticker, &v)
+}
+
+func (p *Portfolio) setCostBasis(ticker string, v *Amount) {
+	if v == nil {
+		delete(p.CostBasis, ticker)
+		if len(p.CostBasis) == 0 {
+			p.CostBasis = nil
+		}
+		return
+	}
+	if p.CostBasis == nil {
+		p.CostBasis = make(map[string]Amount)
+	}
+	p.CostBasis[ticker] = *v
+}
+
+// UnrealizedPnL returns the profit (or loss) of the position if it was closed
+// at the specified price. It returns false if the cost basis of the position
+// is not known.
+func (p Portfolio) UnrealizedPnL(ticker string, price Amount) (Amount, bool) {
+	pos, ok := p.Positions[ticker]
+	if !ok || pos.IsZero() {
+		return Amount{}, true
+	}
+	avg, ok := p.CostBasis[ticker]
+	if !ok {
+		return Amount{}, false
+	}
+	return ToAmount(pos.F().Mul(price.F().Sub(avg.F()))), true
+}
+
+// TotalUnrealizedPnL returns the sum of the unrealized P&L of the positions
+// at the specified prices. Positions with unknown cost basis are excluded.
+func (p Portfolio) TotalUnrealizedPnL(prices map[string]Amount) Amount {
+	total := decimal.Zero
+	for ticker := range p.Positions {
+		if v, ok := p.UnrealizedPnL(ticker, prices[ticker]); ok {
+			total = total.Add(v.F())
+		}
+	}
+	return ToAmount(total)
+}
+
+// BackfillCostBasis reconstructs the cost basis of the user's positions opened
+// before the cost basis was tracked by replaying the trade history. Since the
+// older trades may have been rotated out of the history, a position is
+// backfilled only if the replayed trades add up to it, and the realized P&L
+// only if the replayed trades add up to the current cash. It returns the
+// tickers of the backfilled positions, and whether the realized P&L is
+// backfilled.
+func (u *UserDB) BackfillCostBasis(ctx context.Context, uid string) ([]string, bool, error) {
+	ctx, s := u.T.Start(ctx, "backfill cost basis")
+	defer s.End()
+	var tickers []string
+	var realized bool
+	err := u.DB.RunTx(ctx, func(ctx context.Context, tx Tx) error {
+		tickers, realized = nil, false
+		user, err := tx.GetUser(uid)
+		if err != nil {
+			return err
+		}
+		trades, err := tx.Trades(uid)
+		if err != nil {
+			return err
+		}
+		replayed := replayTrades(trades)
+
+		p := &user.Portfolio
+		for ticker, pos := range p.Positions {
+			if _, ok := p.CostBasis[ticker]; ok {
+				continue
+			}
+			if cb, ok := replayed.CostBasis[ticker]; ok && replayed.Positions[ticker] == pos {
+				p.setCostBasis(ticker, &cb)
+				tickers = append(tickers, ticker)
+			}
+		}
+		if replayed.CashUSD == p.CashUSD && p.RealizedPnL.IsZero() && !replayed.RealizedPnL.IsZero() {
+			p.RealizedPnL = replayed.RealizedPnL
+			realized = true
+		}
+		if len(tickers) == 0 && !realized {
+			return nil
+		}
+		return tx.SetUser(user)
+	})
+	sort.Strings(tickers)
+	return tickers, realized, err
+}
+
+// replayTrades executes the trades (oldest first) on a new portfolio with the
+// starting cash, at their executed prices and fees.
+func replayTrades(trades []TradeRecord) Portfolio {
+	p := Portfolio{CashUSD: defaultStartingCash, Positions: make(map[string]Amount)}
+	for _, tr := range trades {
+		before := p.Positions[tr.Ticker]
+		cost := tr.Size.F().Mul(tr.Price.F())
+		delta := tr.Size.F()
+		if tr.Action == grpcoin.TradeAction_SELL {
+			cost, delta = cost.Neg(), delta.Neg()
+		}
+		p.CashUSD = ToAmount(p.CashUSD.F().Sub(cost).Sub(tr.Fee.F()))
+		if after := before.F().Add(delta); after.IsZero() {
+			delete(p.Positions, tr.Ticker)
+		} else {
+			p.Positions[tr.Ticker] = ToAmount(after)
+		}
+		p.updateCostBasis(tr.Ticker, tr.Action, before, tr.Size, tr.Price, tr.Fee)
+	}
+	return p
+}
@@ -0,0 +1,241 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tradecounters"
+)
+
+func TestPortfolio_updateCostBasis(t *testing.T) {
+	buy, sell := grpcoin.TradeAction_BUY, grpcoin.TradeAction_SELL
+	tests := []struct {
+		name     string
+		basis    map[string]Amount
+		realized Amount
+		before   Amount
+		action   grpcoin.TradeAction
+		size     Amount
+		price    Amount
+		fee      Amount
+
+		wantBasis    map[string]Amount
+		wantRealized Amount
+	}{
+		{name: "open long",
+			action: buy, size: Amount{Units: 2}, price: Amount{Units: 100}, fee: Amount{Units: 2},
+			wantBasis: map[string]Amount{"BTC": {Units: 101}}},
+		{name: "increase long",
+			basis:  map[string]Amount{"BTC": {Units: 101}},
+			before: Amount{Units: 2},
+			action: buy, size: Amount{Units: 2}, price: Amount{Units: 121},
+			wantBasis: map[string]Amount{"BTC": {Units: 111}}},
+		{name: "partially close long",
+			basis:    map[string]Amount{"BTC": {Units: 111}},
+			realized: Amount{Units: 5},
+			before:   Amount{Units: 4},
+			action:   sell, size: Amount{Units: 1}, price: Amount{Units: 131}, fee: Amount{Units: 1},
+			wantBasis:    map[string]Amount{"BTC": {Units: 111}},
+			wantRealized: Amount{Units: 24}},
+		{name: "close long at loss",
+			basis:  map[string]Amount{"BTC": {Units: 111}, "ETH": {Units: 10}},
+			before: Amount{Units: 1},
+			action: sell, size: Amount{Units: 1}, price: Amount{Units: 101},
+			wantBasis:    map[string]Amount{"ETH": {Units: 10}},
+			wantRealized: Amount{Units: -10}},
+		{name: "close last position",
+			basis:  map[string]Amount{"BTC": {Units: 100}},
+			before: Amount{Units: 1},
+			action: sell, size: Amount{Units: 1}, price: Amount{Units: 100},
+			wantBasis: nil},
+		{name: "open short",
+			action: sell, size: Amount{Units: 2}, price: Amount{Units: 100}, fee: Amount{Units: 2},
+			wantBasis: map[string]Amount{"BTC": {Units: 99}}},
+		{name: "partially cover short",
+			basis:  map[string]Amount{"BTC": {Units: 99}},
+			before: Amount{Units: -2},
+			action: buy, size: Amount{Units: 1}, price: Amount{Units: 89},
+			wantBasis:    map[string]Amount{"BTC": {Units: 99}},
+			wantRealized: Amount{Units: 10}},
+		{name: "reverse long to short",
+			basis:  map[string]Amount{"BTC": {Units: 100}},
+			before: Amount{Units: 1},
+			action: sell, size: Amount{Units: 3}, price: Amount{Units: 110}, fee: Amount{Units: 3},
+			wantBasis:    map[string]Amount{"BTC": {Units: 109}},
+			wantRealized: Amount{Units: 9}},
+		{name: "fractional amounts",
+			action: buy, size: Amount{Units: 0, Nanos: 300_000_000}, price: Amount{Units: 10},
+			fee:       Amount{Nanos: 30_000_000},
+			wantBasis: map[string]Amount{"BTC": {Units: 10, Nanos: 100_000_000}}},
+		{name: "unknown basis stays unknown",
+			before: Amount{Units: 2},
+			action: buy, size: Amount{Units: 1}, price: Amount{Units: 100},
+			wantBasis: nil},
+		{name: "unknown basis closed",
+			before:   Amount{Units: 2},
+			realized: Amount{Units: 7},
+			action:   sell, size: Amount{Units: 2}, price: Amount{Units: 100},
+			wantBasis:    nil,
+			wantRealized: Amount{Units: 7}},
+		{name: "unknown basis reversed",
+			before: Amount{Units: 1},
+			action: sell, size: Amount{Units: 2}, price: Amount{Units: 100},
+			wantBasis: map[string]Amount{"BTC": {Units: 100}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Portfolio{CostBasis: tt.basis, RealizedPnL: tt.realized}
+			p.updateCostBasis("BTC", tt.action, tt.before, tt.size, tt.price, tt.fee)
+			if diff := cmp.Diff(tt.wantBasis, p.CostBasis); diff != "" {
+				t.Errorf("cost basis: %s", diff)
+			}
+			if p.RealizedPnL != tt.wantRealized {
+				t.Errorf("realized P&L: got=%v want=%v", p.RealizedPnL, tt.wantRealized)
+			}
+		})
+	}
+}
+
+func TestPortfolio_UnrealizedPnL(t *testing.T) {
+	p := Portfolio{
+		Positions: map[string]Amount{"BTC": {Units: 2}, "ETH": {Units: -3}, "DOGE": {Units: 100}},
+		CostBasis: map[string]Amount{"BTC": {Units: 100}, "ETH": {Units: 10}},
+	}
+	prices := map[string]Amount{"BTC": {Units: 110}, "ETH": {Units: 12}, "DOGE": {Units: 1}}
+	tests := []struct {
+		ticker string
+		want   Amount
+		known  bool
+	}{
+		{"BTC", Amount{Units: 20}, true},
+		{"ETH", Amount{Units: -6}, true},
+		{"DOGE", Amount{}, false},
+		{"XRP", Amount{}, true},
+	}
+	for _, tt := range tests {
+		got, known := p.UnrealizedPnL(tt.ticker, prices[tt.ticker])
+		if got != tt.want || known != tt.known {
+			t.Errorf("UnrealizedPnL(%s) = %v,%v want %v,%v", tt.ticker, got, known, tt.want, tt.known)
+		}
+	}
+	if got, want := p.TotalUnrealizedPnL(prices), (Amount{Units: 14}); got != want {
+		t.Errorf("TotalUnrealizedPnL() = %v, want %v", got, want)
+	}
+}
+
+func Test_replayTrades(t *testing.T) {
+	got := replayTrades([]TradeRecord{
+		{Ticker: "BTC", Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 2}, Price: Amount{Units: 100}, Fee: Amount{Units: 2}},
+		{Ticker: "BTC", Action: grpcoin.TradeAction_SELL, Size: Amount{Units: 1}, Price: Amount{Units: 120}},
+		{Ticker: "ETH", Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 1}, Price: Amount{Units: 50}},
+		{Ticker: "DOGE", Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 10}, Price: Amount{Units: 1}},
+		{Ticker: "DOGE", Action: grpcoin.TradeAction_SELL, Size: Amount{Units: 10}, Price: Amount{Units: 2}},
+	})
+	want := Portfolio{
+		CashUSD:     Amount{Units: 99_878},
+		Positions:   map[string]Amount{"BTC": {Units: 1}, "ETH": {Units: 1}},
+		CostBasis:   map[string]Amount{"BTC": {Units: 101}, "ETH": {Units: 50}},
+		RealizedPnL: Amount{Units: 29},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestUserDB_BackfillCostBasis(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+		for _, tr := range []struct {
+			ticker string
+			action grpcoin.TradeAction
+			price  int64
+			size   int64
+		}{
+			{"BTC", grpcoin.TradeAction_BUY, 100, 2},
+			{"BTC", grpcoin.TradeAction_BUY, 200, 2},
+			{"ETH", grpcoin.TradeAction_BUY, 10, 5},
+			{"ETH", grpcoin.TradeAction_SELL, 20, 3},
+		} {
+			if _, _, err := udb.Trade(ctx, tu.DBKey(), tr.ticker, tr.action,
+				&grpcoin.Amount{Units: tr.price}, &grpcoin.Amount{Units: tr.size}, ""); err != nil {
+				t.Fatal(err)
+			}
+		}
+		tracked, _, err := udb.Get(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// positions opened before the cost basis was tracked
+		legacy := tracked
+		legacy.Portfolio.CostBasis, legacy.Portfolio.RealizedPnL = nil, Amount{}
+		setUser := func(u User) error {
+			return udb.DB.RunTx(ctx, func(_ context.Context, tx Tx) error { return tx.SetUser(u) })
+		}
+		if err := setUser(legacy); err != nil {
+			t.Fatal(err)
+		}
+		tickers, realized, err := udb.BackfillCostBasis(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"BTC", "ETH"}, tickers); diff != "" || !realized {
+			t.Fatalf("wrong backfill (realized=%v): %s", realized, diff)
+		}
+		got, _, err := udb.Get(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tracked.Portfolio, got.Portfolio); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// already backfilled
+		tickers, realized, err = udb.BackfillCostBasis(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		} else if len(tickers) != 0 || realized {
+			t.Fatalf("expected no backfill, got: %v (realized=%v)", tickers, realized)
+		}
+
+		// positions not explained by the history are not backfilled
+		legacy.Portfolio.Positions["BTC"] = Amount{Units: 10}
+		if err := setUser(legacy); err != nil {
+			t.Fatal(err)
+		}
+		tickers, realized, err = udb.BackfillCostBasis(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"ETH"}, tickers); diff != "" || !realized {
+			t.Fatalf("wrong backfill (realized=%v): %s", realized, diff)
+		}
+	})
+}
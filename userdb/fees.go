@@ -0,0 +1,72 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+var (
+	bpsDivisor       = decimal.NewFromInt(10_000)
+	slippageNotional = decimal.NewFromInt(10_000)
+)
+
+// FeeSchedule describes the costs of trading. The zero value means trades
+// are executed at the quote price for free.
+type FeeSchedule struct {
+	// MakerBps is the fee (in basis points of the trade value) of the trades
+	// executed by limit orders.
+	MakerBps float64
+	// TakerBps is the fee (in basis points of the trade value) of the trades
+	// executed at the market price, including the triggered orders.
+	TakerBps float64
+	// MinFeeUSD is the minimum fee charged for a trade.
+	MinFeeUSD float64
+
+	// SlippageBps is how much (in basis points) the executed price moves
+	// against the taker trades for every $10,000 of trade value.
+	SlippageBps float64
+	// MaxSlippageBps caps the slippage, 0 means no limit.
+	MaxSlippageBps float64
+}
+
+// executionPrice returns the price the trade of the specified size executes
+// at with the slippage applied. Maker trades do not have slippage.
+func (f FeeSchedule) executionPrice(action grpcoin.TradeAction, quote, size decimal.Decimal, maker bool) decimal.Decimal {
+	if maker || f.SlippageBps <= 0 {
+		return quote
+	}
+	bps := decimal.NewFromFloat(f.SlippageBps).Mul(quote.Mul(size)).Div(slippageNotional)
+	if f.MaxSlippageBps > 0 {
+		bps = decimal.Min(bps, decimal.NewFromFloat(f.MaxSlippageBps))
+	}
+	slip := quote.Mul(bps).Div(bpsDivisor)
+	if action == grpcoin.TradeAction_SELL {
+		return quote.Sub(slip)
+	}
+	return quote.Add(slip)
+}
+
+// fee returns the fee charged for a trade of the specified value.
+func (f FeeSchedule) fee(value decimal.Decimal, maker bool) decimal.Decimal {
+	bps := f.TakerBps
+	if maker {
+		bps = f.MakerBps
+	}
+	fee := value.Mul(decimal.NewFromFloat(bps)).Div(bpsDivisor)
+	return decimal.Max(fee, decimal.NewFromFloat(f.MinFeeUSD))
+}
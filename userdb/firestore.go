@@ -0,0 +1,470 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+)
+
+const (
+	fsUserCol      = "users"             // users collection
+	fsTradesCol    = "orders"            // sub-collection for user
+	fsValueHistCol = "valuations"        // sub-collection for user's portfolio value over time
+	fsDailyValCol  = "valuations_daily"  // sub-collection for user's daily portfolio value rollups
+	fsWeeklyValCol = "valuations_weekly" // sub-collection for user's weekly portfolio value rollups
+	fsOrderBookCol = "orderbook"         // sub-collection for user's orders waiting to be executed
+	fsTradeReqCol  = "tradereqs"         // sub-collection for user's recent trades by client order id
+	fsAlertsCol    = "alerts"            // sub-collection for user's price alerts
+	fsLedgerCol    = "ledger"            // sub-collection for user's portfolio changes
+)
+
+// FirestoreStore stores the users in Firestore, with the records of a user in
+// the sub-collections of the user's document.
+type FirestoreStore struct {
+	DB *firestore.Client
+}
+
+func (f *FirestoreStore) userRef(uid string) *firestore.DocumentRef {
+	return f.DB.Collection(fsUserCol).Doc(uid)
+}
+
+func (f *FirestoreStore) orderRef(uid, orderID string) *firestore.DocumentRef {
+	return f.userRef(uid).Collection(fsOrderBookCol).Doc(orderID)
+}
+
+func (f *FirestoreStore) alertRef(uid, alertID string) *firestore.DocumentRef {
+	return f.userRef(uid).Collection(fsAlertsCol).Doc(alertID)
+}
+
+func (f *FirestoreStore) ledgerRef(uid string, seq int) *firestore.DocumentRef {
+	return f.userRef(uid).Collection(fsLedgerCol).Doc(fmt.Sprintf("%010d", seq))
+}
+
+func (f *FirestoreStore) RunTx(ctx context.Context, fn func(ctx context.Context, tx Tx) error) error {
+	return f.DB.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		return fn(ctx, &firestoreTx{f: f, tx: tx})
+	}, firestore.MaxAttempts(1))
+}
+
+func (f *FirestoreStore) CreateUser(ctx context.Context, u User) error {
+	_, err := f.userRef(u.ID).Create(ctx, u)
+	return err
+}
+
+func (f *FirestoreStore) GetUser(ctx context.Context, uid string) (User, bool, error) {
+	doc, err := f.userRef(uid).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return User{}, false, nil
+		}
+		return User{}, false, status.Errorf(codes.Internal, "failed to retrieve user: %v", err)
+	}
+	var uv User
+	if err := doc.DataTo(&uv); err != nil {
+		return User{}, false, fmt.Errorf("failed to unpack user record %q: %w", uid, err)
+	}
+	return uv, true, nil
+}
+
+func (f *FirestoreStore) Users(ctx context.Context) ([]User, error) {
+	return readUsers(f.DB.Collection(fsUserCol).Documents(ctx))
+}
+
+func (f *FirestoreStore) UsersWithShorts(ctx context.Context) ([]User, error) {
+	return readUsers(f.DB.Collection(fsUserCol).Where("Portfolio.HasShorts", "==", true).Documents(ctx))
+}
+
+func (f *FirestoreStore) AddTrade(ctx context.Context, uid string, tr TradeRecord) error {
+	_, err := f.userRef(uid).Collection(fsTradesCol).Doc(tradeKey(tr)).Create(ctx, tr)
+	return err
+}
+
+func (f *FirestoreStore) Trades(ctx context.Context, uid string) ([]TradeRecord, error) {
+	return readTrades(f.userRef(uid).Collection(fsTradesCol).Documents(ctx))
+}
+
+func (f *FirestoreStore) TradeHistory(ctx context.Context, uid string, tf TradeFilter, pageSize int, pageToken string) ([]TradeRecord, string, error) {
+	// TODO create an index for orders.ticker + date DESC
+	q := f.userRef(uid).Collection(fsTradesCol).Query
+	if tf.Ticker != "" {
+		q = q.Where("ticker", "==", tf.Ticker)
+	}
+	if !tf.Since.IsZero() {
+		q = q.Where("date", ">=", tf.Since)
+	}
+	if !tf.Until.IsZero() {
+		q = q.Where("date", "<", tf.Until)
+	}
+	q = q.OrderBy("date", firestore.Desc).OrderBy(firestore.DocumentID, firestore.Asc)
+	if pageToken != "" {
+		t, id, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		q = q.StartAfter(t, id)
+	}
+
+	var out []TradeRecord
+	var lastID string
+	iter := q.Limit(pageSize + 1).Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if len(out) == pageSize {
+			return out, encodePageToken(out[len(out)-1].Date, lastID), nil
+		}
+		var v TradeRecord
+		if err := doc.DataTo(&v); err != nil {
+			return nil, "", err
+		}
+		out = append(out, v)
+		lastID = doc.Ref.ID
+	}
+	return out, "", nil
+}
+
+// fsValuationCols are the sub-collections of user's valuation history by
+// resolution.
+var fsValuationCols = map[ValuationResolution]string{
+	HourlyValuations: fsValueHistCol,
+	DailyValuations:  fsDailyValCol,
+	WeeklyValuations: fsWeeklyValCol,
+}
+
+// valuationsCol returns the collection of user's valuation history of the
+// resolution.
+func (f *FirestoreStore) valuationsCol(uid string, res ValuationResolution) (*firestore.CollectionRef, error) {
+	col, ok := fsValuationCols[res]
+	if !ok {
+		return nil, fmt.Errorf("unknown valuation resolution %q", res)
+	}
+	return f.userRef(uid).Collection(col), nil
+}
+
+func (f *FirestoreStore) AddValuation(ctx context.Context, uid string, res ValuationResolution, v ValuationHistory) error {
+	col, err := f.valuationsCol(uid, res)
+	if err != nil {
+		return err
+	}
+	_, err = col.Doc(canonicalizeValuationHistoryDBKey(v.Date)).Create(ctx, v)
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return err
+	}
+	return nil
+}
+
+func (f *FirestoreStore) Valuations(ctx context.Context, uid string, res ValuationResolution, since time.Time) ([]ValuationHistory, error) {
+	col, err := f.valuationsCol(uid, res)
+	if err != nil {
+		return nil, err
+	}
+	q := col.Query
+	if !since.IsZero() {
+		q = q.Where("date", ">=", since)
+	}
+	var out []ValuationHistory
+	iter := q.OrderBy("date", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var v ValuationHistory
+		if err := doc.DataTo(&v); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func (f *FirestoreStore) DeleteValuations(ctx context.Context, uid string, res ValuationResolution, before time.Time) error {
+	col, err := f.valuationsCol(uid, res)
+	if err != nil {
+		return err
+	}
+	// TODO create an index for users/*/date ASC
+	it := col.Where("date", "<", before).Documents(ctx)
+	return firestoreutil.BatchDeleteAll(ctx, f.DB, it)
+}
+
+func (f *FirestoreStore) DeleteTradeRequests(ctx context.Context, uid string, before time.Time) error {
+	it := f.userRef(uid).Collection(fsTradeReqCol).Where("expiresAt", "<", before).Documents(ctx)
+	return firestoreutil.BatchDeleteAll(ctx, f.DB, it)
+}
+
+func (f *FirestoreStore) GetOrder(ctx context.Context, uid, orderID string) (Order, bool, error) {
+	doc, err := f.orderRef(uid, orderID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return Order{}, false, nil
+		}
+		return Order{}, false, status.Errorf(codes.Internal, "failed to retrieve order: %v", err)
+	}
+	var o Order
+	if err := doc.DataTo(&o); err != nil {
+		return Order{}, false, fmt.Errorf("failed to unpack order record %q: %w", orderID, err)
+	}
+	return o, true, nil
+}
+
+func (f *FirestoreStore) ListOrders(ctx context.Context, uid string, of OrderFilter, pageSize int, pageToken string) ([]Order, string, error) {
+	// TODO create indexes for orderbook.status/ticker + createdAt DESC
+	q := f.userRef(uid).Collection(fsOrderBookCol).Query
+	if of.Status != grpcoin.OrderStatus_UNDEFINED_ORDER_STATUS {
+		q = q.Where("status", "==", of.Status)
+	}
+	if of.Ticker != "" {
+		q = q.Where("ticker", "==", of.Ticker)
+	}
+	q = q.OrderBy("createdAt", firestore.Desc).OrderBy(firestore.DocumentID, firestore.Asc)
+	if pageToken != "" {
+		t, id, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		q = q.StartAfter(t, id)
+	}
+	out, err := readOrders(q.Limit(pageSize + 1).Documents(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(out) <= pageSize {
+		return out, "", nil
+	}
+	out = out[:pageSize]
+	last := out[len(out)-1]
+	return out, encodePageToken(last.CreatedAt, last.ID), nil
+}
+
+func (f *FirestoreStore) OpenOrders(ctx context.Context) ([]Order, error) {
+	// TODO create a collection group index for orderbook.status
+	return readOrders(f.DB.CollectionGroup(fsOrderBookCol).
+		Where("status", "==", grpcoin.OrderStatus_OPEN).Documents(ctx))
+}
+
+func (f *FirestoreStore) CreateAlert(ctx context.Context, a Alert) error {
+	_, err := f.alertRef(a.UserID, a.ID).Create(ctx, a)
+	return err
+}
+
+func (f *FirestoreStore) ListAlerts(ctx context.Context, uid string) ([]Alert, error) {
+	return readAlerts(f.userRef(uid).Collection(fsAlertsCol).
+		OrderBy("createdAt", firestore.Desc).Documents(ctx))
+}
+
+func (f *FirestoreStore) ActiveAlerts(ctx context.Context) ([]Alert, error) {
+	// TODO create a collection group index for alerts.active
+	return readAlerts(f.DB.CollectionGroup(fsAlertsCol).Where("active", "==", true).Documents(ctx))
+}
+
+func (f *FirestoreStore) DeleteAlert(ctx context.Context, uid, alertID string) error {
+	_, err := f.alertRef(uid, alertID).Delete(ctx, firestore.Exists)
+	return err
+}
+
+func (f *FirestoreStore) Ledger(ctx context.Context, uid string) ([]LedgerEntry, error) {
+	return readLedger(f.userRef(uid).Collection(fsLedgerCol).OrderBy("seq", firestore.Asc).Documents(ctx))
+}
+
+// firestoreTx implements Tx in a Firestore transaction.
+type firestoreTx struct {
+	f  *FirestoreStore
+	tx *firestore.Transaction
+}
+
+func (t *firestoreTx) GetUser(uid string) (User, error) {
+	var u User
+	err := t.get(t.f.userRef(uid), &u)
+	return u, err
+}
+
+func (t *firestoreTx) GetOrder(uid, orderID string) (Order, error) {
+	var o Order
+	err := t.get(t.f.orderRef(uid, orderID), &o)
+	return o, err
+}
+
+func (t *firestoreTx) GetAlert(uid, alertID string) (Alert, error) {
+	var a Alert
+	err := t.get(t.f.alertRef(uid, alertID), &a)
+	return a, err
+}
+
+func (t *firestoreTx) GetTradeRequest(uid, clientOrderID string) (TradeRequest, bool, error) {
+	var v TradeRequest
+	err := t.get(t.f.userRef(uid).Collection(fsTradeReqCol).Doc(clientOrderID), &v)
+	if status.Code(err) == codes.NotFound {
+		return TradeRequest{}, false, nil
+	}
+	return v, err == nil, err
+}
+
+func (t *firestoreTx) Trades(uid string) ([]TradeRecord, error) {
+	return readTrades(t.tx.Documents(t.f.userRef(uid).Collection(fsTradesCol).OrderBy("date", firestore.Asc)))
+}
+
+func (t *firestoreTx) Ledger(uid string) ([]LedgerEntry, error) {
+	return readLedger(t.tx.Documents(t.f.userRef(uid).Collection(fsLedgerCol).OrderBy("seq", firestore.Asc)))
+}
+
+func (t *firestoreTx) get(ref *firestore.DocumentRef, v interface{}) error {
+	doc, err := t.tx.Get(ref)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.NotFound, "%s not found", ref.Path)
+	} else if err != nil {
+		return fmt.Errorf("failed to read %s for tx: %w", ref.Path, err)
+	}
+	if err := doc.DataTo(v); err != nil {
+		return fmt.Errorf("failed to unpack %s into struct: %w", ref.Path, err)
+	}
+	return nil
+}
+
+func (t *firestoreTx) SetUser(u User) error { return t.tx.Set(t.f.userRef(u.ID), u) }
+
+func (t *firestoreTx) CreateOrder(o Order) error { return t.tx.Create(t.f.orderRef(o.UserID, o.ID), o) }
+
+func (t *firestoreTx) SetOrder(o Order) error { return t.tx.Set(t.f.orderRef(o.UserID, o.ID), o) }
+
+func (t *firestoreTx) SetAlert(a Alert) error { return t.tx.Set(t.f.alertRef(a.UserID, a.ID), a) }
+
+func (t *firestoreTx) SetTradeRequest(uid, clientOrderID string, v TradeRequest) error {
+	return t.tx.Set(t.f.userRef(uid).Collection(fsTradeReqCol).Doc(clientOrderID), v)
+}
+
+func (t *firestoreTx) AddLedgerEntry(uid string, e LedgerEntry) error {
+	return t.tx.Create(t.f.ledgerRef(uid, e.Seq), e)
+}
+
+func readUsers(iter *firestore.DocumentIterator) ([]User, error) {
+	defer iter.Stop()
+	var out []User
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var u User
+		if err := doc.DataTo(&u); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+func readTrades(iter *firestore.DocumentIterator) ([]TradeRecord, error) {
+	defer iter.Stop()
+	var out []TradeRecord
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var v TradeRecord
+		if err := doc.DataTo(&v); err != nil {
+			return nil, fmt.Errorf("failed to unpack trade record: %w", err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+func readOrders(iter *firestore.DocumentIterator) ([]Order, error) {
+	defer iter.Stop()
+	var out []Order
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var o Order
+		if err := doc.DataTo(&o); err != nil {
+			return nil, fmt.Errorf("failed to unpack order record: %w", err)
+		}
+		out = append(out, o)
+	}
+	return out, nil
+}
+
+func readAlerts(iter *firestore.DocumentIterator) ([]Alert, error) {
+	defer iter.Stop()
+	var out []Alert
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var a Alert
+		if err := doc.DataTo(&a); err != nil {
+			return nil, fmt.Errorf("failed to unpack alert record: %w", err)
+		}
+		out = append(out, a)
+	}
+	return out, nil
+}
+
+func readLedger(iter *firestore.DocumentIterator) ([]LedgerEntry, error) {
+	defer iter.Stop()
+	var out []LedgerEntry
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var v LedgerEntry
+		if err := doc.DataTo(&v); err != nil {
+			return nil, fmt.Errorf("failed to unpack ledger entry %s: %w", doc.Ref.ID, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
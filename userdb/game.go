@@ -20,3 +20,14 @@ func setupGamePortfolio(u *User) {
 	u.Portfolio = Portfolio{CashUSD: defaultStartingCash,
 		Positions: map[string]Amount{}}
 }
+
+// Valuation returns the value of the portfolio at the specified prices: cash
+// plus the value of the positions. Positions without a price are valued at
+// zero.
+func Valuation(p Portfolio, prices map[string]Amount) Amount {
+	total := p.CashUSD.F()
+	for curr, amt := range p.Positions {
+		total = total.Add(amt.F().Mul(prices[curr].F()))
+	}
+	return ToAmount(total)
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import "testing"
+
+func TestValuation(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Portfolio
+		prices map[string]Amount
+		want   Amount
+	}{
+		{name: "cash only",
+			p:    Portfolio{CashUSD: Amount{Units: 100, Nanos: 500_000_000}},
+			want: Amount{Units: 100, Nanos: 500_000_000}},
+		{name: "long and short positions",
+			p: Portfolio{CashUSD: Amount{Units: 1000}, Positions: map[string]Amount{
+				"BTC": {Units: 2},
+				"ETH": {Units: -1, Nanos: -500_000_000},
+			}},
+			prices: map[string]Amount{"BTC": {Units: 300}, "ETH": {Units: 100}},
+			want:   Amount{Units: 1450}},
+		{name: "no price",
+			p:    Portfolio{CashUSD: Amount{Units: 1000}, Positions: map[string]Amount{"BTC": {Units: 2}}},
+			want: Amount{Units: 1000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Valuation(tt.p, tt.prices); got != tt.want {
+				t.Errorf("Valuation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Ledger entry types.
+const (
+	// LedgerOpeningBalance records the portfolio before the first change
+	// recorded in the ledger (e.g. the starting cash).
+	LedgerOpeningBalance = "opening_balance"
+	LedgerTrade          = "trade"
+	// LedgerOrder records the reservations of an order that is placed or
+	// closed.
+	LedgerOrder = "order"
+)
+
+// LedgerEntry records a change of user's portfolio as the amounts added to
+// (or subtracted from) it. Entries are appended in the same transaction as
+// the change and never modified, so replaying them reconstructs the
+// portfolio.
+type LedgerEntry struct {
+	// Seq is the position of the entry in the user's ledger, starting at 1.
+	Seq  int       `firestore:"seq"`
+	Date time.Time `firestore:"date"`
+	Type string    `firestore:"type"`
+
+	// Trade is set for trade entries, OrderID for the entries of the changes
+	// made by orders.
+	Trade   *TradeRecord `firestore:"trade,omitempty"`
+	OrderID string       `firestore:"orderID,omitempty"`
+
+	CashUSD           Amount            `firestore:"cashUSD"`
+	Positions         map[string]Amount `firestore:"positions"`
+	ReservedCashUSD   Amount            `firestore:"reservedCashUSD"`
+	ReservedPositions map[string]Amount `firestore:"reservedPositions"`
+}
+
+// appendLedger records the change of user's portfolio from before in tx, and
+// advances the user's ledger sequence. The first entry of a ledger is
+// preceded by the opening balance. It must be invoked before the user is
+// written in tx. The entry is not recorded if the portfolio has not changed.
+func appendLedger(tx Tx, u *User, before Portfolio, e LedgerEntry) error {
+	diff := diffPortfolios(before, u.Portfolio)
+	if diff.empty() {
+		return nil
+	}
+	if u.LedgerSeq == 0 {
+		opening := diffPortfolios(Portfolio{}, before)
+		opening.Seq, opening.Date, opening.Type = 1, e.Date, LedgerOpeningBalance
+		if err := tx.AddLedgerEntry(u.ID, opening); err != nil {
+			return err
+		}
+		u.LedgerSeq = 1
+	}
+	diff.Seq, diff.Date, diff.Type = u.LedgerSeq+1, e.Date, e.Type
+	diff.Trade, diff.OrderID = e.Trade, e.OrderID
+	if err := tx.AddLedgerEntry(u.ID, diff); err != nil {
+		return err
+	}
+	u.LedgerSeq++
+	return nil
+}
+
+// diffPortfolios returns an entry with the amounts to add to p1 to get p2.
+func diffPortfolios(p1, p2 Portfolio) LedgerEntry {
+	return LedgerEntry{
+		CashUSD:           ToAmount(p2.CashUSD.F().Sub(p1.CashUSD.F())),
+		Positions:         diffAmounts(p1.Positions, p2.Positions),
+		ReservedCashUSD:   ToAmount(p2.ReservedCashUSD.F().Sub(p1.ReservedCashUSD.F())),
+		ReservedPositions: diffAmounts(p1.ReservedPositions, p2.ReservedPositions),
+	}
+}
+
+func diffAmounts(m1, m2 map[string]Amount) map[string]Amount {
+	out := make(map[string]Amount)
+	for k, v := range m2 {
+		if d := v.F().Sub(m1[k].F()); !d.IsZero() {
+			out[k] = ToAmount(d)
+		}
+	}
+	for k, v := range m1 {
+		if _, ok := m2[k]; !ok && !v.IsZero() {
+			out[k] = ToAmount(v.F().Neg())
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func (e LedgerEntry) empty() bool {
+	return e.CashUSD.IsZero() && len(e.Positions) == 0 &&
+		e.ReservedCashUSD.IsZero() && len(e.ReservedPositions) == 0
+}
+
+// ReplayLedger returns the portfolio reconstructed from the ledger entries
+// ordered by their sequence. Only the balances (cash, positions and their
+// reservations) are reconstructed.
+func ReplayLedger(entries []LedgerEntry) Portfolio {
+	p := Portfolio{Positions: make(map[string]Amount), ReservedPositions: make(map[string]Amount)}
+	for _, e := range entries {
+		p.CashUSD = ToAmount(p.CashUSD.F().Add(e.CashUSD.F()))
+		p.ReservedCashUSD = ToAmount(p.ReservedCashUSD.F().Add(e.ReservedCashUSD.F()))
+		addAmounts(p.Positions, e.Positions)
+		addAmounts(p.ReservedPositions, e.ReservedPositions)
+	}
+	if len(p.ReservedPositions) == 0 {
+		p.ReservedPositions = nil
+	}
+	p.HasShorts = hasShorts(p.Positions)
+	return p
+}
+
+func addAmounts(m, delta map[string]Amount) {
+	for k, v := range delta {
+		if sum := m[k].F().Add(v.F()); sum.IsZero() {
+			delete(m, k)
+		} else {
+			m[k] = ToAmount(sum)
+		}
+	}
+}
+
+// Reconciliation is the result of comparing user's portfolio and trade
+// history with the ledger.
+type Reconciliation struct {
+	UserID  string
+	Entries int
+	// Discrepancies describe the differences of the portfolio from the
+	// portfolio replayed from the ledger, and the problems of the ledger.
+	Discrepancies []string
+	// MissingTrades are the trades in the ledger missing from the trade
+	// history.
+	MissingTrades []TradeRecord
+	// Fixed is set if the portfolio is corrected or the missing trades are
+	// added to the trade history.
+	Fixed bool
+}
+
+// Reconcile replays the user's ledger and compares the result with the
+// portfolio, and the trades in the ledger with the trade history. If fix is
+// set, the portfolio balances are corrected to match the ledger, and the
+// missing trades are added to the history.
+func (u *UserDB) Reconcile(ctx context.Context, uid string, fix bool) (Reconciliation, error) {
+	ctx, s := u.T.Start(ctx, "reconcile")
+	defer s.End()
+	out := Reconciliation{UserID: uid}
+
+	history, err := u.DB.Trades(ctx, uid)
+	if err != nil {
+		return out, fmt.Errorf("failed to read trade history: %w", err)
+	}
+	err = u.DB.RunTx(ctx, func(ctx context.Context, tx Tx) error {
+		out.Entries, out.Discrepancies, out.MissingTrades, out.Fixed = 0, nil, nil, false
+		user, err := tx.GetUser(uid)
+		if err != nil {
+			return err
+		}
+		entries, err := tx.Ledger(uid)
+		if err != nil {
+			return err
+		}
+		out.Entries = len(entries)
+		for i, e := range entries {
+			if e.Seq != i+1 {
+				out.Discrepancies = append(out.Discrepancies, fmt.Sprintf("ledger entry %d is missing", i+1))
+				break
+			}
+		}
+		if len(entries) != user.LedgerSeq {
+			out.Discrepancies = append(out.Discrepancies,
+				fmt.Sprintf("ledger has %d entries, user record expects %d", len(entries), user.LedgerSeq))
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		want := ReplayLedger(entries)
+		diff := diffPortfolios(user.Portfolio, want)
+		out.Discrepancies = append(out.Discrepancies, describeDiff(diff)...)
+		out.MissingTrades = missingTrades(entries, history)
+		last := entries[len(entries)-1].Seq
+		if !fix || (diff.empty() && user.LedgerSeq == last) {
+			return nil
+		}
+		// the ledger is the source of truth, so the correction is not
+		// recorded in it. The cost basis and realized P&L are kept.
+		user.Portfolio.CashUSD, user.Portfolio.ReservedCashUSD = want.CashUSD, want.ReservedCashUSD
+		user.Portfolio.Positions, user.Portfolio.ReservedPositions = want.Positions, want.ReservedPositions
+		user.Portfolio.HasShorts = want.HasShorts
+		for ticker := range user.Portfolio.CostBasis {
+			if _, ok := want.Positions[ticker]; !ok {
+				user.Portfolio.setCostBasis(ticker, nil)
+			}
+		}
+		user.LedgerSeq = last
+		out.Fixed = true
+		return tx.SetUser(user)
+	})
+	if err != nil {
+		return out, err
+	}
+	if fix {
+		for _, tr := range out.MissingTrades {
+			if err := u.DB.AddTrade(ctx, uid, tr); err != nil {
+				return out, fmt.Errorf("failed to add missing trade to history: %w", err)
+			}
+		}
+		if len(out.MissingTrades) > 0 {
+			if err := u.Cache.InvalidateTrades(ctx, uid); err != nil {
+				return out, fmt.Errorf("failed to invalidate trade history cache: %w", err)
+			}
+			out.Fixed = true
+		}
+	}
+	return out, nil
+}
+
+// describeDiff returns the descriptions of the amounts to add to the
+// portfolio to match the ledger.
+func describeDiff(d LedgerEntry) []string {
+	var out []string
+	if !d.CashUSD.IsZero() {
+		out = append(out, fmt.Sprintf("cash is off by %s", d.CashUSD.F().Neg()))
+	}
+	if !d.ReservedCashUSD.IsZero() {
+		out = append(out, fmt.Sprintf("reserved cash is off by %s", d.ReservedCashUSD.F().Neg()))
+	}
+	for _, ticker := range sortedKeys(d.Positions) {
+		out = append(out, fmt.Sprintf("%s position is off by %s", ticker, d.Positions[ticker].F().Neg()))
+	}
+	for _, ticker := range sortedKeys(d.ReservedPositions) {
+		out = append(out, fmt.Sprintf("reserved %s position is off by %s", ticker, d.ReservedPositions[ticker].F().Neg()))
+	}
+	return out
+}
+
+// missingTrades returns the trades of the ledger entries that are missing
+// from the trade history.
+func missingTrades(entries []LedgerEntry, history []TradeRecord) []TradeRecord {
+	have := make(map[string]bool, len(history))
+	for _, tr := range history {
+		have[tradeKey(tr)] = true
+	}
+	var out []TradeRecord
+	for _, e := range entries {
+		if e.Trade == nil || have[tradeKey(*e.Trade)] {
+			continue
+		}
+		out = append(out, *e.Trade)
+	}
+	return out
+}
+
+func sortedKeys(m map[string]Amount) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,245 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tradecounters"
+)
+
+func Test_diffPortfolios(t *testing.T) {
+	p1 := Portfolio{
+		CashUSD:           Amount{Units: 100},
+		Positions:         map[string]Amount{"BTC": {Units: 1}, "ETH": {Units: 2}, "DOGE": {}},
+		ReservedPositions: map[string]Amount{"ETH": {Units: 1}},
+	}
+	p2 := Portfolio{
+		CashUSD:         Amount{Units: 50, Nanos: 500_000_000},
+		Positions:       map[string]Amount{"BTC": {Units: 1}, "LTC": {Units: -3}},
+		ReservedCashUSD: Amount{Units: 10},
+	}
+	want := LedgerEntry{
+		CashUSD:           Amount{Units: -49, Nanos: -500_000_000},
+		Positions:         map[string]Amount{"ETH": {Units: -2}, "LTC": {Units: -3}},
+		ReservedCashUSD:   Amount{Units: 10},
+		ReservedPositions: map[string]Amount{"ETH": {Units: -1}},
+	}
+	if diff := cmp.Diff(want, diffPortfolios(p1, p2)); diff != "" {
+		t.Fatal(diff)
+	}
+	if d := diffPortfolios(p1, p1); !d.empty() {
+		t.Fatalf("expected no difference, got: %#v", d)
+	}
+}
+
+func TestReplayLedger(t *testing.T) {
+	p1 := Portfolio{CashUSD: Amount{Units: 100_000}}
+	p2 := Portfolio{
+		CashUSD:           Amount{Units: 40_000},
+		Positions:         map[string]Amount{"BTC": {Units: 2}},
+		ReservedCashUSD:   Amount{Units: 1000},
+		ReservedPositions: map[string]Amount{"BTC": {Units: 1}},
+	}
+	p3 := Portfolio{
+		CashUSD:   Amount{Units: 130_000},
+		Positions: map[string]Amount{"ETH": {Units: -10}},
+		HasShorts: true,
+	}
+	got := ReplayLedger([]LedgerEntry{diffPortfolios(Portfolio{}, p1), diffPortfolios(p1, p2), diffPortfolios(p2, p3)})
+	if diff := cmp.Diff(p3, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestUserDB_Reconcile(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+
+		// no changes recorded yet
+		r, err := udb.Reconcile(ctx, tu.DBKey(), false)
+		if err != nil {
+			t.Fatal(err)
+		} else if r.Entries != 0 || len(r.Discrepancies) != 0 {
+			t.Fatalf("expected empty ledger, got: %#v", r)
+		}
+
+		for _, tr := range []struct {
+			ticker string
+			action grpcoin.TradeAction
+			price  int64
+			size   int64
+		}{
+			{"BTC", grpcoin.TradeAction_BUY, 100, 2},
+			{"ETH", grpcoin.TradeAction_BUY, 10, 5},
+			{"BTC", grpcoin.TradeAction_SELL, 200, 1},
+		} {
+			if _, _, err := udb.Trade(ctx, tu.DBKey(), tr.ticker, tr.action,
+				&grpcoin.Amount{Units: tr.price}, &grpcoin.Amount{Units: tr.size}, ""); err != nil {
+				t.Fatal(err)
+			}
+		}
+		o, err := udb.CreateOrder(ctx, Order{
+			ID:          "order1",
+			UserID:      tu.DBKey(),
+			Type:        grpcoin.OrderType_LIMIT,
+			Ticker:      "ETH",
+			Action:      grpcoin.TradeAction_SELL,
+			Size:        Amount{Units: 2},
+			LimitPrice:  Amount{Units: 30},
+			TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+			Status:      grpcoin.OrderStatus_OPEN,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := udb.FillOrder(ctx, o, &grpcoin.Amount{Units: 31}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := udb.CreateOrder(ctx, Order{
+			ID:          "order2",
+			UserID:      tu.DBKey(),
+			Type:        grpcoin.OrderType_LIMIT,
+			Ticker:      "BTC",
+			Action:      grpcoin.TradeAction_BUY,
+			Size:        Amount{Units: 1},
+			LimitPrice:  Amount{Units: 50},
+			TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+			Status:      grpcoin.OrderStatus_OPEN,
+			CreatedAt:   time.Now().UTC(),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := db.Ledger(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var types []string
+		for _, e := range entries {
+			types = append(types, e.Type)
+		}
+		if diff := cmp.Diff([]string{LedgerOpeningBalance, LedgerTrade, LedgerTrade, LedgerTrade,
+			LedgerOrder, LedgerTrade, LedgerOrder}, types); diff != "" {
+			t.Fatal(diff)
+		}
+		if entries[5].OrderID != "order1" || entries[5].Trade == nil || entries[5].Trade.OrderID != "order1" {
+			t.Fatalf("wrong order fill entry: %#v", entries[5])
+		}
+		u, _, err := udb.Get(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(u.Portfolio, ReplayLedger(entries),
+			cmpopts.EquateEmpty(), cmpopts.IgnoreFields(Portfolio{}, "CostBasis", "RealizedPnL")); diff != "" {
+			t.Fatalf("replayed portfolio differs: %s", diff)
+		}
+		r, err = udb.Reconcile(ctx, tu.DBKey(), false)
+		if err != nil {
+			t.Fatal(err)
+		} else if r.Entries != 7 || len(r.Discrepancies) != 0 || len(r.MissingTrades) != 0 {
+			t.Fatalf("expected consistent portfolio, got: %#v", r)
+		}
+
+		// portfolio changed outside of the ledger
+		tampered := u.clone()
+		tampered.Portfolio.CashUSD = ToAmount(u.Portfolio.CashUSD.F().Add(decimal.NewFromInt(5)))
+		tampered.Portfolio.Positions["DOGE"] = Amount{Units: 1000}
+		if err := udb.DB.RunTx(ctx, func(_ context.Context, tx Tx) error { return tx.SetUser(tampered) }); err != nil {
+			t.Fatal(err)
+		}
+		r, err = udb.Reconcile(ctx, tu.DBKey(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"cash is off by 5", "DOGE position is off by 1000"}, r.Discrepancies); diff != "" {
+			t.Fatal(diff)
+		}
+		r, err = udb.Reconcile(ctx, tu.DBKey(), true)
+		if err != nil {
+			t.Fatal(err)
+		} else if !r.Fixed {
+			t.Fatalf("expected fixed portfolio, got: %#v", r)
+		}
+		fixed, _, err := udb.Get(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(u.Portfolio, fixed.Portfolio, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatal(diff)
+		}
+		r, err = udb.Reconcile(ctx, tu.DBKey(), false)
+		if err != nil {
+			t.Fatal(err)
+		} else if r.Entries != 7 || len(r.Discrepancies) != 0 {
+			t.Fatalf("expected consistent portfolio after fix, got: %#v", r)
+		}
+
+		// trade recorded in the ledger but not in the history (e.g. the
+		// history write after the trade failed)
+		lost := TradeRecord{Date: time.Now().UTC(), Ticker: "ETH", Action: grpcoin.TradeAction_BUY,
+			Size: Amount{Units: 1}, Price: Amount{Units: 10}}
+		if err := udb.DB.RunTx(ctx, func(_ context.Context, tx Tx) error {
+			u, err := tx.GetUser(tu.DBKey())
+			if err != nil {
+				return err
+			}
+			u.LedgerSeq++
+			if err := tx.AddLedgerEntry(u.ID, LedgerEntry{Seq: u.LedgerSeq, Date: lost.Date, Type: LedgerTrade, Trade: &lost}); err != nil {
+				return err
+			}
+			return tx.SetUser(u)
+		}); err != nil {
+			t.Fatal(err)
+		}
+		r, err = udb.Reconcile(ctx, tu.DBKey(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(r.MissingTrades) != 1 || !r.Fixed {
+			t.Fatalf("expected missing trade fixed, got: %#v", r)
+		}
+		history, err := db.Trades(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		} else if len(history) != 5 {
+			t.Fatalf("expected 5 trades in history, got %d", len(history))
+		}
+		r, err = udb.Reconcile(ctx, tu.DBKey(), false)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(r.Discrepancies) != 0 || len(r.MissingTrades) != 0 {
+			t.Fatalf("expected consistent history after fix, got: %#v", r)
+		}
+	})
+}
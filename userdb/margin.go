@@ -0,0 +1,283 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+// MarginPolicy describes the requirements of holding short positions. Long
+// positions are always paid in full, so the proceeds of short sales cannot
+// be used to buy coins. The zero value disables short selling.
+type MarginPolicy struct {
+	// InitialMargin is the ratio of the short positions' value that must be
+	// held in cash, in addition to the proceeds of the short sales, to open
+	// or increase short positions.
+	InitialMargin float64
+	// MaintenanceMargin is the ratio of the short positions' value that must
+	// be held in cash, in addition to the proceeds of the short sales, to
+	// avoid the liquidation of the positions.
+	MaintenanceMargin float64
+}
+
+// Enabled reports whether short selling is allowed.
+func (m MarginPolicy) Enabled() bool { return m.InitialMargin > 0 }
+
+// MarginStatus describes the portfolio valued at the current prices.
+type MarginStatus struct {
+	// Equity is the value of the portfolio: cash and the value of long
+	// positions minus the value of short positions.
+	Equity Amount
+	// MarginUsed is the initial margin required for the short positions.
+	MarginUsed Amount
+	// BuyingPower is the cash that can be used to buy coins or held as margin
+	// for new short positions. It is negative if the portfolio is below the
+	// initial margin requirement.
+	BuyingPower Amount
+}
+
+// Status values the portfolio at the specified prices, which must include
+// all the positions held.
+func (m MarginPolicy) Status(p Portfolio, prices map[string]Amount) (MarginStatus, error) {
+	equity := p.CashUSD.F()
+	for ticker, pos := range p.Positions {
+		price, ok := prices[ticker]
+		if !ok {
+			return MarginStatus{}, fmt.Errorf("no price for %s", ticker)
+		}
+		equity = equity.Add(pos.F().Mul(price.F()))
+	}
+	shorts, err := shortValue(p, prices)
+	if err != nil {
+		return MarginStatus{}, err
+	}
+	return MarginStatus{
+		Equity:      ToAmount(equity),
+		MarginUsed:  ToAmount(shorts.Mul(decimal.NewFromFloat(m.InitialMargin))),
+		BuyingPower: ToAmount(excessCash(p.AvailableCashUSD().F(), shorts, m.InitialMargin)),
+	}, nil
+}
+
+// BelowMaintenance reports whether the portfolio's short positions should be
+// liquidated at the specified prices, which must include the short positions.
+func (m MarginPolicy) BelowMaintenance(p Portfolio, prices map[string]Amount) (bool, error) {
+	shorts, err := shortValue(p, prices)
+	if err != nil {
+		return false, err
+	}
+	return excessCash(p.CashUSD.F(), shorts, m.MaintenanceMargin).IsNegative(), nil
+}
+
+// LiquidationOrder returns the tickers of the positions to close when the
+// portfolio is below the maintenance margin. These are the short positions,
+// preceded by the long positions if the cash is not enough to buy back the
+// short positions.
+func LiquidationOrder(p Portfolio, prices map[string]Amount) ([]string, error) {
+	shorts, err := shortValue(p, prices)
+	if err != nil {
+		return nil, err
+	}
+	var longs, out []string
+	for ticker, pos := range p.Positions {
+		if pos.IsNegative() {
+			out = append(out, ticker)
+		} else if !pos.IsZero() {
+			longs = append(longs, ticker)
+		}
+	}
+	sort.Strings(out)
+	if p.AvailableCashUSD().F().LessThan(shorts) {
+		sort.Strings(longs)
+		out = append(longs, out...)
+	}
+	return out, nil
+}
+
+// checkMargin fails the trade if the portfolio is below the initial margin
+// requirement after the trade, unless the trade reduces the shortfall (e.g.
+// buying back short positions). before is the excess cash before the trade.
+func (m MarginPolicy) checkMargin(before decimal.Decimal, p Portfolio, prices map[string]Amount) error {
+	after, err := m.excess(p, prices)
+	if err != nil {
+		return err
+	}
+	if after.IsNegative() && after.LessThan(before) {
+		return status.Errorf(codes.InvalidArgument,
+			"insufficient margin after transaction (buying power %s), %v%% of the short positions' value must be held as margin",
+			after, m.InitialMargin*100)
+	}
+	return nil
+}
+
+// excess returns the available cash in excess of the initial margin
+// requirement.
+func (m MarginPolicy) excess(p Portfolio, prices map[string]Amount) (decimal.Decimal, error) {
+	shorts, err := shortValue(p, prices)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return excessCash(p.AvailableCashUSD().F(), shorts, m.InitialMargin), nil
+}
+
+// excessCash returns the cash left after holding the cash to buy back the
+// short positions and the margin at the specified ratio.
+func excessCash(cash, shorts decimal.Decimal, ratio float64) decimal.Decimal {
+	return cash.Sub(shorts.Mul(decimal.NewFromFloat(1 + ratio)))
+}
+
+// shortValue returns the value of the short positions at the specified
+// prices.
+func shortValue(p Portfolio, prices map[string]Amount) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for ticker, pos := range p.Positions {
+		if !pos.IsNegative() {
+			continue
+		}
+		price, ok := prices[ticker]
+		if !ok {
+			return decimal.Zero, fmt.Errorf("no price for short position %s", ticker)
+		}
+		total = total.Add(pos.F().Neg().Mul(price.F()))
+	}
+	return total, nil
+}
+
+// Liquidate closes the user's position in ticker at the quote price because
+// the portfolio fell below the maintenance margin. The user's open orders are
+// cancelled in the same transaction, since their reservations would block
+// closing the position. pos is the position at the time of the margin check:
+// if it has changed since (e.g. it was liquidated by another instance), it
+// fails with FailedPrecondition.
+func (u *UserDB) Liquidate(ctx context.Context, uid, ticker string, pos Amount, quote *grpcoin.Amount) (TradeRecord, Portfolio, error) {
+	open, err := u.userOpenOrders(ctx, uid)
+	if err != nil {
+		return TradeRecord{}, Portfolio{}, fmt.Errorf("failed to list open orders: %w", err)
+	}
+	action, size := closingTrade(pos)
+	tr := TradeRecord{
+		Ticker:      ticker,
+		Action:      action,
+		Size:        size,
+		Price:       ToAmount(toDecimal(quote)),
+		Liquidation: true,
+	}
+	return u.trade(ctx, uid, tr, false, func(tx Tx) (func(*Portfolio) error, error) {
+		var cancel []Order
+		for _, o := range open {
+			v, err := readOpenOrder(tx, uid, o.ID)
+			if c := status.Code(err); c == codes.FailedPrecondition || c == codes.NotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			cancel = append(cancel, v)
+		}
+		return func(p *Portfolio) error {
+			if p.Positions[ticker] != pos {
+				return status.Errorf(codes.FailedPrecondition, "%s position changed since the margin check", ticker)
+			}
+			for _, o := range cancel {
+				p.release(o)
+				o.Status = grpcoin.OrderStatus_CANCELLED
+				o.StatusReason = "cancelled by the liquidation of the portfolio"
+				if err := tx.SetOrder(o); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	})
+}
+
+// userOpenOrders returns all open orders of the user.
+func (u *UserDB) userOpenOrders(ctx context.Context, uid string) ([]Order, error) {
+	var out []Order
+	var pageToken string
+	for {
+		page, next, err := u.DB.ListOrders(ctx, uid, OrderFilter{Status: grpcoin.OrderStatus_OPEN}, 100, pageToken)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, page...)
+		if next == "" {
+			return out, nil
+		}
+		pageToken = next
+	}
+}
+
+// shortPrices returns the prices of the user's short positions and the
+// ticker being traded at the specified quote. It's invoked before the trade
+// transaction so that the quotes are not fetched while the transaction is
+// open.
+func (u *UserDB) shortPrices(ctx context.Context, uid, ticker string, quote Amount) (map[string]Amount, error) {
+	out := map[string]Amount{ticker: quote}
+	user, ok, err := u.DB.GetUser(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user for margin check: %w", err)
+	} else if !ok {
+		return out, nil // the transaction fails with the missing user
+	}
+	for t, pos := range user.Portfolio.Positions {
+		if _, ok := out[t]; ok || !pos.IsNegative() {
+			continue
+		}
+		if u.Quotes == nil {
+			return nil, status.Error(codes.Internal, "quote provider is not configured for margin checks")
+		}
+		v, err := u.Quotes.GetQuote(ctx, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s quote for margin check: %w", t, err)
+		}
+		out[t] = ToAmount(toDecimal(v))
+	}
+	return out, nil
+}
+
+// checkPrices fails with Aborted if the portfolio has short positions opened
+// after the prices were fetched.
+func checkPrices(p Portfolio, prices map[string]Amount) error {
+	for t, pos := range p.Positions {
+		if _, ok := prices[t]; !ok && pos.IsNegative() {
+			return status.Errorf(codes.Aborted, "%s short position changed during the trade, please retry", t)
+		}
+	}
+	return nil
+}
+
+func hasShorts(positions map[string]Amount) bool {
+	for _, v := range positions {
+		if v.IsNegative() {
+			return true
+		}
+	}
+	return false
+}
+
+// closingTrade returns the action and size of the trade to close the position.
+func closingTrade(pos Amount) (grpcoin.TradeAction, Amount) {
+	if pos.IsNegative() {
+		return grpcoin.TradeAction_BUY, ToAmount(pos.F().Neg())
+	}
+	return grpcoin.TradeAction_SELL, pos
+}
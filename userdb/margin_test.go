@@ -0,0 +1,159 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+func TestMarginPolicy_Status(t *testing.T) {
+	m := MarginPolicy{InitialMargin: 0.5, MaintenanceMargin: 0.25}
+	p := Portfolio{
+		CashUSD:         Amount{Units: 3000},
+		ReservedCashUSD: Amount{Units: 100},
+		Positions: map[string]Amount{
+			"BTC": {Units: 2},
+			"ETH": {Units: -10},
+		},
+	}
+	prices := map[string]Amount{"BTC": {Units: 500}, "ETH": {Units: 100}}
+	got, err := m.Status(p, prices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := MarginStatus{
+		Equity:      Amount{Units: 3000}, // 3000 + 2*500 - 10*100
+		MarginUsed:  Amount{Units: 500},  // 1000 * 0.5
+		BuyingPower: Amount{Units: 3000 - 100 - 1500},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+	if _, err := m.Status(p, map[string]Amount{"ETH": {Units: 100}}); err == nil {
+		t.Fatal("expected error for missing price")
+	}
+}
+
+func TestMarginPolicy_BelowMaintenance(t *testing.T) {
+	m := MarginPolicy{InitialMargin: 0.5, MaintenanceMargin: 0.25}
+	p := Portfolio{
+		CashUSD:   Amount{Units: 1500},
+		Positions: map[string]Amount{"ETH": {Units: -10}},
+	}
+	tests := []struct {
+		price int64
+		want  bool
+	}{
+		{price: 100, want: false},
+		{price: 120, want: false}, // 1200*1.25 = 1500
+		{price: 121, want: true},
+	}
+	for _, tt := range tests {
+		got, err := m.BelowMaintenance(p, map[string]Amount{"ETH": {Units: tt.price}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("BelowMaintenance(price=%d) = %v, want %v", tt.price, got, tt.want)
+		}
+	}
+}
+
+func TestMarginPolicy_checkMargin(t *testing.T) {
+	m := MarginPolicy{InitialMargin: 0.5}
+	prices := map[string]Amount{"BTC": {Units: 100}, "ETH": {Units: 100}}
+	trade := func(p Portfolio, action grpcoin.TradeAction, size int64) error {
+		before, err := m.excess(p, prices)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := makeTrade(&p, action, "BTC", &grpcoin.Amount{Units: 100}, &grpcoin.Amount{Units: size},
+			FeeSchedule{}, false, true); err != nil {
+			return err
+		}
+		return m.checkMargin(before, p, prices)
+	}
+
+	// $1000 cash can hold $2000 of shorts at 50% initial margin
+	if err := trade(Portfolio{CashUSD: Amount{Units: 1000}}, grpcoin.TradeAction_SELL, 20); err != nil {
+		t.Fatal(err)
+	}
+	if err := trade(Portfolio{CashUSD: Amount{Units: 1000}}, grpcoin.TradeAction_SELL, 21); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got: %v", err)
+	}
+	// proceeds of short sales cannot be used for buying
+	short := Portfolio{CashUSD: Amount{Units: 3000}, Positions: map[string]Amount{"ETH": {Units: -20}}}
+	if err := trade(short, grpcoin.TradeAction_BUY, 1); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got: %v", err)
+	}
+	// buying back is allowed even if the portfolio stays below the margin
+	// requirement
+	underwater := Portfolio{CashUSD: Amount{Units: 2500}, Positions: map[string]Amount{"BTC": {Units: -20}}}
+	if err := trade(underwater, grpcoin.TradeAction_BUY, 5); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckPrices(t *testing.T) {
+	prices := map[string]Amount{"BTC": {Units: 100}}
+	p := Portfolio{Positions: map[string]Amount{"BTC": {Units: -1}, "ETH": {Units: 2}}}
+	if err := checkPrices(p, prices); err != nil {
+		t.Fatal(err)
+	}
+	// short position opened after the prices were fetched
+	p.Positions["DOGE"] = Amount{Units: -3}
+	if err := checkPrices(p, prices); status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted, got: %v", err)
+	}
+}
+
+func TestLiquidationOrder(t *testing.T) {
+	prices := map[string]Amount{"BTC": {Units: 100}, "ETH": {Units: 10}, "DOGE": {Units: 1}}
+	tests := []struct {
+		name string
+		p    Portfolio
+		want []string
+	}{
+		{
+			name: "enough cash to buy back",
+			p: Portfolio{CashUSD: Amount{Units: 3000}, Positions: map[string]Amount{
+				"BTC": {Units: -20}, "ETH": {Units: -10}, "DOGE": {Units: 5}}},
+			want: []string{"BTC", "ETH"},
+		},
+		{
+			name: "sell longs first",
+			p: Portfolio{CashUSD: Amount{Units: 2000}, Positions: map[string]Amount{
+				"BTC": {Units: -20}, "ETH": {Units: -10}, "DOGE": {Units: 5}}},
+			want: []string{"DOGE", "BTC", "ETH"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LiquidationOrder(tt.p, prices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
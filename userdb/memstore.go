@@ -0,0 +1,683 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+)
+
+// MemStore stores the users in memory, for running locally without the
+// Firestore emulator and in tests. Transactions are serialized.
+type MemStore struct {
+	mu    sync.Mutex
+	users map[string]*memUser
+}
+
+// memUser holds the user record and its related records by their keys. The
+// related records can exist without the user record, like Firestore
+// sub-collections.
+type memUser struct {
+	user       *User
+	trades     map[string]TradeRecord
+	valuations map[ValuationResolution]map[string]ValuationHistory
+	orders     map[string]Order
+	tradeReqs  map[string]TradeRequest
+	alerts     map[string]Alert
+	ledger     map[int]LedgerEntry
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{users: make(map[string]*memUser)}
+}
+
+// get returns the records of the user, creating them if they do not exist.
+func (m *MemStore) get(uid string) *memUser {
+	v, ok := m.users[uid]
+	if !ok {
+		v = &memUser{
+			trades:     make(map[string]TradeRecord),
+			valuations: make(map[ValuationResolution]map[string]ValuationHistory),
+			orders:     make(map[string]Order),
+			tradeReqs:  make(map[string]TradeRequest),
+			alerts:     make(map[string]Alert),
+			ledger:     make(map[int]LedgerEntry),
+		}
+		m.users[uid] = v
+	}
+	return v
+}
+
+func (m *MemStore) RunTx(ctx context.Context, f func(ctx context.Context, tx Tx) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tx := &memTx{m: m}
+	if err := f(ctx, tx); err != nil {
+		return err
+	}
+	for _, w := range tx.writes {
+		w()
+	}
+	return nil
+}
+
+func (m *MemStore) CreateUser(_ context.Context, u User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v := m.get(u.ID)
+	if v.user != nil {
+		return status.Errorf(codes.AlreadyExists, "user %q already exists", u.ID)
+	}
+	u = u.clone()
+	v.user = &u
+	return nil
+}
+
+func (m *MemStore) GetUser(_ context.Context, uid string) (User, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.users[uid]
+	if !ok || v.user == nil {
+		return User{}, false, nil
+	}
+	return v.user.clone(), true, nil
+}
+
+func (m *MemStore) Users(_ context.Context) ([]User, error) {
+	return m.filterUsers(func(User) bool { return true }), nil
+}
+
+func (m *MemStore) UsersWithShorts(_ context.Context) ([]User, error) {
+	return m.filterUsers(func(u User) bool { return u.Portfolio.HasShorts }), nil
+}
+
+// filterUsers returns the users matching f ordered by their ids.
+func (m *MemStore) filterUsers(f func(User) bool) []User {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []User
+	for _, v := range m.users {
+		if v.user != nil && f(*v.user) {
+			out = append(out, v.user.clone())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func (m *MemStore) AddTrade(_ context.Context, uid string, tr TradeRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v := m.get(uid)
+	key := tradeKey(tr)
+	if _, ok := v.trades[key]; ok {
+		return status.Errorf(codes.AlreadyExists, "trade %q already exists", key)
+	}
+	v.trades[key] = tr.clone()
+	return nil
+}
+
+func (m *MemStore) Trades(_ context.Context, uid string) ([]TradeRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.trades(uid), nil
+}
+
+// trades returns the user's trades ordered by date.
+func (m *MemStore) trades(uid string) []TradeRecord {
+	v, ok := m.users[uid]
+	if !ok {
+		return nil
+	}
+	var out []TradeRecord
+	for _, tr := range v.trades {
+		out = append(out, tr.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out
+}
+
+func (m *MemStore) TradeHistory(_ context.Context, uid string, f TradeFilter, pageSize int, pageToken string) ([]TradeRecord, string, error) {
+	var after time.Time
+	var afterKey string
+	if pageToken != "" {
+		var err error
+		if after, afterKey, err = decodePageToken(pageToken); err != nil {
+			return nil, "", err
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []TradeRecord
+	for _, tr := range m.trades(uid) {
+		if f.match(tr) {
+			out = append(out, tr)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Date.Equal(out[j].Date) {
+			return out[i].Date.After(out[j].Date)
+		}
+		return tradeKey(out[i]) < tradeKey(out[j])
+	})
+	if pageToken != "" {
+		i := sort.Search(len(out), func(i int) bool {
+			d := out[i].Date
+			return d.Before(after) || (d.Equal(after) && tradeKey(out[i]) > afterKey)
+		})
+		out = out[i:]
+	}
+	if len(out) <= pageSize {
+		return out, "", nil
+	}
+	out = out[:pageSize]
+	last := out[len(out)-1]
+	return out, encodePageToken(last.Date, tradeKey(last)), nil
+}
+
+// valuationsOf returns the user's valuations of the resolution, creating the
+// map if it does not exist.
+func (v *memUser) valuationsOf(res ValuationResolution) map[string]ValuationHistory {
+	vals, ok := v.valuations[res]
+	if !ok {
+		vals = make(map[string]ValuationHistory)
+		v.valuations[res] = vals
+	}
+	return vals
+}
+
+func (m *MemStore) AddValuation(_ context.Context, uid string, res ValuationResolution, v ValuationHistory) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	vals := m.get(uid).valuationsOf(res)
+	key := canonicalizeValuationHistoryDBKey(v.Date)
+	if _, ok := vals[key]; !ok {
+		vals[key] = v
+	}
+	return nil
+}
+
+func (m *MemStore) Valuations(_ context.Context, uid string, res ValuationResolution, since time.Time) ([]ValuationHistory, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.users[uid]
+	if !ok {
+		return nil, nil
+	}
+	var out []ValuationHistory
+	for _, val := range v.valuations[res] {
+		if since.IsZero() || !val.Date.Before(since) {
+			out = append(out, val)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Date.Before(out[j].Date) })
+	return out, nil
+}
+
+func (m *MemStore) DeleteValuations(_ context.Context, uid string, res ValuationResolution, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.users[uid]
+	if !ok {
+		return nil
+	}
+	vals := v.valuations[res]
+	for k, val := range vals {
+		if val.Date.Before(before) {
+			delete(vals, k)
+		}
+	}
+	return nil
+}
+
+func (m *MemStore) DeleteTradeRequests(_ context.Context, uid string, before time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.users[uid]
+	if !ok {
+		return nil
+	}
+	for k, r := range v.tradeReqs {
+		if r.ExpiresAt.Before(before) {
+			delete(v.tradeReqs, k)
+		}
+	}
+	return nil
+}
+
+func (m *MemStore) GetOrder(_ context.Context, uid, orderID string) (Order, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.users[uid]
+	if !ok {
+		return Order{}, false, nil
+	}
+	o, ok := v.orders[orderID]
+	return o, ok, nil
+}
+
+func (m *MemStore) ListOrders(_ context.Context, uid string, f OrderFilter, pageSize int, pageToken string) ([]Order, string, error) {
+	var after time.Time
+	var afterID string
+	if pageToken != "" {
+		var err error
+		if after, afterID, err = decodePageToken(pageToken); err != nil {
+			return nil, "", err
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Order
+	if v, ok := m.users[uid]; ok {
+		for _, o := range v.orders {
+			if f.match(o) {
+				out = append(out, o)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	if pageToken != "" {
+		i := sort.Search(len(out), func(i int) bool {
+			t := out[i].CreatedAt
+			return t.Before(after) || (t.Equal(after) && out[i].ID > afterID)
+		})
+		out = out[i:]
+	}
+	if len(out) <= pageSize {
+		return out, "", nil
+	}
+	out = out[:pageSize]
+	last := out[len(out)-1]
+	return out, encodePageToken(last.CreatedAt, last.ID), nil
+}
+
+func (m *MemStore) OpenOrders(_ context.Context) ([]Order, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Order
+	for _, v := range m.users {
+		for _, o := range v.orders {
+			if o.Status == grpcoin.OrderStatus_OPEN {
+				out = append(out, o)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (m *MemStore) CreateAlert(_ context.Context, a Alert) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	alerts := m.get(a.UserID).alerts
+	if _, ok := alerts[a.ID]; ok {
+		return status.Errorf(codes.AlreadyExists, "alert %q already exists", a.ID)
+	}
+	alerts[a.ID] = a
+	return nil
+}
+
+func (m *MemStore) ListAlerts(_ context.Context, uid string) ([]Alert, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Alert
+	if v, ok := m.users[uid]; ok {
+		for _, a := range v.alerts {
+			out = append(out, a)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (m *MemStore) ActiveAlerts(_ context.Context) ([]Alert, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Alert
+	for _, v := range m.users {
+		for _, a := range v.alerts {
+			if a.Active {
+				out = append(out, a)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (m *MemStore) DeleteAlert(_ context.Context, uid, alertID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.users[uid]
+	if !ok {
+		return status.Errorf(codes.NotFound, "alert %q not found", alertID)
+	}
+	if _, ok := v.alerts[alertID]; !ok {
+		return status.Errorf(codes.NotFound, "alert %q not found", alertID)
+	}
+	delete(v.alerts, alertID)
+	return nil
+}
+
+func (m *MemStore) Ledger(_ context.Context, uid string) ([]LedgerEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ledger(uid), nil
+}
+
+func (m *MemStore) ledger(uid string) []LedgerEntry {
+	v, ok := m.users[uid]
+	if !ok {
+		return nil
+	}
+	out := make([]LedgerEntry, 0, len(v.ledger))
+	for _, e := range v.ledger {
+		out = append(out, e.clone())
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Seq < out[j].Seq })
+	return out
+}
+
+// memTx implements Tx while holding the lock of the MemStore. The writes are
+// applied when the transaction is committed.
+type memTx struct {
+	m      *MemStore
+	writes []func()
+}
+
+func (t *memTx) GetUser(uid string) (User, error) {
+	v, ok := t.m.users[uid]
+	if !ok || v.user == nil {
+		return User{}, status.Errorf(codes.NotFound, "user %q not found", uid)
+	}
+	return v.user.clone(), nil
+}
+
+func (t *memTx) GetOrder(uid, orderID string) (Order, error) {
+	v, ok := t.m.users[uid]
+	if !ok {
+		return Order{}, status.Errorf(codes.NotFound, "order %q not found", orderID)
+	}
+	o, ok := v.orders[orderID]
+	if !ok {
+		return Order{}, status.Errorf(codes.NotFound, "order %q not found", orderID)
+	}
+	return o, nil
+}
+
+func (t *memTx) GetAlert(uid, alertID string) (Alert, error) {
+	v, ok := t.m.users[uid]
+	if !ok {
+		return Alert{}, status.Errorf(codes.NotFound, "alert %q not found", alertID)
+	}
+	a, ok := v.alerts[alertID]
+	if !ok {
+		return Alert{}, status.Errorf(codes.NotFound, "alert %q not found", alertID)
+	}
+	return a, nil
+}
+
+func (t *memTx) GetTradeRequest(uid, clientOrderID string) (TradeRequest, bool, error) {
+	v, ok := t.m.users[uid]
+	if !ok {
+		return TradeRequest{}, false, nil
+	}
+	r, ok := v.tradeReqs[clientOrderID]
+	if !ok {
+		return TradeRequest{}, false, nil
+	}
+	return r.clone(), true, nil
+}
+
+func (t *memTx) Trades(uid string) ([]TradeRecord, error) { return t.m.trades(uid), nil }
+
+func (t *memTx) Ledger(uid string) ([]LedgerEntry, error) { return t.m.ledger(uid), nil }
+
+func (t *memTx) SetUser(u User) error {
+	u = u.clone()
+	t.writes = append(t.writes, func() { t.m.get(u.ID).user = &u })
+	return nil
+}
+
+func (t *memTx) CreateOrder(o Order) error {
+	if v, ok := t.m.users[o.UserID]; ok {
+		if _, ok := v.orders[o.ID]; ok {
+			return status.Errorf(codes.AlreadyExists, "order %q already exists", o.ID)
+		}
+	}
+	return t.SetOrder(o)
+}
+
+func (t *memTx) SetOrder(o Order) error {
+	t.writes = append(t.writes, func() { t.m.get(o.UserID).orders[o.ID] = o })
+	return nil
+}
+
+func (t *memTx) SetAlert(a Alert) error {
+	t.writes = append(t.writes, func() { t.m.get(a.UserID).alerts[a.ID] = a })
+	return nil
+}
+
+func (t *memTx) SetTradeRequest(uid, clientOrderID string, v TradeRequest) error {
+	v = v.clone()
+	t.writes = append(t.writes, func() { t.m.get(uid).tradeReqs[clientOrderID] = v })
+	return nil
+}
+
+func (t *memTx) AddLedgerEntry(uid string, e LedgerEntry) error {
+	if v, ok := t.m.users[uid]; ok {
+		if _, ok := v.ledger[e.Seq]; ok {
+			return status.Errorf(codes.AlreadyExists, "ledger entry %d already exists", e.Seq)
+		}
+	}
+	e = e.clone()
+	t.writes = append(t.writes, func() { t.m.get(uid).ledger[e.Seq] = e })
+	return nil
+}
+
+// clone returns a copy of the user that does not share the maps of the
+// portfolio, as the records read from a database would not.
+func (u User) clone() User {
+	u.Portfolio = u.Portfolio.clone()
+	return u
+}
+
+func (p Portfolio) clone() Portfolio {
+	p.Positions = cloneAmounts(p.Positions)
+	p.ReservedPositions = cloneAmounts(p.ReservedPositions)
+	p.CostBasis = cloneAmounts(p.CostBasis)
+	return p
+}
+
+func (tr TradeRecord) clone() TradeRecord {
+	if tr.TriggerPrice != nil {
+		v := *tr.TriggerPrice
+		tr.TriggerPrice = &v
+	}
+	return tr
+}
+
+func (v TradeRequest) clone() TradeRequest {
+	v.Trade = v.Trade.clone()
+	v.Portfolio = v.Portfolio.clone()
+	return v
+}
+
+func (e LedgerEntry) clone() LedgerEntry {
+	if e.Trade != nil {
+		tr := e.Trade.clone()
+		e.Trade = &tr
+	}
+	e.Positions = cloneAmounts(e.Positions)
+	e.ReservedPositions = cloneAmounts(e.ReservedPositions)
+	return e
+}
+
+func cloneAmounts(m map[string]Amount) map[string]Amount {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]Amount, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// Import loads the users and their records from the data exported from
+// Firestore (see firestoreutil.ImportData for the format).
+func (m *MemStore) Import(r io.Reader) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return firestoreutil.ReadData(r, func(path string, data map[string]interface{}) error {
+		parts := strings.Split(path, "/")
+		if len(parts) != 2 && len(parts) != 4 || parts[0] != fsUserCol {
+			return fmt.Errorf("unsupported document path %q", path)
+		}
+		v := m.get(parts[1])
+		if len(parts) == 2 {
+			var u User
+			if err := decodeDoc(data, &u); err != nil {
+				return fmt.Errorf("failed to decode %s: %w", path, err)
+			}
+			v.user = &u
+			return nil
+		}
+		var err error
+		switch key := parts[3]; parts[2] {
+		case fsTradesCol:
+			var tr TradeRecord
+			err = decodeDoc(data, &tr)
+			v.trades[key] = tr
+		case fsValueHistCol, fsDailyValCol, fsWeeklyValCol:
+			var val ValuationHistory
+			err = decodeDoc(data, &val)
+			for res, col := range fsValuationCols {
+				if col == parts[2] {
+					v.valuationsOf(res)[key] = val
+				}
+			}
+		case fsOrderBookCol:
+			var o Order
+			err = decodeDoc(data, &o)
+			v.orders[key] = o
+		case fsTradeReqCol:
+			var tr TradeRequest
+			err = decodeDoc(data, &tr)
+			v.tradeReqs[key] = tr
+		case fsAlertsCol:
+			var a Alert
+			err = decodeDoc(data, &a)
+			v.alerts[key] = a
+		case fsLedgerCol:
+			var e LedgerEntry
+			err = decodeDoc(data, &e)
+			v.ledger[e.Seq] = e
+		default:
+			return fmt.Errorf("unsupported document path %q", path)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// decodeDoc sets the struct pointed by v from the Firestore document data,
+// matching the fields by their firestore tags like DocumentSnapshot.DataTo.
+func decodeDoc(data map[string]interface{}, v interface{}) error {
+	return decodeValue(reflect.ValueOf(v).Elem(), data)
+}
+
+func decodeValue(dst reflect.Value, src interface{}) error {
+	if src == nil {
+		return nil
+	}
+	if t, ok := src.(time.Time); ok && dst.Type() == reflect.TypeOf(t) {
+		dst.Set(reflect.ValueOf(t.UTC()))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		v := reflect.New(dst.Type().Elem())
+		if err := decodeValue(v.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(v)
+	case reflect.Struct:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot decode %T into %s", src, dst.Type())
+		}
+		for i := 0; i < dst.NumField(); i++ {
+			f := dst.Type().Field(i)
+			name := f.Name
+			if tag := strings.Split(f.Tag.Get("firestore"), ",")[0]; tag != "" {
+				name = tag
+			}
+			if err := decodeValue(dst.Field(i), m[name]); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	case reflect.Map:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot decode %T into %s", src, dst.Type())
+		}
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), len(m)))
+		for k, v := range m {
+			e := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeValue(e, v); err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			dst.SetMapIndex(reflect.ValueOf(k), e)
+		}
+	default:
+		v := reflect.ValueOf(src)
+		if v.Kind() == reflect.Map || v.Kind() == reflect.Struct ||
+			(dst.Kind() == reflect.String) != (v.Kind() == reflect.String) || !v.Type().ConvertibleTo(dst.Type()) {
+			return fmt.Errorf("cannot decode %T into %s", src, dst.Type())
+		}
+		dst.Set(v.Convert(dst.Type()))
+	}
+	return nil
+}
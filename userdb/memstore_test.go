@@ -0,0 +1,93 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+func TestMemStore_Import(t *testing.T) {
+	ctx := context.Background()
+	f, err := os.Open("../testdata/local.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	m := NewMemStore()
+	if err := m.Import(f); err != nil {
+		t.Fatal(err)
+	}
+	users, err := m.Users(ctx)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(users) == 0 {
+		t.Fatal("no users imported")
+	}
+	u := users[0]
+	if u.ID == "" || u.DisplayName == "" || u.CreatedAt.IsZero() || u.Portfolio.CashUSD.IsZero() {
+		t.Fatalf("user not imported correctly: %#v", u)
+	}
+	for _, u := range users {
+		trades, err := m.Trades(ctx, u.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tr := range trades {
+			if tr.Date.IsZero() || tr.Ticker == "" || tr.Size.IsZero() || tr.Price.IsZero() {
+				t.Fatalf("trade not imported correctly: %#v", tr)
+			}
+		}
+	}
+}
+
+func Test_decodeDoc(t *testing.T) {
+	ts := time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC)
+	var got Order
+	err := decodeDoc(map[string]interface{}{
+		"id":        "o1",
+		"uid":       "u1",
+		"type":      int64(grpcoin.OrderType_STOP),
+		"size":      map[string]interface{}{"Units": int64(1), "Nanos": int64(5)},
+		"tif":       int64(grpcoin.TimeInForce_GOOD_TILL_CANCELLED),
+		"createdAt": ts,
+		"ocoID":     nil,
+	}, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Order{
+		ID:          "o1",
+		UserID:      "u1",
+		Type:        grpcoin.OrderType_STOP,
+		Size:        Amount{Units: 1, Nanos: 5},
+		TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+		CreatedAt:   ts,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var tr TradeRecord
+	if err := decodeDoc(map[string]interface{}{"ticker": int64(1)}, &tr); err == nil {
+		t.Fatal("expected error decoding number into string")
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+// Order represents an order stored on the server until it is executed by
+// the order matcher, or closed.
+type Order struct {
+	ID          string              `firestore:"id"`
+	UserID      string              `firestore:"uid"`
+	Type        grpcoin.OrderType   `firestore:"type"`
+	Ticker      string              `firestore:"ticker"`
+	Action      grpcoin.TradeAction `firestore:"action"`
+	Size        Amount              `firestore:"size"`
+	LimitPrice  Amount              `firestore:"limitPrice"`
+	TimeInForce grpcoin.TimeInForce `firestore:"tif"`
+
+	// TriggerPrice is the price that executes STOP and TAKE_PROFIT orders.
+	TriggerPrice Amount `firestore:"triggerPrice"`
+
+	// StopLossPrice and TakeProfitPrice are the prices of the exit orders
+	// placed when the order is filled. Zero if the order has no bracket.
+	StopLossPrice   Amount `firestore:"stopLossPrice"`
+	TakeProfitPrice Amount `firestore:"takeProfitPrice"`
+
+	// ParentID is the order that placed this order through its bracket.
+	ParentID string `firestore:"parentID,omitempty"`
+	// OCOID is the order cancelled when this order is filled.
+	OCOID string `firestore:"ocoID,omitempty"`
+
+	Status       grpcoin.OrderStatus `firestore:"status"`
+	StatusReason string              `firestore:"statusReason,omitempty"`
+
+	// Reserved is the cash (for BUY orders) or the position (for SELL orders)
+	// held in the portfolio while the order is open.
+	Reserved Amount `firestore:"reserved"`
+
+	CreatedAt     time.Time `firestore:"createdAt"`
+	ExpiresAt     time.Time `firestore:"expiresAt"` // zero if the order does not expire
+	FilledAt      time.Time `firestore:"filledAt"`
+	ExecutedPrice Amount    `firestore:"executedPrice"`
+}
+
+// Executable reports whether the order can be filled at the market price.
+func (o Order) Executable(price *grpcoin.Amount) bool {
+	p := toDecimal(price)
+	buy := o.Action == grpcoin.TradeAction_BUY
+	switch o.Type {
+	case grpcoin.OrderType_LIMIT:
+		if buy {
+			return p.LessThanOrEqual(o.LimitPrice.F())
+		}
+		return p.GreaterThanOrEqual(o.LimitPrice.F())
+	case grpcoin.OrderType_STOP:
+		if buy {
+			return p.GreaterThanOrEqual(o.TriggerPrice.F())
+		}
+		return p.LessThanOrEqual(o.TriggerPrice.F())
+	case grpcoin.OrderType_TAKE_PROFIT:
+		if buy {
+			return p.LessThanOrEqual(o.TriggerPrice.F())
+		}
+		return p.GreaterThanOrEqual(o.TriggerPrice.F())
+	}
+	return false
+}
+
+// reservation returns the amount to be reserved in the portfolio for the
+// order. BUY orders reserve the cash needed at the order price with the
+// slippage and fee of the fee schedule, which may not be enough if a
+// triggered order is executed above its trigger price.
+func (o Order) reservation(fees FeeSchedule) Amount {
+	if o.Action != grpcoin.TradeAction_BUY {
+		return o.Size
+	}
+	price := o.LimitPrice
+	if o.IsTriggered() {
+		price = o.TriggerPrice
+	}
+	p := fees.executionPrice(o.Action, price.F(), o.Size.F(), o.IsMaker())
+	cost := o.Size.F().Mul(p)
+	return ToAmount(cost.Add(fees.fee(cost, o.IsMaker())))
+}
+
+// IsTriggered reports whether the order executes at the market price once
+// its trigger price is reached.
+func (o Order) IsTriggered() bool {
+	return o.Type == grpcoin.OrderType_STOP || o.Type == grpcoin.OrderType_TAKE_PROFIT
+}
+
+// IsMaker reports whether the order rests on the book until the market
+// reaches its price (i.e. a limit order), which is charged the maker fee
+// without slippage. It is still filled at the triggering quote price.
+func (o Order) IsMaker() bool { return o.Type == grpcoin.OrderType_LIMIT }
+
+// BracketOrders returns the exit orders to be placed when the order is
+// filled, or nil if the order does not have a bracket. Since only one of the
+// exit orders can be filled, only the first one holds the reservation.
+func BracketOrders(o Order, now time.Time) []Order {
+	var out []Order
+	exit := func(t grpcoin.OrderType, id string, price Amount) Order {
+		return Order{
+			ID:           id,
+			UserID:       o.UserID,
+			Type:         t,
+			Ticker:       o.Ticker,
+			Action:       grpcoin.TradeAction_SELL,
+			Size:         o.Size,
+			TriggerPrice: price,
+			TimeInForce:  grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+			ParentID:     o.ID,
+			Status:       grpcoin.OrderStatus_OPEN,
+			CreatedAt:    now,
+		}
+	}
+	slID, tpID := o.ID+"-sl", o.ID+"-tp"
+	if !o.StopLossPrice.IsZero() {
+		out = append(out, exit(grpcoin.OrderType_STOP, slID, o.StopLossPrice))
+	}
+	if !o.TakeProfitPrice.IsZero() {
+		out = append(out, exit(grpcoin.OrderType_TAKE_PROFIT, tpID, o.TakeProfitPrice))
+	}
+	if len(out) > 0 {
+		out[0].Reserved = o.Size
+	}
+	if len(out) == 2 {
+		out[0].OCOID, out[1].OCOID = tpID, slID
+	}
+	return out
+}
+
+// Expired reports whether the order has passed its expiration time.
+func (o Order) Expired(now time.Time) bool {
+	return !o.ExpiresAt.IsZero() && !now.Before(o.ExpiresAt)
+}
+
+// CreateOrder stores a new order, and reserves the cash or position needed
+// to execute it in the same transaction. Fails with InvalidArgument if the
+// user does not have enough cash or position available.
+func (u *UserDB) CreateOrder(ctx context.Context, o Order) (Order, error) {
+	ctx, s := u.T.Start(ctx, "create order")
+	defer s.End()
+	o.Reserved = o.reservation(u.Fees)
+	err := u.DB.RunTx(ctx, func(ctx context.Context, tx Tx) error {
+		user, err := tx.GetUser(o.UserID)
+		if err != nil {
+			return err
+		}
+		before := user.Portfolio.clone()
+		if err := user.Portfolio.reserve(o); err != nil {
+			return err
+		}
+		if err := appendLedger(tx, &user, before, LedgerEntry{
+			Date: time.Now().UTC(), Type: LedgerOrder, OrderID: o.ID}); err != nil {
+			return err
+		}
+		if err := tx.SetUser(user); err != nil {
+			return err
+		}
+		return tx.CreateOrder(o)
+	})
+	if err == nil {
+		u.portfolioChanged(ctx, o.UserID)
+	}
+	return o, err
+}
+
+// GetOrder retrieves user's order with the specified id.
+func (u *UserDB) GetOrder(ctx context.Context, uid, orderID string) (Order, bool, error) {
+	return u.DB.GetOrder(ctx, uid, orderID)
+}
+
+// OrderFilter narrows down the orders returned by ListOrders. Zero values
+// match all orders.
+type OrderFilter struct {
+	Status grpcoin.OrderStatus
+	Ticker string
+}
+
+// match reports whether the order matches the filter.
+func (f OrderFilter) match(o Order) bool {
+	return (f.Status == grpcoin.OrderStatus_UNDEFINED_ORDER_STATUS || o.Status == f.Status) &&
+		(f.Ticker == "" || o.Ticker == f.Ticker)
+}
+
+// ListOrders returns a page of user's orders (most recent first) starting
+// after the pageToken returned with the previous page, or from the beginning
+// if pageToken is empty. The returned page token is empty on the last page.
+func (u *UserDB) ListOrders(ctx context.Context, uid string, f OrderFilter, pageSize int, pageToken string) ([]Order, string, error) {
+	ctx, s := u.T.Start(ctx, "list orders")
+	defer s.End()
+	out, next, err := u.DB.ListOrders(ctx, uid, f, pageSize, pageToken)
+	if err != nil {
+		s.RecordError(err)
+	}
+	return out, next, err
+}
+
+// OpenOrders returns open orders of all users.
+func (u *UserDB) OpenOrders(ctx context.Context) ([]Order, error) {
+	ctx, s := u.T.Start(ctx, "open orders")
+	defer s.End()
+	out, err := u.DB.OpenOrders(ctx)
+	if err != nil {
+		s.RecordError(err)
+	}
+	return out, err
+}
+
+// readOpenOrder reads the order in tx, and fails with FailedPrecondition if
+// the order is no longer open.
+func readOpenOrder(tx Tx, uid, orderID string) (Order, error) {
+	o, err := tx.GetOrder(uid, orderID)
+	if status.Code(err) == codes.NotFound {
+		return Order{}, status.Errorf(codes.NotFound, "order %q not found", orderID)
+	} else if err != nil {
+		return Order{}, err
+	}
+	if o.Status != grpcoin.OrderStatus_OPEN {
+		return o, status.Errorf(codes.FailedPrecondition, "order %q is not open (status: %s)", o.ID, o.Status)
+	}
+	return o, nil
+}
+
+// readOCOOrder reads the OCO order of o in tx, and returns nil if o does not
+// have an OCO order, or it is no longer open.
+func readOCOOrder(tx Tx, o Order) (*Order, error) {
+	if o.OCOID == "" {
+		return nil, nil
+	}
+	v, err := readOpenOrder(tx, o.UserID, o.OCOID)
+	if c := status.Code(err); c == codes.FailedPrecondition || c == codes.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// FillOrder executes the open order at the quote price through the same
+// transaction that marks the order as filled, so that an order is not
+// executed more than once. The same transaction also places the order's
+// bracket orders, cancels its OCO order and updates the reservations.
+func (u *UserDB) FillOrder(ctx context.Context, o Order, quote *grpcoin.Amount) (Order, Portfolio, error) {
+	tr := TradeRecord{
+		Ticker:  o.Ticker,
+		Action:  o.Action,
+		Size:    o.Size,
+		Price:   ToAmount(toDecimal(quote)),
+		OrderID: o.ID,
+	}
+	if o.IsTriggered() {
+		tp := o.TriggerPrice
+		tr.TriggerPrice = &tp
+	}
+	// the order is written before the trade is made, so its executed price
+	// is computed the same way the trade computes it
+	executedPrice := ToAmount(u.Fees.executionPrice(o.Action, toDecimal(quote), o.Size.F(), o.IsMaker()))
+	var filled Order
+	executed, p, err := u.trade(ctx, o.UserID, tr, o.IsMaker(), func(tx Tx) (func(*Portfolio) error, error) {
+		v, err := readOpenOrder(tx, o.UserID, o.ID)
+		if err != nil {
+			return nil, err
+		}
+		oco, err := readOCOOrder(tx, v)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now().UTC()
+		v.Status = grpcoin.OrderStatus_FILLED
+		v.FilledAt = now
+		v.ExecutedPrice = executedPrice
+		filled = v
+		return func(p *Portfolio) error {
+			p.release(v)
+			if err := tx.SetOrder(v); err != nil {
+				return err
+			}
+			if oco != nil {
+				p.release(*oco)
+				oco.Status = grpcoin.OrderStatus_CANCELLED
+				oco.StatusReason = fmt.Sprintf("order %s is filled", v.ID)
+				if err := tx.SetOrder(*oco); err != nil {
+					return err
+				}
+			}
+			// exit orders hold the position bought by this trade, so they
+			// cannot be checked against the position available yet.
+			for _, b := range BracketOrders(v, now) {
+				p.hold(b, b.Reserved.F())
+				if err := tx.CreateOrder(b); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	})
+	if err == nil {
+		filled.ExecutedPrice = executed.Price
+	}
+	return filled, p, err
+}
+
+// CloseOrder changes the status of an open order to the specified status
+// (e.g. cancelled, expired) and releases its reservation. If the order has an
+// open OCO order, the reservation is handed over to it instead. Fails with
+// FailedPrecondition if the order is not open anymore.
+func (u *UserDB) CloseOrder(ctx context.Context, uid, orderID string, st grpcoin.OrderStatus, reason string) (Order, error) {
+	ctx, s := u.T.Start(ctx, "close order")
+	defer s.End()
+	var out Order
+	err := u.DB.RunTx(ctx, func(ctx context.Context, tx Tx) error {
+		o, err := readOpenOrder(tx, uid, orderID)
+		if err != nil {
+			return err
+		}
+		oco, err := readOCOOrder(tx, o)
+		if err != nil {
+			return err
+		}
+		user, err := tx.GetUser(uid)
+		if err != nil {
+			return err
+		}
+		if oco != nil {
+			oco.Reserved = ToAmount(oco.Reserved.F().Add(o.Reserved.F()))
+			oco.OCOID = ""
+			if err := tx.SetOrder(*oco); err != nil {
+				return err
+			}
+		} else {
+			before := user.Portfolio.clone()
+			user.Portfolio.release(o)
+			if err := appendLedger(tx, &user, before, LedgerEntry{
+				Date: time.Now().UTC(), Type: LedgerOrder, OrderID: o.ID}); err != nil {
+				return err
+			}
+			if err := tx.SetUser(user); err != nil {
+				return err
+			}
+		}
+		o.Status = st
+		o.StatusReason = reason
+		out = o
+		return tx.SetOrder(o)
+	})
+	if err == nil {
+		u.portfolioChanged(ctx, uid)
+	}
+	return out, err
+}
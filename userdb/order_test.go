@@ -0,0 +1,580 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+	"github.com/grpcoin/grpcoin/testutil"
+	"github.com/grpcoin/grpcoin/tradecounters"
+)
+
+func TestOrder_Executable(t *testing.T) {
+	tests := []struct {
+		name  string
+		o     Order
+		price *grpcoin.Amount
+		want  bool
+	}{
+		{name: "buy limit above price",
+			o:     Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_BUY, LimitPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 99, Nanos: 999_999_999},
+			want:  true},
+		{name: "buy limit at price",
+			o:     Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_BUY, LimitPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 100},
+			want:  true},
+		{name: "buy limit below price",
+			o:     Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_BUY, LimitPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 100, Nanos: 1},
+			want:  false},
+		{name: "sell limit below price",
+			o:     Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_SELL, LimitPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 100, Nanos: 1},
+			want:  true},
+		{name: "sell limit above price",
+			o:     Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_SELL, LimitPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 99},
+			want:  false},
+		{name: "sell stop above price",
+			o:     Order{Type: grpcoin.OrderType_STOP, Action: grpcoin.TradeAction_SELL, TriggerPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 99},
+			want:  true},
+		{name: "sell stop below price",
+			o:     Order{Type: grpcoin.OrderType_STOP, Action: grpcoin.TradeAction_SELL, TriggerPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 101},
+			want:  false},
+		{name: "buy stop below price",
+			o:     Order{Type: grpcoin.OrderType_STOP, Action: grpcoin.TradeAction_BUY, TriggerPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 101},
+			want:  true},
+		{name: "sell take profit below price",
+			o:     Order{Type: grpcoin.OrderType_TAKE_PROFIT, Action: grpcoin.TradeAction_SELL, TriggerPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 101},
+			want:  true},
+		{name: "sell take profit above price",
+			o:     Order{Type: grpcoin.OrderType_TAKE_PROFIT, Action: grpcoin.TradeAction_SELL, TriggerPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 99},
+			want:  false},
+		{name: "buy take profit above price",
+			o:     Order{Type: grpcoin.OrderType_TAKE_PROFIT, Action: grpcoin.TradeAction_BUY, TriggerPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 99},
+			want:  true},
+		{name: "unknown type",
+			o:     Order{Action: grpcoin.TradeAction_BUY, LimitPrice: Amount{Units: 100}},
+			price: &grpcoin.Amount{Units: 1},
+			want:  false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.Executable(tt.price); got != tt.want {
+				t.Errorf("Executable(%v) = %v, want %v", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrder_Expired(t *testing.T) {
+	now := time.Date(2050, 1, 1, 10, 0, 0, 0, time.UTC)
+	if (Order{}).Expired(now) {
+		t.Fatal("order without expiration should not expire")
+	}
+	if (Order{ExpiresAt: now.Add(time.Second)}).Expired(now) {
+		t.Fatal("order should not be expired yet")
+	}
+	if !(Order{ExpiresAt: now}).Expired(now) {
+		t.Fatal("order should be expired")
+	}
+}
+
+func TestBracketOrders(t *testing.T) {
+	now := time.Date(2050, 1, 1, 10, 0, 0, 0, time.UTC)
+	o := Order{ID: "x", UserID: "u", Type: grpcoin.OrderType_LIMIT, Ticker: "BTC",
+		Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 2}, LimitPrice: Amount{Units: 100}}
+	if got := BracketOrders(o, now); got != nil {
+		t.Fatalf("expected no bracket orders, got: %#v", got)
+	}
+
+	o.StopLossPrice, o.TakeProfitPrice = Amount{Units: 90}, Amount{Units: 120}
+	exit := Order{UserID: "u", Ticker: "BTC", Action: grpcoin.TradeAction_SELL, Size: Amount{Units: 2},
+		TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED, ParentID: "x",
+		Status: grpcoin.OrderStatus_OPEN, CreatedAt: now}
+	sl, tp := exit, exit
+	sl.ID, sl.Type, sl.TriggerPrice, sl.OCOID = "x-sl", grpcoin.OrderType_STOP, Amount{Units: 90}, "x-tp"
+	sl.Reserved = Amount{Units: 2}
+	tp.ID, tp.Type, tp.TriggerPrice, tp.OCOID = "x-tp", grpcoin.OrderType_TAKE_PROFIT, Amount{Units: 120}, "x-sl"
+	if diff := cmp.Diff([]Order{sl, tp}, BracketOrders(o, now)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	o.TakeProfitPrice = Amount{}
+	sl.OCOID = ""
+	if diff := cmp.Diff([]Order{sl}, BracketOrders(o, now)); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestUserDB_FillOrder(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+
+		o := Order{
+			ID:          "order1",
+			UserID:      tu.DBKey(),
+			Type:        grpcoin.OrderType_LIMIT,
+			Ticker:      "BTC",
+			Action:      grpcoin.TradeAction_BUY,
+			Size:        Amount{Units: 2},
+			LimitPrice:  Amount{Units: 1000},
+			TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+			Status:      grpcoin.OrderStatus_OPEN,
+			CreatedAt:   time.Now().UTC(),
+		}
+		if _, err := udb.CreateOrder(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+		open, err := udb.OpenOrders(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(open) != 1 || open[0].ID != o.ID {
+			t.Fatalf("expected 1 open order, got: %#v", open)
+		}
+
+		filled, p, err := udb.FillOrder(ctx, o, &grpcoin.Amount{Units: 900})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filled.Status != grpcoin.OrderStatus_FILLED {
+			t.Fatalf("wrong order status: %v", filled.Status)
+		}
+		if diff := cmp.Diff(Portfolio{
+			CashUSD:   Amount{Units: 98_200},
+			Positions: map[string]Amount{"BTC": {Units: 2}},
+			CostBasis: map[string]Amount{"BTC": {Units: 900}},
+		}, p); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// cannot fill twice
+		if _, _, err := udb.FillOrder(ctx, o, &grpcoin.Amount{Units: 900}); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition, got: %v", err)
+		}
+		if _, err := udb.CloseOrder(ctx, o.UserID, o.ID, grpcoin.OrderStatus_CANCELLED, ""); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition, got: %v", err)
+		}
+
+		got, ok, err := udb.GetOrder(ctx, o.UserID, o.ID)
+		if err != nil {
+			t.Fatal(err)
+		} else if !ok {
+			t.Fatal("order not found")
+		}
+		if diff := cmp.Diff(filled, got, cmpopts.EquateApproxTime(time.Millisecond)); diff != "" {
+			t.Fatal(diff)
+		}
+
+		trades, _, err := udb.UserTrades(ctx, tu.DBKey(), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedTrades := []TradeRecord{
+			{Ticker: "BTC", Action: grpcoin.TradeAction_BUY, Size: Amount{2, 0}, Price: Amount{900, 0}, OrderID: "order1"},
+		}
+		if diff := cmp.Diff(expectedTrades, trades, cmpopts.IgnoreFields(TradeRecord{}, "Date")); diff != "" {
+			t.Fatal(diff)
+		}
+
+		open, err = udb.OpenOrders(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(open) != 0 {
+			t.Fatalf("expected no open orders, got: %#v", open)
+		}
+	})
+}
+
+func TestOrder_reservation(t *testing.T) {
+	tests := []struct {
+		name string
+		o    Order
+		fees FeeSchedule
+		want Amount
+	}{
+		{
+			name: "sell reserves the position",
+			o:    Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_SELL, Size: Amount{Units: 2}, LimitPrice: Amount{Units: 1000}},
+			fees: FeeSchedule{MakerBps: 10},
+			want: Amount{Units: 2},
+		},
+		{
+			name: "limit buy without fees",
+			o:    Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 2}, LimitPrice: Amount{Units: 1000}},
+			want: Amount{Units: 2000},
+		},
+		{
+			name: "limit buy with maker fee",
+			o:    Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 2}, LimitPrice: Amount{Units: 1000}},
+			fees: FeeSchedule{MakerBps: 10, TakerBps: 20, SlippageBps: 10},
+			want: Amount{Units: 2002},
+		},
+		{
+			name: "limit buy with min fee",
+			o:    Order{Type: grpcoin.OrderType_LIMIT, Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 2}, LimitPrice: Amount{Units: 1000}},
+			fees: FeeSchedule{MakerBps: 10, MinFeeUSD: 5},
+			want: Amount{Units: 2005},
+		},
+		{
+			name: "stop buy with slippage and taker fee",
+			o:    Order{Type: grpcoin.OrderType_STOP, Action: grpcoin.TradeAction_BUY, Size: Amount{Units: 10}, TriggerPrice: Amount{Units: 1000}},
+			fees: FeeSchedule{MakerBps: 10, TakerBps: 20, SlippageBps: 10},
+			// 10bps slippage on $10,000 executes at 1001, plus 20bps fee
+			want: Amount{Units: 10_030, Nanos: 20_000_000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.reservation(tt.fees); got != tt.want {
+				t.Fatalf("reservation()=%v, expected=%v", got.F(), tt.want.F())
+			}
+		})
+	}
+}
+
+func TestUserDB_FillOrder_fees(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{},
+			Fees:         FeeSchedule{MakerBps: 10, TakerBps: 20, MinFeeUSD: 100}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+		order := func(id string, size Amount) Order {
+			return Order{
+				ID:          id,
+				UserID:      tu.DBKey(),
+				Type:        grpcoin.OrderType_LIMIT,
+				Ticker:      "BTC",
+				Action:      grpcoin.TradeAction_BUY,
+				Size:        size,
+				LimitPrice:  Amount{Units: 1000},
+				TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+				Status:      grpcoin.OrderStatus_OPEN,
+				CreatedAt:   time.Now().UTC(),
+			}
+		}
+
+		// all cash without the fee
+		if _, err := udb.CreateOrder(ctx, order("order1", Amount{Units: 100})); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for order without cash for the fee, got: %v", err)
+		}
+		// all cash with the min fee: 99.9 * 1000 + 100
+		o, err := udb.CreateOrder(ctx, order("order2", Amount{Units: 99, Nanos: 900_000_000}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.Reserved != (Amount{Units: 100_000}) {
+			t.Fatalf("wrong reservation: %v", o.Reserved.F())
+		}
+		_, p, err := udb.FillOrder(ctx, o, &grpcoin.Amount{Units: 1000})
+		if err != nil {
+			t.Fatalf("order reserving all cash failed to fill: %v", err)
+		}
+		if !p.CashUSD.IsZero() || !p.ReservedCashUSD.IsZero() {
+			t.Fatalf("expected no cash left, got: %#v", p)
+		}
+	})
+}
+
+func TestUserDB_FillOrder_executedPrice(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{},
+			Fees:         FeeSchedule{SlippageBps: 10}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+		o, err := udb.CreateOrder(ctx, Order{
+			ID:           "stop",
+			UserID:       tu.DBKey(),
+			Type:         grpcoin.OrderType_STOP,
+			Ticker:       "BTC",
+			Action:       grpcoin.TradeAction_BUY,
+			Size:         Amount{Units: 10},
+			TriggerPrice: Amount{Units: 1000},
+			TimeInForce:  grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+			Status:       grpcoin.OrderStatus_OPEN,
+			CreatedAt:    time.Now().UTC(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		filled, _, err := udb.FillOrder(ctx, o, &grpcoin.Amount{Units: 1000})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 10bps of slippage for $10,000
+		want := Amount{Units: 1001}
+		if filled.ExecutedPrice != want {
+			t.Fatalf("wrong executed price of the filled order: %v", filled.ExecutedPrice.F())
+		}
+		got, _, err := udb.GetOrder(ctx, tu.DBKey(), o.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ExecutedPrice != want {
+			t.Fatalf("wrong executed price of the stored order: %v", got.ExecutedPrice.F())
+		}
+		trades, _, err := udb.TradeHistory(ctx, tu.DBKey(), TradeFilter{}, 10, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 1 || trades[0].Price != want {
+			t.Fatalf("order and trade prices differ: %#v", trades)
+		}
+	})
+}
+
+func TestUserDB_FillOrder_bracket(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+		o := Order{
+			ID:              "entry",
+			UserID:          tu.DBKey(),
+			Type:            grpcoin.OrderType_LIMIT,
+			Ticker:          "BTC",
+			Action:          grpcoin.TradeAction_BUY,
+			Size:            Amount{Units: 1},
+			LimitPrice:      Amount{Units: 1000},
+			StopLossPrice:   Amount{Units: 900},
+			TakeProfitPrice: Amount{Units: 1200},
+			TimeInForce:     grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+			Status:          grpcoin.OrderStatus_OPEN,
+			CreatedAt:       time.Now().UTC(),
+		}
+		if _, err := udb.CreateOrder(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+		filled, _, err := udb.FillOrder(ctx, o, &grpcoin.Amount{Units: 1000})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exits := BracketOrders(filled, filled.FilledAt)
+		open, err := udb.OpenOrders(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(open) != len(exits) {
+			t.Fatalf("expected %d open exit orders, got: %#v", len(exits), open)
+		}
+
+		// stop-loss triggers, take-profit gets cancelled
+		sl := exits[0]
+		if _, p, err := udb.FillOrder(ctx, sl, &grpcoin.Amount{Units: 850}); err != nil {
+			t.Fatal(err)
+		} else if _, ok := p.Positions["BTC"]; ok {
+			t.Fatalf("position should be closed: %#v", p)
+		}
+		tp, _, err := udb.GetOrder(ctx, tu.DBKey(), sl.OCOID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tp.Status != grpcoin.OrderStatus_CANCELLED {
+			t.Fatalf("expected oco order to be cancelled, got: %s", tp.Status)
+		}
+
+		trades, _, err := udb.UserTrades(ctx, tu.DBKey(), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedTrades := []TradeRecord{
+			{Ticker: "BTC", Action: grpcoin.TradeAction_SELL, Size: Amount{1, 0}, Price: Amount{850, 0}, OrderID: sl.ID,
+				TriggerPrice: &Amount{900, 0}},
+			{Ticker: "BTC", Action: grpcoin.TradeAction_BUY, Size: Amount{1, 0}, Price: Amount{1000, 0}, OrderID: "entry"},
+		}
+		if diff := cmp.Diff(expectedTrades, trades, cmpopts.IgnoreFields(TradeRecord{}, "Date")); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func TestUserDB_CloseOrder(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+		o, err := udb.CreateOrder(ctx, Order{
+			ID:          "order1",
+			UserID:      tu.DBKey(),
+			Type:        grpcoin.OrderType_LIMIT,
+			Ticker:      "BTC",
+			Action:      grpcoin.TradeAction_BUY,
+			Size:        Amount{Units: 2},
+			LimitPrice:  Amount{Units: 30_000},
+			TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+			Status:      grpcoin.OrderStatus_OPEN,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.Reserved != (Amount{Units: 60_000}) {
+			t.Fatalf("wrong reservation: %v", o.Reserved)
+		}
+
+		// reserved cash cannot be used by other trades or orders
+		if _, _, err := udb.Trade(ctx, tu.DBKey(), "BTC", grpcoin.TradeAction_BUY,
+			&grpcoin.Amount{Units: 30_000}, &grpcoin.Amount{Units: 2}, ""); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got: %v", err)
+		}
+		o2 := o
+		o2.ID = "order2"
+		if _, err := udb.CreateOrder(ctx, o2); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got: %v", err)
+		}
+
+		closed, err := udb.CloseOrder(ctx, tu.DBKey(), o.ID, grpcoin.OrderStatus_CANCELLED, "cancelled by user")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if closed.Status != grpcoin.OrderStatus_CANCELLED {
+			t.Fatalf("wrong order status: %s", closed.Status)
+		}
+		u, _, err := udb.Get(ctx, tu.DBKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(Portfolio{CashUSD: Amount{Units: 100_000}}, u.Portfolio, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// cancelled order cannot be filled
+		if _, _, err := udb.FillOrder(ctx, o, &grpcoin.Amount{Units: 1}); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("expected FailedPrecondition, got: %v", err)
+		}
+		if _, err := udb.CloseOrder(ctx, tu.DBKey(), "unknown", grpcoin.OrderStatus_CANCELLED, ""); status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound, got: %v", err)
+		}
+	})
+}
+
+func TestUserDB_ListOrders(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+		now := time.Now().UTC()
+		var ids []string
+		for i := 0; i < 5; i++ {
+			o, err := udb.CreateOrder(ctx, Order{
+				ID:          fmt.Sprintf("order%d", i),
+				UserID:      tu.DBKey(),
+				Type:        grpcoin.OrderType_LIMIT,
+				Ticker:      "BTC",
+				Action:      grpcoin.TradeAction_BUY,
+				Size:        Amount{Units: 1},
+				LimitPrice:  Amount{Units: 100},
+				TimeInForce: grpcoin.TimeInForce_GOOD_TILL_CANCELLED,
+				Status:      grpcoin.OrderStatus_OPEN,
+				CreatedAt:   now.Add(time.Duration(i) * time.Second),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append([]string{o.ID}, ids...) // most recent first
+		}
+		if _, err := udb.CloseOrder(ctx, tu.DBKey(), "order2", grpcoin.OrderStatus_CANCELLED, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		var token string
+		for {
+			page, next, err := udb.ListOrders(ctx, tu.DBKey(), OrderFilter{}, 2, token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, o := range page {
+				got = append(got, o.ID)
+			}
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		if diff := cmp.Diff(ids, got); diff != "" {
+			t.Fatal(diff)
+		}
+
+		open, _, err := udb.ListOrders(ctx, tu.DBKey(), OrderFilter{Status: grpcoin.OrderStatus_OPEN, Ticker: "BTC"}, 10, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(open) != 4 {
+			t.Fatalf("expected 4 open orders, got %d", len(open))
+		}
+		if _, _, err := udb.ListOrders(ctx, tu.DBKey(), OrderFilter{}, 10, "invalid"); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got: %v", err)
+		}
+	})
+}
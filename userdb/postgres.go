@@ -0,0 +1,599 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/grpcoin/grpcoin/api/grpcoin"
+)
+
+// pgMigrations are the schema changes of PostgresStore, applied in order.
+// Records are stored as JSON in the data column, next to the columns they are
+// queried by. Never change an applied migration, append a new one instead.
+var pgMigrations = []string{
+	`CREATE TABLE users (
+		id         TEXT PRIMARY KEY,
+		has_shorts BOOLEAN NOT NULL DEFAULT FALSE,
+		data       JSONB NOT NULL
+	);
+	CREATE INDEX users_has_shorts ON users (id) WHERE has_shorts;
+
+	CREATE TABLE trades (
+		uid    TEXT NOT NULL,
+		key    TEXT NOT NULL,
+		date   TIMESTAMPTZ NOT NULL,
+		ticker TEXT NOT NULL,
+		data   JSONB NOT NULL,
+		PRIMARY KEY (uid, key)
+	);
+	CREATE INDEX trades_uid_date ON trades (uid, date DESC, key);
+
+	CREATE TABLE valuations (
+		uid  TEXT NOT NULL,
+		key  TEXT NOT NULL,
+		date TIMESTAMPTZ NOT NULL,
+		data JSONB NOT NULL,
+		PRIMARY KEY (uid, key)
+	);
+
+	CREATE TABLE orders (
+		uid        TEXT NOT NULL,
+		id         TEXT NOT NULL,
+		status     INTEGER NOT NULL,
+		ticker     TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		data       JSONB NOT NULL,
+		PRIMARY KEY (uid, id)
+	);
+	CREATE INDEX orders_uid_created_at ON orders (uid, created_at DESC, id);
+	CREATE INDEX orders_open ON orders (uid, id) WHERE status = 1;
+
+	CREATE TABLE trade_requests (
+		uid             TEXT NOT NULL,
+		client_order_id TEXT NOT NULL,
+		data            JSONB NOT NULL,
+		PRIMARY KEY (uid, client_order_id)
+	);
+
+	CREATE TABLE alerts (
+		uid        TEXT NOT NULL,
+		id         TEXT NOT NULL,
+		active     BOOLEAN NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		data       JSONB NOT NULL,
+		PRIMARY KEY (uid, id)
+	);
+	CREATE INDEX alerts_active ON alerts (uid, id) WHERE active;`,
+
+	`CREATE TABLE ledger (
+		uid  TEXT NOT NULL,
+		seq  INTEGER NOT NULL,
+		data JSONB NOT NULL,
+		PRIMARY KEY (uid, seq)
+	);`,
+
+	`ALTER TABLE valuations ADD COLUMN resolution TEXT NOT NULL DEFAULT 'hourly';
+	ALTER TABLE valuations DROP CONSTRAINT valuations_pkey;
+	ALTER TABLE valuations ADD PRIMARY KEY (uid, resolution, key);`,
+}
+
+// PostgresStore stores the users in PostgreSQL, for deployments outside of
+// Google Cloud. Migrate must be called before the store is used.
+//
+// Transactions run at the repeatable read isolation level and lock the rows
+// they read, so a transaction fails with Aborted (instead of being retried)
+// if the rows are changed by a concurrent transaction, like Firestore
+// transactions run by FirestoreStore.
+type PostgresStore struct {
+	DB *sql.DB
+}
+
+// Migrate applies the schema migrations that have not been applied yet.
+// Servers starting concurrently wait for each other's migrations.
+func (p *PostgresStore) Migrate(ctx context.Context) error {
+	if _, err := p.DB.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	for i, m := range pgMigrations {
+		version := i + 1
+		err := p.inTx(ctx, nil, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, `LOCK TABLE schema_migrations IN EXCLUSIVE MODE`); err != nil {
+				return err
+			}
+			var applied bool
+			if err := tx.QueryRowContext(ctx,
+				`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version).Scan(&applied); err != nil {
+				return err
+			}
+			if applied {
+				return nil
+			}
+			if _, err := tx.ExecContext(ctx, m); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// inTx runs f in a transaction, which is committed if f returns nil.
+func (p *PostgresStore) inTx(ctx context.Context, opts *sql.TxOptions, f func(tx *sql.Tx) error) error {
+	tx, err := p.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return pgError(err)
+	}
+	if err := f(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return pgError(tx.Commit())
+}
+
+func (p *PostgresStore) RunTx(ctx context.Context, f func(ctx context.Context, tx Tx) error) error {
+	return p.inTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead}, func(tx *sql.Tx) error {
+		return f(ctx, &pgTx{ctx: ctx, tx: tx})
+	})
+}
+
+func (p *PostgresStore) CreateUser(ctx context.Context, u User) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	_, err = p.DB.ExecContext(ctx, `INSERT INTO users (id, has_shorts, data) VALUES ($1, $2, $3)`,
+		u.ID, u.Portfolio.HasShorts, data)
+	return pgError(err)
+}
+
+func (p *PostgresStore) GetUser(ctx context.Context, uid string) (User, bool, error) {
+	var u User
+	err := queryRow(ctx, p.DB, &u, `SELECT data FROM users WHERE id = $1`, uid)
+	if status.Code(err) == codes.NotFound {
+		return User{}, false, nil
+	} else if err != nil {
+		return User{}, false, status.Errorf(codes.Internal, "failed to retrieve user: %v", err)
+	}
+	return u, true, nil
+}
+
+func (p *PostgresStore) Users(ctx context.Context) ([]User, error) {
+	return queryUsers(ctx, p.DB, `SELECT data FROM users ORDER BY id`)
+}
+
+func (p *PostgresStore) UsersWithShorts(ctx context.Context) ([]User, error) {
+	return queryUsers(ctx, p.DB, `SELECT data FROM users WHERE has_shorts ORDER BY id`)
+}
+
+func (p *PostgresStore) AddTrade(ctx context.Context, uid string, tr TradeRecord) error {
+	data, err := json.Marshal(tr)
+	if err != nil {
+		return err
+	}
+	_, err = p.DB.ExecContext(ctx, `INSERT INTO trades (uid, key, date, ticker, data) VALUES ($1, $2, $3, $4, $5)`,
+		uid, tradeKey(tr), tr.Date, tr.Ticker, data)
+	return pgError(err)
+}
+
+func (p *PostgresStore) Trades(ctx context.Context, uid string) ([]TradeRecord, error) {
+	return queryTrades(ctx, p.DB, `SELECT data FROM trades WHERE uid = $1 ORDER BY date, key`, uid)
+}
+
+func (p *PostgresStore) TradeHistory(ctx context.Context, uid string, f TradeFilter, pageSize int, pageToken string) ([]TradeRecord, string, error) {
+	q := pgQuery{args: []interface{}{uid}}
+	q.where = append(q.where, "uid = $1")
+	if f.Ticker != "" {
+		q.add("ticker = %s", f.Ticker)
+	}
+	if !f.Since.IsZero() {
+		q.add("date >= %s", f.Since)
+	}
+	if !f.Until.IsZero() {
+		q.add("date < %s", f.Until)
+	}
+	if pageToken != "" {
+		t, key, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		q.add("(%s, key) > (date, %s)", t, key)
+	}
+	out, err := queryTrades(ctx, p.DB, `SELECT data FROM trades WHERE `+q.String()+
+		fmt.Sprintf(` ORDER BY date DESC, key LIMIT %d`, pageSize+1), q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(out) <= pageSize {
+		return out, "", nil
+	}
+	out = out[:pageSize]
+	last := out[len(out)-1]
+	return out, encodePageToken(last.Date, tradeKey(last)), nil
+}
+
+func (p *PostgresStore) AddValuation(ctx context.Context, uid string, res ValuationResolution, v ValuationHistory) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = p.DB.ExecContext(ctx, `INSERT INTO valuations (uid, resolution, key, date, data) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (uid, resolution, key) DO NOTHING`, uid, string(res), canonicalizeValuationHistoryDBKey(v.Date), v.Date, data)
+	return pgError(err)
+}
+
+func (p *PostgresStore) Valuations(ctx context.Context, uid string, res ValuationResolution, since time.Time) ([]ValuationHistory, error) {
+	q := &pgQuery{}
+	q.add("uid = %s", uid)
+	q.add("resolution = %s", string(res))
+	if !since.IsZero() {
+		q.add("date >= %s", since)
+	}
+	var out []ValuationHistory
+	err := queryRows(ctx, p.DB, func(data []byte) error {
+		var v ValuationHistory
+		if err := json.Unmarshal(data, &v); err != nil {
+			return fmt.Errorf("failed to unpack valuation record: %w", err)
+		}
+		out = append(out, v)
+		return nil
+	}, `SELECT data FROM valuations WHERE `+q.String()+` ORDER BY date`, q.args...)
+	return out, err
+}
+
+func (p *PostgresStore) DeleteValuations(ctx context.Context, uid string, res ValuationResolution, before time.Time) error {
+	_, err := p.DB.ExecContext(ctx, `DELETE FROM valuations WHERE uid = $1 AND resolution = $2 AND date < $3`,
+		uid, string(res), before)
+	return pgError(err)
+}
+
+func (p *PostgresStore) DeleteTradeRequests(ctx context.Context, uid string, before time.Time) error {
+	_, err := p.DB.ExecContext(ctx, `DELETE FROM trade_requests WHERE uid = $1 AND (data->>'ExpiresAt')::timestamptz < $2`,
+		uid, before)
+	return pgError(err)
+}
+
+func (p *PostgresStore) GetOrder(ctx context.Context, uid, orderID string) (Order, bool, error) {
+	var o Order
+	err := queryRow(ctx, p.DB, &o, `SELECT data FROM orders WHERE uid = $1 AND id = $2`, uid, orderID)
+	if status.Code(err) == codes.NotFound {
+		return Order{}, false, nil
+	} else if err != nil {
+		return Order{}, false, status.Errorf(codes.Internal, "failed to retrieve order: %v", err)
+	}
+	return o, true, nil
+}
+
+func (p *PostgresStore) ListOrders(ctx context.Context, uid string, f OrderFilter, pageSize int, pageToken string) ([]Order, string, error) {
+	q := pgQuery{args: []interface{}{uid}}
+	q.where = append(q.where, "uid = $1")
+	if f.Status != grpcoin.OrderStatus_UNDEFINED_ORDER_STATUS {
+		q.add("status = %s", int32(f.Status))
+	}
+	if f.Ticker != "" {
+		q.add("ticker = %s", f.Ticker)
+	}
+	if pageToken != "" {
+		t, id, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		q.add("(%s, id) > (created_at, %s)", t, id)
+	}
+	out, err := queryOrders(ctx, p.DB, `SELECT data FROM orders WHERE `+q.String()+
+		fmt.Sprintf(` ORDER BY created_at DESC, id LIMIT %d`, pageSize+1), q.args...)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(out) <= pageSize {
+		return out, "", nil
+	}
+	out = out[:pageSize]
+	last := out[len(out)-1]
+	return out, encodePageToken(last.CreatedAt, last.ID), nil
+}
+
+func (p *PostgresStore) OpenOrders(ctx context.Context) ([]Order, error) {
+	return queryOrders(ctx, p.DB, `SELECT data FROM orders WHERE status = $1 ORDER BY uid, id`,
+		int32(grpcoin.OrderStatus_OPEN))
+}
+
+func (p *PostgresStore) CreateAlert(ctx context.Context, a Alert) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	_, err = p.DB.ExecContext(ctx, `INSERT INTO alerts (uid, id, active, created_at, data) VALUES ($1, $2, $3, $4, $5)`,
+		a.UserID, a.ID, a.Active, a.CreatedAt, data)
+	return pgError(err)
+}
+
+func (p *PostgresStore) ListAlerts(ctx context.Context, uid string) ([]Alert, error) {
+	return queryAlerts(ctx, p.DB, `SELECT data FROM alerts WHERE uid = $1 ORDER BY created_at DESC`, uid)
+}
+
+func (p *PostgresStore) ActiveAlerts(ctx context.Context) ([]Alert, error) {
+	return queryAlerts(ctx, p.DB, `SELECT data FROM alerts WHERE active ORDER BY uid, id`)
+}
+
+func (p *PostgresStore) DeleteAlert(ctx context.Context, uid, alertID string) error {
+	res, err := p.DB.ExecContext(ctx, `DELETE FROM alerts WHERE uid = $1 AND id = $2`, uid, alertID)
+	if err != nil {
+		return pgError(err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return status.Errorf(codes.NotFound, "alert %q not found", alertID)
+	}
+	return nil
+}
+
+func (p *PostgresStore) Ledger(ctx context.Context, uid string) ([]LedgerEntry, error) {
+	return queryLedger(ctx, p.DB, `SELECT data FROM ledger WHERE uid = $1 ORDER BY seq`, uid)
+}
+
+// pgTx implements Tx in a SQL transaction. The rows read are locked until the
+// transaction ends.
+type pgTx struct {
+	ctx context.Context
+	tx  *sql.Tx
+}
+
+func (t *pgTx) GetUser(uid string) (User, error) {
+	var u User
+	err := queryRow(t.ctx, t.tx, &u, `SELECT data FROM users WHERE id = $1 FOR UPDATE`, uid)
+	if status.Code(err) == codes.NotFound {
+		return User{}, status.Errorf(codes.NotFound, "user %q not found", uid)
+	}
+	return u, err
+}
+
+func (t *pgTx) GetOrder(uid, orderID string) (Order, error) {
+	var o Order
+	err := queryRow(t.ctx, t.tx, &o, `SELECT data FROM orders WHERE uid = $1 AND id = $2 FOR UPDATE`, uid, orderID)
+	if status.Code(err) == codes.NotFound {
+		return Order{}, status.Errorf(codes.NotFound, "order %q not found", orderID)
+	}
+	return o, err
+}
+
+func (t *pgTx) GetAlert(uid, alertID string) (Alert, error) {
+	var a Alert
+	err := queryRow(t.ctx, t.tx, &a, `SELECT data FROM alerts WHERE uid = $1 AND id = $2 FOR UPDATE`, uid, alertID)
+	if status.Code(err) == codes.NotFound {
+		return Alert{}, status.Errorf(codes.NotFound, "alert %q not found", alertID)
+	}
+	return a, err
+}
+
+func (t *pgTx) GetTradeRequest(uid, clientOrderID string) (TradeRequest, bool, error) {
+	var v TradeRequest
+	err := queryRow(t.ctx, t.tx, &v, `SELECT data FROM trade_requests
+		WHERE uid = $1 AND client_order_id = $2 FOR UPDATE`, uid, clientOrderID)
+	if status.Code(err) == codes.NotFound {
+		return TradeRequest{}, false, nil
+	}
+	return v, err == nil, err
+}
+
+func (t *pgTx) Trades(uid string) ([]TradeRecord, error) {
+	return queryTrades(t.ctx, t.tx, `SELECT data FROM trades WHERE uid = $1 ORDER BY date, key`, uid)
+}
+
+func (t *pgTx) Ledger(uid string) ([]LedgerEntry, error) {
+	return queryLedger(t.ctx, t.tx, `SELECT data FROM ledger WHERE uid = $1 ORDER BY seq`, uid)
+}
+
+func (t *pgTx) SetUser(u User) error {
+	return t.exec(u, `INSERT INTO users (id, has_shorts, data) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET has_shorts = EXCLUDED.has_shorts, data = EXCLUDED.data`,
+		u.ID, u.Portfolio.HasShorts)
+}
+
+func (t *pgTx) CreateOrder(o Order) error {
+	return t.exec(o, `INSERT INTO orders (uid, id, status, ticker, created_at, data) VALUES ($1, $2, $3, $4, $5, $6)`,
+		o.UserID, o.ID, int32(o.Status), o.Ticker, o.CreatedAt)
+}
+
+func (t *pgTx) SetOrder(o Order) error {
+	return t.exec(o, `INSERT INTO orders (uid, id, status, ticker, created_at, data) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (uid, id) DO UPDATE SET status = EXCLUDED.status, data = EXCLUDED.data`,
+		o.UserID, o.ID, int32(o.Status), o.Ticker, o.CreatedAt)
+}
+
+func (t *pgTx) SetAlert(a Alert) error {
+	return t.exec(a, `INSERT INTO alerts (uid, id, active, created_at, data) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (uid, id) DO UPDATE SET active = EXCLUDED.active, data = EXCLUDED.data`,
+		a.UserID, a.ID, a.Active, a.CreatedAt)
+}
+
+func (t *pgTx) SetTradeRequest(uid, clientOrderID string, v TradeRequest) error {
+	return t.exec(v, `INSERT INTO trade_requests (uid, client_order_id, data) VALUES ($1, $2, $3)
+		ON CONFLICT (uid, client_order_id) DO UPDATE SET data = EXCLUDED.data`,
+		uid, clientOrderID)
+}
+
+func (t *pgTx) AddLedgerEntry(uid string, e LedgerEntry) error {
+	return t.exec(e, `INSERT INTO ledger (uid, seq, data) VALUES ($1, $2, $3)`, uid, e.Seq)
+}
+
+// exec runs the statement with the record encoded as the last argument.
+func (t *pgTx) exec(record interface{}, query string, args ...interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = t.tx.ExecContext(t.ctx, query, append(args, data)...)
+	return pgError(err)
+}
+
+// pgQuery builds the conditions of a query.
+type pgQuery struct {
+	where []string
+	args  []interface{}
+}
+
+// add adds the condition with the %s verbs replaced by the placeholders of
+// the args.
+func (q *pgQuery) add(cond string, args ...interface{}) {
+	ph := make([]interface{}, len(args))
+	for i, v := range args {
+		q.args = append(q.args, v)
+		ph[i] = fmt.Sprintf("$%d", len(q.args))
+	}
+	q.where = append(q.where, fmt.Sprintf(cond, ph...))
+}
+
+func (q *pgQuery) String() string { return strings.Join(q.where, " AND ") }
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// queryRow decodes the data column of the row returned by the query into v,
+// and fails with NotFound if there is no row.
+func queryRow(ctx context.Context, q querier, v interface{}, query string, args ...interface{}) error {
+	var data []byte
+	err := q.QueryRowContext(ctx, query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "record not found")
+	} else if err != nil {
+		return pgError(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to unpack record into %T: %w", v, err)
+	}
+	return nil
+}
+
+// queryRows invokes f with the data column of each row returned by the query.
+func queryRows(ctx context.Context, q querier, f func(data []byte) error, query string, args ...interface{}) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return pgError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		if err := f(data); err != nil {
+			return err
+		}
+	}
+	return pgError(rows.Err())
+}
+
+func queryUsers(ctx context.Context, q querier, query string, args ...interface{}) ([]User, error) {
+	var out []User
+	err := queryRows(ctx, q, func(data []byte) error {
+		var u User
+		if err := json.Unmarshal(data, &u); err != nil {
+			return fmt.Errorf("failed to unpack user record: %w", err)
+		}
+		out = append(out, u)
+		return nil
+	}, query, args...)
+	return out, err
+}
+
+func queryTrades(ctx context.Context, q querier, query string, args ...interface{}) ([]TradeRecord, error) {
+	var out []TradeRecord
+	err := queryRows(ctx, q, func(data []byte) error {
+		var tr TradeRecord
+		if err := json.Unmarshal(data, &tr); err != nil {
+			return fmt.Errorf("failed to unpack trade record: %w", err)
+		}
+		out = append(out, tr)
+		return nil
+	}, query, args...)
+	return out, err
+}
+
+func queryOrders(ctx context.Context, q querier, query string, args ...interface{}) ([]Order, error) {
+	var out []Order
+	err := queryRows(ctx, q, func(data []byte) error {
+		var o Order
+		if err := json.Unmarshal(data, &o); err != nil {
+			return fmt.Errorf("failed to unpack order record: %w", err)
+		}
+		out = append(out, o)
+		return nil
+	}, query, args...)
+	return out, err
+}
+
+func queryAlerts(ctx context.Context, q querier, query string, args ...interface{}) ([]Alert, error) {
+	var out []Alert
+	err := queryRows(ctx, q, func(data []byte) error {
+		var a Alert
+		if err := json.Unmarshal(data, &a); err != nil {
+			return fmt.Errorf("failed to unpack alert record: %w", err)
+		}
+		out = append(out, a)
+		return nil
+	}, query, args...)
+	return out, err
+}
+
+func queryLedger(ctx context.Context, q querier, query string, args ...interface{}) ([]LedgerEntry, error) {
+	var out []LedgerEntry
+	err := queryRows(ctx, q, func(data []byte) error {
+		var e LedgerEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return fmt.Errorf("failed to unpack ledger entry: %w", err)
+		}
+		out = append(out, e)
+		return nil
+	}, query, args...)
+	return out, err
+}
+
+// pgError converts the PostgreSQL errors that callers handle to gRPC status
+// errors: unique key violations to AlreadyExists, and conflicts with
+// concurrent transactions to Aborted.
+func pgError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case "23505": // unique_violation
+		return status.Errorf(codes.AlreadyExists, "record already exists: %v", pqErr.Message)
+	case "40001", "40P01": // serialization_failure, deadlock_detected
+		return status.Errorf(codes.Aborted, "transaction conflicted with another one: %v", pqErr.Message)
+	}
+	return err
+}
@@ -25,12 +25,13 @@ import (
 )
 
 type ProfileCache interface {
-	GetTrades(ctx context.Context, uid string) ([]TradeRecord, bool, error)
-	SaveTrades(ctx context.Context, uid string, v []TradeRecord) error
+	// GetTrades and SaveTrades operate on the first page of user's trades.
+	GetTrades(ctx context.Context, uid string) (TradePage, bool, error)
+	SaveTrades(ctx context.Context, uid string, v TradePage) error
 	InvalidateTrades(ctx context.Context, uid string) error
 
-	GetValuation(ctx context.Context, uid string, now time.Time) ([]ValuationHistory, bool, error)
-	SaveValuation(ctx context.Context, uid string, now time.Time, v []ValuationHistory) error
+	GetValuation(ctx context.Context, uid string, rng ValuationRange, now time.Time) ([]ValuationHistory, bool, error)
+	SaveValuation(ctx context.Context, uid string, rng ValuationRange, now time.Time, v []ValuationHistory) error
 }
 
 const (
@@ -38,22 +39,29 @@ const (
 	portfolioValueChangeInterval = time.Hour
 )
 
+// TradePage is a page of user's trades (most recent first) and the token to
+// retrieve the next page, which is empty on the last page.
+type TradePage struct {
+	Trades        []TradeRecord
+	NextPageToken string
+}
+
 type UserDBCache struct {
 	R *redis.Client
 }
 
-func (_ UserDBCache) tradesCacheKey(uid string) string { return fmt.Sprintf("trades::%s", uid) }
-func (_ UserDBCache) valuationCacheKey(uid string, now time.Time) string {
-	return fmt.Sprintf("portfolioValuation::%s::%d", uid, now.Truncate(portfolioValueChangeInterval).Unix())
+func (_ UserDBCache) tradesCacheKey(uid string) string { return fmt.Sprintf("tradesPage::%s", uid) }
+func (_ UserDBCache) valuationCacheKey(uid string, rng ValuationRange, now time.Time) string {
+	return fmt.Sprintf("portfolioValuation::%s::%s::%d", uid, rng, now.Truncate(portfolioValueChangeInterval).Unix())
 }
 
-func (u UserDBCache) GetTrades(ctx context.Context, uid string) ([]TradeRecord, bool, error) {
+func (u UserDBCache) GetTrades(ctx context.Context, uid string) (TradePage, bool, error) {
 	var v cachedTradeHistory
 	err := u.R.Get(ctx, u.tradesCacheKey(uid)).Scan(&v)
-	return v, !errors.Is(err, redis.Nil), nonRedisNilErr(err)
+	return TradePage(v), !errors.Is(err, redis.Nil), nonRedisNilErr(err)
 }
 
-func (u UserDBCache) SaveTrades(ctx context.Context, uid string, v []TradeRecord) error {
+func (u UserDBCache) SaveTrades(ctx context.Context, uid string, v TradePage) error {
 	return u.R.Set(ctx, u.tradesCacheKey(uid), cachedTradeHistory(v), userTradeHistoryTTL).Err()
 }
 
@@ -61,14 +69,14 @@ func (u UserDBCache) InvalidateTrades(ctx context.Context, uid string) error {
 	return u.R.Del(ctx, u.tradesCacheKey(uid)).Err()
 }
 
-func (u UserDBCache) GetValuation(ctx context.Context, uid string, now time.Time) ([]ValuationHistory, bool, error) {
+func (u UserDBCache) GetValuation(ctx context.Context, uid string, rng ValuationRange, now time.Time) ([]ValuationHistory, bool, error) {
 	var v cachedValuationHistory
-	err := u.R.Get(ctx, u.valuationCacheKey(uid, now)).Scan(&v)
+	err := u.R.Get(ctx, u.valuationCacheKey(uid, rng, now)).Scan(&v)
 	return v, !errors.Is(err, redis.Nil), nonRedisNilErr(err)
 }
 
-func (u UserDBCache) SaveValuation(ctx context.Context, uid string, now time.Time, v []ValuationHistory) error {
-	return u.R.Set(ctx, u.valuationCacheKey(uid, now), cachedValuationHistory(v), portfolioValueChangeInterval).Err()
+func (u UserDBCache) SaveValuation(ctx context.Context, uid string, rng ValuationRange, now time.Time, v []ValuationHistory) error {
+	return u.R.Set(ctx, u.valuationCacheKey(uid, rng, now), cachedValuationHistory(v), portfolioValueChangeInterval).Err()
 }
 
 func nonRedisNilErr(err error) error {
@@ -78,7 +86,7 @@ func nonRedisNilErr(err error) error {
 	return err
 }
 
-type cachedTradeHistory []TradeRecord
+type cachedTradeHistory TradePage
 
 func (c cachedTradeHistory) MarshalBinary() (data []byte, err error) { return json.Marshal(c) }
 
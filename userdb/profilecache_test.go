@@ -35,12 +35,15 @@ func TestTradeHistoryCaches(t *testing.T) {
 		t.Fatal("was not expecting value")
 	}
 
-	tr := []TradeRecord{
-		{Date: time.Unix(100, 0),
-			Ticker: "BTC",
-			Action: 1,
-			Size:   Amount{1, 1},
-			Price:  Amount{2, 2}},
+	tr := TradePage{
+		Trades: []TradeRecord{
+			{Date: time.Unix(100, 0),
+				Ticker: "BTC",
+				Action: 1,
+				Size:   Amount{1, 1},
+				Price:  Amount{2, 2}},
+		},
+		NextPageToken: "next",
 	}
 	if err := c.SaveTrades(context.TODO(), "foo", tr); err != nil {
 		t.Fatal(err)
@@ -72,7 +75,7 @@ func TestPortfolioValidationHistoryCaches(t *testing.T) {
 	rc := testutil.MockRedis(t)
 	var c UserDBCache = UserDBCache{R: rc}
 	now := time.Date(2020, 01, 01, 0, 0, 0, 0, time.UTC)
-	_, ok, err := c.GetValuation(context.TODO(), "foo", now)
+	_, ok, err := c.GetValuation(context.TODO(), "foo", ValuationRangeMonth, now)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -85,19 +88,19 @@ func TestPortfolioValidationHistoryCaches(t *testing.T) {
 		{Date: time.Unix(2, 0), Value: Amount{2, 2}},
 		{Date: time.Unix(3, 0), Value: Amount{3, 3}},
 	}
-	if err := c.SaveValuation(context.TODO(), "foo", now, pv); err != nil {
+	if err := c.SaveValuation(context.TODO(), "foo", ValuationRangeMonth, now, pv); err != nil {
 		t.Fatal(err)
 	}
 
 	// query too far ahead
-	if _, ok, err := c.GetValuation(context.TODO(), "foo", now.Add(time.Hour)); err != nil {
+	if _, ok, err := c.GetValuation(context.TODO(), "foo", ValuationRangeMonth, now.Add(time.Hour)); err != nil {
 		t.Fatal(err)
 	} else if ok {
 		t.Fatal("was not expecting value bc queried too far ahead")
 	}
 
 	// query while cached data is valid
-	v, ok, err := c.GetValuation(context.TODO(), "foo", now.Add(time.Minute*59))
+	v, ok, err := c.GetValuation(context.TODO(), "foo", ValuationRangeMonth, now.Add(time.Minute*59))
 	if err != nil {
 		t.Fatal(err)
 	}
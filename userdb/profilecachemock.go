@@ -21,18 +21,18 @@ import (
 
 type MockProfileCache struct{}
 
-func (m MockProfileCache) GetTrades(_ context.Context, _ string) ([]TradeRecord, bool, error) {
-	return nil, false, nil
+func (m MockProfileCache) GetTrades(_ context.Context, _ string) (TradePage, bool, error) {
+	return TradePage{}, false, nil
 }
 
-func (m MockProfileCache) SaveTrades(_ context.Context, _ string, v []TradeRecord) error { return nil }
+func (m MockProfileCache) SaveTrades(_ context.Context, _ string, v TradePage) error { return nil }
 
 func (m MockProfileCache) InvalidateTrades(_ context.Context, _ string) error { return nil }
 
-func (m MockProfileCache) GetValuation(_ context.Context, _ string, _ time.Time) ([]ValuationHistory, bool, error) {
+func (m MockProfileCache) GetValuation(_ context.Context, _ string, _ ValuationRange, _ time.Time) ([]ValuationHistory, bool, error) {
 	return nil, false, nil
 }
 
-func (m MockProfileCache) SaveValuation(_ context.Context, _ string, _ time.Time, v []ValuationHistory) error {
+func (m MockProfileCache) SaveValuation(_ context.Context, _ string, _ ValuationRange, _ time.Time, v []ValuationHistory) error {
 	return nil
 }
@@ -0,0 +1,104 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"time"
+)
+
+// Store persists the user records and their trade history, valuation
+// history, orders and alerts. FirestoreStore is used in production, and
+// MemStore is used when running locally and in tests.
+//
+// Methods return gRPC status errors (e.g. NotFound, AlreadyExists) for the
+// conditions callers are expected to handle.
+type Store interface {
+	// RunTx runs f in a transaction, which is committed if f returns nil.
+	// The transaction is not retried if it conflicts with another one. f must
+	// not use the Store itself.
+	RunTx(ctx context.Context, f func(ctx context.Context, tx Tx) error) error
+
+	// CreateUser stores a new user. Fails with AlreadyExists if the user
+	// exists.
+	CreateUser(ctx context.Context, u User) error
+	GetUser(ctx context.Context, uid string) (User, bool, error)
+	Users(ctx context.Context) ([]User, error)
+	UsersWithShorts(ctx context.Context) ([]User, error)
+
+	// AddTrade records the trade in the user's trade history.
+	AddTrade(ctx context.Context, uid string, tr TradeRecord) error
+	// Trades returns the user's trade history (oldest first).
+	Trades(ctx context.Context, uid string) ([]TradeRecord, error)
+	// TradeHistory returns a page of the user's trades (most recent first).
+	TradeHistory(ctx context.Context, uid string, f TradeFilter, pageSize int, pageToken string) ([]TradeRecord, string, error)
+
+	// AddValuation records the user's portfolio value in the history of the
+	// resolution, unless a value is already recorded at the same time.
+	AddValuation(ctx context.Context, uid string, res ValuationResolution, v ValuationHistory) error
+	// Valuations returns the user's valuation history of the resolution
+	// since the time (oldest first). Zero since returns the whole history.
+	Valuations(ctx context.Context, uid string, res ValuationResolution, since time.Time) ([]ValuationHistory, error)
+	// DeleteValuations deletes the user's valuations of the resolution
+	// before the time.
+	DeleteValuations(ctx context.Context, uid string, res ValuationResolution, before time.Time) error
+
+	GetOrder(ctx context.Context, uid, orderID string) (Order, bool, error)
+	// ListOrders returns a page of the user's orders (most recent first).
+	ListOrders(ctx context.Context, uid string, f OrderFilter, pageSize int, pageToken string) ([]Order, string, error)
+	// OpenOrders returns the open orders of all users.
+	OpenOrders(ctx context.Context) ([]Order, error)
+
+	// CreateAlert stores a new alert. Fails with AlreadyExists if the alert
+	// exists.
+	CreateAlert(ctx context.Context, a Alert) error
+	// ListAlerts returns the user's alerts (most recent first).
+	ListAlerts(ctx context.Context, uid string) ([]Alert, error)
+	// ActiveAlerts returns the alerts of all users that have not fired yet.
+	ActiveAlerts(ctx context.Context) ([]Alert, error)
+	// DeleteAlert fails with NotFound if the alert does not exist.
+	DeleteAlert(ctx context.Context, uid, alertID string) error
+
+	// Ledger returns the user's ledger entries ordered by their sequence.
+	Ledger(ctx context.Context, uid string) ([]LedgerEntry, error)
+
+	// DeleteTradeRequests deletes the user's trade requests that expire
+	// before the time.
+	DeleteTradeRequests(ctx context.Context, uid string, before time.Time) error
+}
+
+// Tx reads and writes records in a transaction. All reads must be done
+// before the writes. Reads of missing records fail with NotFound.
+type Tx interface {
+	GetUser(uid string) (User, error)
+	GetOrder(uid, orderID string) (Order, error)
+	GetAlert(uid, alertID string) (Alert, error)
+	// GetTradeRequest returns false if the trade request does not exist.
+	GetTradeRequest(uid, clientOrderID string) (TradeRequest, bool, error)
+	// Trades returns the user's trade history (oldest first).
+	Trades(uid string) ([]TradeRecord, error)
+	// Ledger returns the user's ledger entries ordered by their sequence.
+	Ledger(uid string) ([]LedgerEntry, error)
+
+	SetUser(u User) error
+	// CreateOrder fails with AlreadyExists if the order exists.
+	CreateOrder(o Order) error
+	SetOrder(o Order) error
+	SetAlert(a Alert) error
+	SetTradeRequest(uid, clientOrderID string, v TradeRequest) error
+	// AddLedgerEntry fails with AlreadyExists if the user's ledger has an
+	// entry with the same sequence.
+	AddLedgerEntry(uid string, e LedgerEntry) error
+}
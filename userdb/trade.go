@@ -26,7 +26,13 @@ import (
 	"github.com/grpcoin/grpcoin/api/grpcoin"
 )
 
-func makeTrade(p *Portfolio, action grpcoin.TradeAction, ticker string, quote, quantity *grpcoin.Amount) error {
+// makeTrade executes the trade on the portfolio at the quote price with the
+// slippage and fee from the fee schedule applied. Maker trades are the ones
+// made by limit orders. If allowShort is set, the position can become
+// negative (the margin requirements are checked by the caller). It returns
+// the executed price and the fee charged.
+func makeTrade(p *Portfolio, action grpcoin.TradeAction, ticker string, quote, quantity *grpcoin.Amount,
+	fees FeeSchedule, maker, allowShort bool) (price, fee Amount, err error) {
 	inCash := toDecimal(p.CashUSD.V())
 	pos, ok := p.Positions[ticker]
 	if !ok {
@@ -37,7 +43,9 @@ func makeTrade(p *Portfolio, action grpcoin.TradeAction, ticker string, quote, q
 	}
 	posN := toDecimal(pos.V())
 
-	cost := toDecimal(quantity).Mul(toDecimal(quote))
+	price = ToAmount(fees.executionPrice(action, toDecimal(quote), toDecimal(quantity), maker))
+	cost := toDecimal(quantity).Mul(price.F())
+	fee = ToAmount(fees.fee(cost, maker))
 
 	posDelta := toDecimal(quantity)
 
@@ -45,27 +53,89 @@ func makeTrade(p *Portfolio, action grpcoin.TradeAction, ticker string, quote, q
 		posDelta = posDelta.Neg()
 		cost = cost.Neg()
 	}
+	cost = cost.Add(fee.F())
 
 	finalCash := inCash.Sub(cost)
 	finalPos := posN.Add(posDelta)
 	if finalCash.IsNegative() {
-		return status.Errorf(codes.InvalidArgument,
+		return price, fee, status.Errorf(codes.InvalidArgument,
 			"insufficient cash after transaction (%s)", finalCash)
 
 	}
-	if finalPos.IsNegative() {
-		return status.Errorf(codes.InvalidArgument,
+	if finalPos.IsNegative() && !allowShort {
+		return price, fee, status.Errorf(codes.InvalidArgument,
 			"insufficient %s positions (%s) after transaction (current: %s)", ticker, finalPos, posN)
 	}
+	if reserved := p.ReservedCashUSD.F(); finalCash.LessThan(reserved) {
+		return price, fee, status.Errorf(codes.InvalidArgument,
+			"insufficient cash after transaction (%s), %s is reserved for open orders", finalCash, reserved)
+	}
+	if reserved := p.ReservedPositions[ticker].F(); reserved.IsPositive() && finalPos.LessThan(reserved) {
+		return price, fee, status.Errorf(codes.InvalidArgument,
+			"insufficient %s positions (%s) after transaction, %s is reserved for open orders", ticker, finalPos, reserved)
+	}
 	p.CashUSD = ToAmount(finalCash)
 	if finalPos.IsZero() {
 		delete(p.Positions, ticker)
 	} else {
 		p.Positions[ticker] = ToAmount(finalPos)
 	}
+	p.HasShorts = hasShorts(p.Positions)
+	return price, fee, nil
+}
+
+// AvailableCashUSD returns the cash that is not reserved for open orders.
+func (p Portfolio) AvailableCashUSD() Amount {
+	return ToAmount(p.CashUSD.F().Sub(p.ReservedCashUSD.F()))
+}
+
+// AvailablePosition returns the position that is not reserved for open
+// orders.
+func (p Portfolio) AvailablePosition(ticker string) Amount {
+	return ToAmount(p.Positions[ticker].F().Sub(p.ReservedPositions[ticker].F()))
+}
+
+// reserve holds the order's reservation in the portfolio. Fails with
+// InvalidArgument if there is not enough cash or position available.
+func (p *Portfolio) reserve(o Order) error {
+	if o.Action == grpcoin.TradeAction_BUY {
+		if avail := p.AvailableCashUSD().F(); avail.LessThan(o.Reserved.F()) {
+			return status.Errorf(codes.InvalidArgument,
+				"insufficient cash available (%s) for order (%s)", avail, o.Reserved.F())
+		}
+	} else if avail := p.AvailablePosition(o.Ticker).F(); avail.LessThan(o.Reserved.F()) {
+		return status.Errorf(codes.InvalidArgument,
+			"insufficient %s positions available (%s) for order (%s)", o.Ticker, avail, o.Reserved.F())
+	}
+	p.hold(o, o.Reserved.F())
 	return nil
 }
 
+// release gives back the order's reservation to the portfolio.
+func (p *Portfolio) release(o Order) { p.hold(o, o.Reserved.F().Neg()) }
+
+func (p *Portfolio) hold(o Order, delta decimal.Decimal) {
+	if delta.IsZero() {
+		return
+	}
+	if o.Action == grpcoin.TradeAction_BUY {
+		p.ReservedCashUSD = ToAmount(p.ReservedCashUSD.F().Add(delta))
+		return
+	}
+	v := ToAmount(p.ReservedPositions[o.Ticker].F().Add(delta))
+	if v.IsZero() {
+		delete(p.ReservedPositions, o.Ticker)
+		if len(p.ReservedPositions) == 0 {
+			p.ReservedPositions = nil
+		}
+		return
+	}
+	if p.ReservedPositions == nil {
+		p.ReservedPositions = make(map[string]Amount)
+	}
+	p.ReservedPositions[o.Ticker] = v
+}
+
 func toDecimal(a *grpcoin.Amount) decimal.Decimal {
 	u, n := a.Units, a.Nanos
 	if a.Units < 0 {
@@ -76,7 +146,7 @@ func toDecimal(a *grpcoin.Amount) decimal.Decimal {
 	}
 	s := fmt.Sprintf("%d.%09d", u, n)
 
-	if a.Nanos < 0 && a.Units < 0 {
+	if a.Units < 0 || a.Nanos < 0 {
 		s = "-" + s
 	}
 	return decimal.RequireFromString(s)
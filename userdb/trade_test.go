@@ -44,6 +44,14 @@ func Test_toDecimal(t *testing.T) {
 			a:    &grpcoin.Amount{Units: 3, Nanos: 300},
 			want: ("3.000000300"),
 		},
+		{
+			a:    &grpcoin.Amount{Units: -3},
+			want: ("-3.000000000"),
+		},
+		{
+			a:    &grpcoin.Amount{Nanos: -3},
+			want: ("-0.000000003"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.a.String(), func(t *testing.T) {
@@ -79,18 +87,23 @@ func TestToAmount(t *testing.T) {
 
 func Test_makeTrade(t *testing.T) {
 	type args struct {
-		p        *Portfolio
-		action   grpcoin.TradeAction
-		ticker   string
-		quote    *grpcoin.Amount
-		quantity *grpcoin.Amount
+		p          *Portfolio
+		action     grpcoin.TradeAction
+		ticker     string
+		quote      *grpcoin.Amount
+		quantity   *grpcoin.Amount
+		fees       FeeSchedule
+		maker      bool
+		allowShort bool
 	}
 	tests := []struct {
-		name   string
-		args   args
-		code   codes.Code
-		errMsg string
-		want   *Portfolio
+		name      string
+		args      args
+		code      codes.Code
+		errMsg    string
+		want      *Portfolio
+		wantPrice *Amount
+		wantFee   Amount
 	}{
 		{name: "insufficient cash",
 			args: args{p: &Portfolio{
@@ -124,6 +137,31 @@ func Test_makeTrade(t *testing.T) {
 				quantity: &grpcoin.Amount{Units: 2}},
 			code:   codes.InvalidArgument,
 			errMsg: "insufficient ETH positions"},
+		{name: "cash reserved for orders",
+			args: args{p: &Portfolio{
+				CashUSD:         Amount{Units: 1000},
+				ReservedCashUSD: Amount{Units: 700},
+			},
+				action:   grpcoin.TradeAction_BUY,
+				ticker:   "BTC",
+				quote:    &grpcoin.Amount{Units: 200},
+				quantity: &grpcoin.Amount{Units: 2},
+			},
+			code:   codes.InvalidArgument,
+			errMsg: "insufficient cash after transaction (600), 700 is reserved for open orders"},
+		{name: "positions reserved for orders",
+			args: args{p: &Portfolio{
+				CashUSD:           Amount{Units: 100},
+				Positions:         map[string]Amount{"BTC": {Units: 3}},
+				ReservedPositions: map[string]Amount{"BTC": {Units: 2}},
+			},
+				action:   grpcoin.TradeAction_SELL,
+				ticker:   "BTC",
+				quote:    &grpcoin.Amount{Units: 100},
+				quantity: &grpcoin.Amount{Units: 2},
+			},
+			code:   codes.InvalidArgument,
+			errMsg: "insufficient BTC positions (1) after transaction, 2 is reserved for open orders"},
 		{name: "no position initiated (buy)",
 			args: args{p: &Portfolio{
 				CashUSD: Amount{400, 1}},
@@ -193,10 +231,114 @@ func Test_makeTrade(t *testing.T) {
 				Positions: map[string]Amount{},
 			},
 		},
+		{name: "fee makes cash insufficient",
+			args: args{p: &Portfolio{CashUSD: Amount{Units: 100}},
+				action:   grpcoin.TradeAction_BUY,
+				ticker:   "BTC",
+				quote:    &grpcoin.Amount{Units: 100},
+				quantity: &grpcoin.Amount{Units: 1},
+				fees:     FeeSchedule{TakerBps: 10},
+			},
+			code:   codes.InvalidArgument,
+			errMsg: "insufficient cash",
+		},
+		{name: "minimum fee",
+			args: args{p: &Portfolio{CashUSD: Amount{Units: 1000}},
+				action:   grpcoin.TradeAction_BUY,
+				ticker:   "BTC",
+				quote:    &grpcoin.Amount{Units: 100},
+				quantity: &grpcoin.Amount{Units: 1},
+				fees:     FeeSchedule{TakerBps: 10, MinFeeUSD: 1},
+			},
+			code: codes.OK,
+			want: &Portfolio{
+				CashUSD:   Amount{Units: 899},
+				Positions: map[string]Amount{"BTC": {Units: 1}},
+			},
+			wantPrice: &Amount{Units: 100},
+			wantFee:   Amount{Units: 1},
+		},
+		{name: "maker fee without slippage",
+			args: args{p: &Portfolio{Positions: map[string]Amount{"BTC": {Units: 2}}},
+				action:   grpcoin.TradeAction_SELL,
+				ticker:   "BTC",
+				quote:    &grpcoin.Amount{Units: 10_000},
+				quantity: &grpcoin.Amount{Units: 2},
+				fees:     FeeSchedule{MakerBps: 10, TakerBps: 20, SlippageBps: 10},
+				maker:    true,
+			},
+			code: codes.OK,
+			want: &Portfolio{
+				CashUSD:   Amount{Units: 19_980},
+				Positions: map[string]Amount{},
+			},
+			wantPrice: &Amount{Units: 10_000},
+			wantFee:   Amount{Units: 20},
+		},
+		{name: "taker buy slippage",
+			args: args{p: &Portfolio{CashUSD: Amount{Units: 20_000}},
+				action:   grpcoin.TradeAction_BUY,
+				ticker:   "BTC",
+				quote:    &grpcoin.Amount{Units: 10_000},
+				quantity: &grpcoin.Amount{Units: 1},
+				fees:     FeeSchedule{SlippageBps: 10},
+			},
+			code: codes.OK,
+			want: &Portfolio{
+				CashUSD:   Amount{Units: 9_990},
+				Positions: map[string]Amount{"BTC": {Units: 1}},
+			},
+			wantPrice: &Amount{Units: 10_010},
+		},
+		{name: "taker sell slippage capped",
+			args: args{p: &Portfolio{Positions: map[string]Amount{"BTC": {Units: 10}}},
+				action:   grpcoin.TradeAction_SELL,
+				ticker:   "BTC",
+				quote:    &grpcoin.Amount{Units: 10_000},
+				quantity: &grpcoin.Amount{Units: 10},
+				fees:     FeeSchedule{SlippageBps: 10, MaxSlippageBps: 50},
+			},
+			code: codes.OK,
+			want: &Portfolio{
+				CashUSD:   Amount{Units: 99_500},
+				Positions: map[string]Amount{},
+			},
+			wantPrice: &Amount{Units: 9_950},
+		},
+		{name: "short sale",
+			args: args{p: &Portfolio{CashUSD: Amount{Units: 1000}, Positions: map[string]Amount{"BTC": {Units: 1}}},
+				action:     grpcoin.TradeAction_SELL,
+				ticker:     "BTC",
+				quote:      &grpcoin.Amount{Units: 100},
+				quantity:   &grpcoin.Amount{Units: 3},
+				allowShort: true,
+			},
+			code: codes.OK,
+			want: &Portfolio{
+				CashUSD:   Amount{Units: 1300},
+				Positions: map[string]Amount{"BTC": {Units: -2}},
+				HasShorts: true,
+			},
+		},
+		{name: "buy back short position",
+			args: args{p: &Portfolio{CashUSD: Amount{Units: 1300}, Positions: map[string]Amount{"BTC": {Units: -2}}, HasShorts: true},
+				action:     grpcoin.TradeAction_BUY,
+				ticker:     "BTC",
+				quote:      &grpcoin.Amount{Units: 150},
+				quantity:   &grpcoin.Amount{Units: 2},
+				allowShort: true,
+			},
+			code: codes.OK,
+			want: &Portfolio{
+				CashUSD:   Amount{Units: 1000},
+				Positions: map[string]Amount{},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := makeTrade(tt.args.p, tt.args.action, tt.args.ticker, tt.args.quote, tt.args.quantity)
+			price, fee, err := makeTrade(tt.args.p, tt.args.action, tt.args.ticker, tt.args.quote, tt.args.quantity,
+				tt.args.fees, tt.args.maker, tt.args.allowShort)
 			if status.Code(err) != tt.code {
 				t.Errorf("makeTrade() error = %v, want code=%v", err, tt.code)
 			}
@@ -208,7 +350,59 @@ func Test_makeTrade(t *testing.T) {
 				if diff != "" {
 					t.Fatal(diff)
 				}
+				if tt.wantPrice != nil && price != *tt.wantPrice {
+					t.Errorf("wrong executed price: got=%v want=%v", price, *tt.wantPrice)
+				}
+				if fee != tt.wantFee {
+					t.Errorf("wrong fee: got=%v want=%v", fee, tt.wantFee)
+				}
 			}
 		})
 	}
 }
+
+func TestPortfolio_reserve(t *testing.T) {
+	p := Portfolio{
+		CashUSD:   Amount{Units: 1000},
+		Positions: map[string]Amount{"BTC": {Units: 2}},
+	}
+	buy := Order{Ticker: "BTC", Action: grpcoin.TradeAction_BUY, Reserved: Amount{Units: 600}}
+	sell := Order{Ticker: "BTC", Action: grpcoin.TradeAction_SELL, Reserved: Amount{Units: 1, Nanos: 500_000_000}}
+
+	if err := p.reserve(buy); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.reserve(sell); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(Portfolio{
+		CashUSD:           Amount{Units: 1000},
+		Positions:         map[string]Amount{"BTC": {Units: 2}},
+		ReservedCashUSD:   Amount{Units: 600},
+		ReservedPositions: map[string]Amount{"BTC": {Units: 1, Nanos: 500_000_000}},
+	}, p); diff != "" {
+		t.Fatal(diff)
+	}
+	if got := p.AvailableCashUSD(); got != (Amount{Units: 400}) {
+		t.Fatalf("wrong available cash: %v", got)
+	}
+	if got := p.AvailablePosition("BTC"); got != (Amount{Nanos: 500_000_000}) {
+		t.Fatalf("wrong available position: %v", got)
+	}
+
+	if err := p.reserve(buy); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for insufficient cash, got: %v", err)
+	}
+	if err := p.reserve(sell); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for insufficient positions, got: %v", err)
+	}
+
+	p.release(buy)
+	p.release(sell)
+	if diff := cmp.Diff(Portfolio{
+		CashUSD:   Amount{Units: 1000},
+		Positions: map[string]Amount{"BTC": {Units: 2}},
+	}, p); diff != "" {
+		t.Fatal(diff)
+	}
+}
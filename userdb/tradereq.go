@@ -0,0 +1,68 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TradeRequest records the outcome of a trade made with a client order id,
+// so that the retries of the request return the same result. Expired records
+// are ignored, and deleted by DeleteExpiredTradeRequests.
+type TradeRequest struct {
+	Trade     TradeRecord `firestore:"trade"`
+	Portfolio Portfolio   `firestore:"portfolio"`
+	ExpiresAt time.Time   `firestore:"expiresAt"`
+}
+
+func (u *UserDB) clientOrderIDTTL() time.Duration {
+	if u.ClientOrderIDTTL > 0 {
+		return u.ClientOrderIDTTL
+	}
+	return defaultClientOrderIDTTL
+}
+
+// DeleteExpiredTradeRequests deletes the user's trade request records that
+// have expired as of now.
+func (u *UserDB) DeleteExpiredTradeRequests(ctx context.Context, uid string, now time.Time) error {
+	ctx, s := u.T.Start(ctx, "delete expired trade requests")
+	defer s.End()
+	if err := u.DB.DeleteTradeRequests(ctx, uid, now); err != nil {
+		s.RecordError(err)
+		return fmt.Errorf("failed to delete expired trade requests: %w", err)
+	}
+	return nil
+}
+
+// readTradeRequest reads the trade request record in tx. It returns false if
+// the record does not exist or has expired as of now.
+func readTradeRequest(tx Tx, uid, clientOrderID string, now time.Time) (TradeRequest, bool, error) {
+	v, ok, err := tx.GetTradeRequest(uid, clientOrderID)
+	if err != nil {
+		return TradeRequest{}, false, fmt.Errorf("failed to read trade request: %w", err)
+	}
+	if !ok || !now.Before(v.ExpiresAt) {
+		return TradeRequest{}, false, nil
+	}
+	return v, true, nil
+}
+
+// sameOrder reports whether v and tr are trades for the same order
+// regardless of their execution price and time.
+func (v TradeRecord) sameOrder(tr TradeRecord) bool {
+	return v.Ticker == tr.Ticker && v.Action == tr.Action && v.Size == tr.Size
+}
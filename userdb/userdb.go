@@ -16,19 +16,19 @@ package userdb
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
-	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
-	"cloud.google.com/go/firestore"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
-	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+	"github.com/grpcoin/grpcoin/realtimequote"
 	"github.com/grpcoin/grpcoin/tradecounters"
 	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -39,17 +39,8 @@ import (
 type ctxUserRecordKey struct{}
 
 const (
-	fsUserCol      = "users"      // users collection
-	fsTradesCol    = "orders"     // sub-collection for user
-	fsValueHistCol = "valuations" // sub-collection for user's portfolio value over time
-
-	maxTradeHistory         = 300  // keep latest N trade history records
-	tradeHistoryRotateCheck = 0.01 // probability of checking & purging excess trade history records
-)
-
-var (
-	// r is for probabilistic actions
-	r = rand.New(rand.NewSource(time.Now().UnixNano()))
+	defaultTradesPageSize   = 50 // trades shown per page of user's trade history
+	defaultClientOrderIDTTL = time.Hour * 24
 )
 
 type User struct {
@@ -62,11 +53,31 @@ type User struct {
 		LastTrade  time.Time
 		TradeCount int
 	}
+	// LedgerSeq is the sequence number of the last entry in the user's
+	// ledger (zero if the ledger is empty).
+	LedgerSeq int
 }
 
 type Portfolio struct {
 	CashUSD   Amount
 	Positions map[string]Amount
+
+	// ReservedCashUSD and ReservedPositions are held for open orders, and
+	// cannot be used by other trades.
+	ReservedCashUSD   Amount
+	ReservedPositions map[string]Amount
+
+	// HasShorts is set if any of the positions are negative, so that the
+	// portfolios to check for liquidation can be queried.
+	HasShorts bool
+
+	// CostBasis is the average price paid per coin of the long positions
+	// (or received per coin of the short positions), including the fees.
+	// Positions opened before the cost basis was tracked are missing, until
+	// they are backfilled.
+	CostBasis map[string]Amount
+	// RealizedPnL is the profit (or loss) of the closed positions.
+	RealizedPnL Amount
 }
 
 type Amount struct {
@@ -89,6 +100,20 @@ type TradeRecord struct {
 	Action grpcoin.TradeAction `firestore:"action"`
 	Size   Amount              `firestore:"size"`
 	Price  Amount              `firestore:"price"`
+
+	// OrderID is set if the trade is executed as a result of an order.
+	OrderID string `firestore:"orderID,omitempty"`
+	// TriggerPrice is set if the trade is executed by a triggered order (e.g.
+	// stop-loss) and can differ from the executed Price.
+	TriggerPrice *Amount `firestore:"triggerPrice,omitempty"`
+	// ClientOrderID is set if the trade request specified an id to make
+	// retries of the trade idempotent.
+	ClientOrderID string `firestore:"clientOrderID,omitempty"`
+	// Fee is the trading fee charged for the trade.
+	Fee Amount `firestore:"fee"`
+	// Liquidation is set if the trade is made to close a position because
+	// the portfolio fell below the maintenance margin.
+	Liquidation bool `firestore:"liquidation,omitempty"`
 }
 
 // ValuationHistory represents user's portfolio value at a particular time.
@@ -98,10 +123,45 @@ type ValuationHistory struct {
 }
 
 type UserDB struct {
-	DB           *firestore.Client
+	DB           Store
 	Cache        ProfileCache
 	TradeCounter *tradecounters.TradeCounter
 	T            trace.Tracer
+
+	// Fees are the trading costs applied to the trades.
+	Fees FeeSchedule
+
+	// Margin is the short selling policy. If short selling is enabled,
+	// Quotes must be set to value the short positions at trade time.
+	Margin MarginPolicy
+	Quotes realtimequote.QuoteProvider
+
+	// ClientOrderIDTTL is how long a trade is remembered by its client order
+	// id, so that retries within this window are not executed again.
+	// Defaults to 24 hours.
+	ClientOrderIDTTL time.Duration
+
+	// TradesPageSize is the number of trades returned per page by
+	// UserTrades. Defaults to 50.
+	TradesPageSize int
+
+	// Changes is notified after users' portfolios change, if set.
+	Changes PortfolioChangeNotifier
+}
+
+// PortfolioChangeNotifier is notified after a user's portfolio changes (e.g.
+// trades, reservations of orders).
+type PortfolioChangeNotifier interface {
+	PortfolioChanged(ctx context.Context, uid string) error
+}
+
+func (u *UserDB) portfolioChanged(ctx context.Context, uid string) {
+	if u.Changes == nil {
+		return
+	}
+	if err := u.Changes.PortfolioChanged(ctx, uid); err != nil {
+		ctxzap.Extract(ctx).Warn("failed to notify portfolio change", zap.Error(err))
+	}
 }
 
 func (u *UserDB) Create(ctx context.Context, au auth.AuthenticatedUser) error {
@@ -111,8 +171,7 @@ func (u *UserDB) Create(ctx context.Context, au auth.AuthenticatedUser) error {
 		CreatedAt:   time.Now(),
 	}
 	setupGamePortfolio(&newUser)
-	_, err := u.DB.Collection(fsUserCol).Doc(au.DBKey()).Create(ctx, newUser)
-	if err != nil {
+	if err := u.DB.CreateUser(ctx, newUser); err != nil {
 		return err
 	}
 
@@ -122,38 +181,16 @@ func (u *UserDB) Create(ctx context.Context, au auth.AuthenticatedUser) error {
 }
 
 func (u *UserDB) Get(ctx context.Context, userID string) (User, bool, error) {
-	doc, err := u.DB.Collection(fsUserCol).Doc(userID).Get(ctx)
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			return User{}, false, nil
-		}
-		return User{}, false, status.Errorf(codes.Internal, "failed to retrieve user: %v", err)
-	}
-	var uv User
-	if err := doc.DataTo(&uv); err != nil {
-		return User{}, false, fmt.Errorf("failed to unpack user record %q: %w", userID, err)
-	}
-	return uv, true, nil
+	return u.DB.GetUser(ctx, userID)
 }
 
 func (u *UserDB) GetAll(ctx context.Context) ([]User, error) {
-	var out []User
-	iter := u.DB.Collection(fsUserCol).Documents(ctx)
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			return nil, err
-		}
-		var u User
-		if err := doc.DataTo(&u); err != nil {
-			return nil, err
-		}
-		out = append(out, u)
-	}
-	return out, nil
+	return u.DB.Users(ctx)
+}
+
+// UsersWithShorts returns the users holding short positions.
+func (u *UserDB) UsersWithShorts(ctx context.Context) ([]User, error) {
+	return u.DB.UsersWithShorts(ctx)
 }
 
 func (u *UserDB) EnsureAccountExists(ctx context.Context, au auth.AuthenticatedUser) (User, error) {
@@ -196,37 +233,132 @@ func (u *UserDB) EnsureAccountExistsInterceptor() grpc_auth.AuthFunc {
 	}
 }
 
+// Trade executes the trade at the quote price and returns the executed trade
+// with the resulting portfolio. If clientOrderID is not empty and a trade with
+// the same id was executed within the ClientOrderIDTTL, the original trade and
+// portfolio are returned instead of trading again.
 func (u *UserDB) Trade(ctx context.Context, uid string, ticker string, action grpcoin.TradeAction,
-	quote, quantity *grpcoin.Amount) (Portfolio, error) {
+	quote, quantity *grpcoin.Amount, clientOrderID string) (TradeRecord, Portfolio, error) {
+	return u.trade(ctx, uid, TradeRecord{
+		Ticker:        ticker,
+		Action:        action,
+		Size:          ToAmount(toDecimal(quantity)),
+		Price:         ToAmount(toDecimal(quote)),
+		ClientOrderID: clientOrderID,
+	}, false, nil)
+}
+
+// tradeTxHook is invoked in the trade transaction before the user record is
+// read, so it can perform its own reads. The returned write func is invoked
+// with the portfolio before the trade is made on it (e.g. to release the
+// reservations of the order being filled), and can perform additional writes.
+type tradeTxHook func(tx Tx) (write func(p *Portfolio) error, err error)
+
+// trade executes the trade described by tr (except its date and fee) on the
+// user's portfolio with the fees applied and records it in the trade history.
+// The price of tr is the quote price, it is updated with the executed price.
+// If hook is not nil, it is invoked as part of the same transaction.
+func (u *UserDB) trade(ctx context.Context, uid string, tr TradeRecord, maker bool, hook tradeTxHook) (TradeRecord, Portfolio, error) {
 	subCtx, s := u.T.Start(ctx, "trade tx")
-	ref := u.DB.Collection("users").Doc(uid)
-	var resultingPortfolio Portfolio
-	err := u.DB.RunTransaction(subCtx, func(ctx context.Context, tx *firestore.Transaction) error {
-		doc, err := tx.Get(ref)
+	ttl := u.clientOrderIDTTL()
+	fees, margin := u.Fees, u.Margin
+	var prices map[string]Amount
+	if margin.Enabled() {
+		var err error
+		if prices, err = u.shortPrices(subCtx, uid, tr.Ticker, tr.Price); err != nil {
+			s.End()
+			return TradeRecord{}, Portfolio{}, err
+		}
+	}
+	var (
+		executed           TradeRecord
+		resultingPortfolio Portfolio
+		duplicate          bool
+	)
+	err := u.DB.RunTx(subCtx, func(ctx context.Context, tx Tx) error {
+		var hookWrite func(p *Portfolio) error
+		if hook != nil {
+			w, err := hook(tx)
+			if err != nil {
+				return err
+			}
+			hookWrite = w
+		}
+		now := time.Now().UTC()
+		if tr.ClientOrderID != "" {
+			prev, ok, err := readTradeRequest(tx, uid, tr.ClientOrderID, now)
+			if err != nil {
+				return err
+			}
+			if ok {
+				if !prev.Trade.sameOrder(tr) {
+					return status.Errorf(codes.AlreadyExists,
+						"client order id %q was used for a different trade", tr.ClientOrderID)
+				}
+				executed, resultingPortfolio, duplicate = prev.Trade, prev.Portfolio, true
+				return nil
+			}
+		}
+		u, err := tx.GetUser(uid)
 		if err != nil {
-			return fmt.Errorf("failed to read user record for tx: %w", err)
+			return err
+		}
+		before := u.Portfolio.clone()
+		if hookWrite != nil {
+			if err := hookWrite(&u.Portfolio); err != nil {
+				return err
+			}
 		}
-		var u User
-		if err := doc.DataTo(&u); err != nil {
-			return fmt.Errorf("failed to unpack user record into struct: %w", err)
+		var excessBefore decimal.Decimal
+		if margin.Enabled() {
+			if err := checkPrices(u.Portfolio, prices); err != nil {
+				return err
+			}
+			if excessBefore, err = margin.excess(u.Portfolio, prices); err != nil {
+				return err
+			}
 		}
-		if err := makeTrade(&u.Portfolio, action, ticker, quote, quantity); err != nil {
+		posBefore := u.Portfolio.Positions[tr.Ticker]
+		price, fee, err := makeTrade(&u.Portfolio, tr.Action, tr.Ticker, tr.Price.V(), tr.Size.V(), fees, maker, margin.Enabled())
+		if err != nil {
 			return err
 		}
-		u.TradeStats.LastTrade = time.Now()
+		if margin.Enabled() {
+			if err := margin.checkMargin(excessBefore, u.Portfolio, prices); err != nil {
+				return err
+			}
+		}
+		u.Portfolio.updateCostBasis(tr.Ticker, tr.Action, posBefore, tr.Size, price, fee)
+		u.TradeStats.LastTrade = now
 		u.TradeStats.TradeCount++
+		executed = tr
+		executed.Date = now
+		executed.Price = price
+		executed.Fee = fee
 		resultingPortfolio = u.Portfolio
-		return tx.Set(ref, u)
-	}, firestore.MaxAttempts(1))
+		if tr.ClientOrderID != "" {
+			if err := tx.SetTradeRequest(uid, tr.ClientOrderID, TradeRequest{
+				Trade:     executed,
+				Portfolio: u.Portfolio,
+				ExpiresAt: now.Add(ttl),
+			}); err != nil {
+				return err
+			}
+		}
+		if err := appendLedger(tx, &u, before, LedgerEntry{
+			Date: now, Type: LedgerTrade, Trade: &executed, OrderID: executed.OrderID}); err != nil {
+			return err
+		}
+		return tx.SetUser(u)
+	})
 	s.End()
 
-	if err != nil {
-		return resultingPortfolio, err
+	if err != nil || duplicate {
+		return executed, resultingPortfolio, err
 	}
 
 	subCtx, s = u.T.Start(ctx, "log trade")
-	err = u.recordTradeHistory(subCtx, uid, time.Now().UTC(), ticker, action,
-		ToAmount(toDecimal(quantity)), ToAmount(toDecimal(quote)))
+	err = u.recordTradeHistory(subCtx, uid, executed)
 	if err != nil {
 		s.RecordError(err)
 		ctxzap.Extract(ctx).Warn("failed to record trade history", zap.Error(err))
@@ -239,111 +371,115 @@ func (u *UserDB) Trade(ctx context.Context, uid string, ticker string, action gr
 		ctxzap.Extract(ctx).Warn("failed to invalidate trade history cache", zap.Error(err))
 	}
 
-	// probabilistically delete unnecessary trade history records
-	if r.Float64() < tradeHistoryRotateCheck {
-		subCtx, s := u.T.Start(ctx, "rotate trade history")
-		defer s.End()
-		if err := u.RotateTradeHistory(subCtx, uid, maxTradeHistory); err != nil {
-			s.RecordError(err)
-			ctxzap.Extract(ctx).Warn("failed to rotate trade history", zap.Error(err))
-		}
-	}
+	u.portfolioChanged(ctx, uid)
 
 	subCtx, s = u.T.Start(ctx, "update trade stats")
-	tradeAmount, _ := toDecimal(quantity).Mul(toDecimal(quote)).Float64()
-	if err := u.TradeCounter.IncrTrades(subCtx, time.Now(), tradeAmount); err != nil {
+	tradeAmount, _ := executed.Size.F().Mul(executed.Price.F()).Float64()
+	fee, _ := executed.Fee.F().Float64()
+	if err := u.TradeCounter.IncrTrades(subCtx, time.Now(), tradeAmount, fee); err != nil {
 		s.RecordError(err)
 		ctxzap.Extract(ctx).Warn("failed to update trade stats", zap.Error(err))
 	}
 	s.End()
 
-	return resultingPortfolio, nil // do not block trades on trade history bookkeeping
+	return executed, resultingPortfolio, nil // do not block trades on trade history bookkeeping
 }
 
-func (u *UserDB) recordTradeHistory(ctx context.Context, uid string,
-	t time.Time, ticker string, action grpcoin.TradeAction, size, price Amount) error {
-	id := t.Format(time.RFC3339Nano)
-	_, err := u.DB.Collection(fsUserCol).Doc(uid).Collection(fsTradesCol).Doc(id).Create(ctx, TradeRecord{
-		Date:   t,
-		Ticker: ticker,
-		Action: action,
-		Size:   size,
-		Price:  price,
-	})
-	return err
+func (u *UserDB) recordTradeHistory(ctx context.Context, uid string, tr TradeRecord) error {
+	return u.DB.AddTrade(ctx, uid, tr)
 }
 
-func (u *UserDB) UserTrades(ctx context.Context, uid string) ([]TradeRecord, error) {
+// tradeKey returns the key of the trade in the trade history.
+func tradeKey(tr TradeRecord) string { return tr.Date.Format(time.RFC3339Nano) }
+
+// UserTrades returns a page of user's trades (most recent first) with the
+// TradesPageSize, starting after the pageToken returned with the previous
+// page. The returned page token is empty on the last page. Only the first
+// page (with empty pageToken) is cached, as it is the one shown on profiles.
+func (u *UserDB) UserTrades(ctx context.Context, uid string, pageToken string) ([]TradeRecord, string, error) {
 	ctx, s := u.T.Start(ctx, "trade history")
 	defer s.End()
 
-	if v, ok, err := u.Cache.GetTrades(ctx, uid); err != nil {
-		return nil, fmt.Errorf("failed to query trade history cache: %v", err)
-	} else if ok {
-		return v, nil
+	if pageToken == "" {
+		if v, ok, err := u.Cache.GetTrades(ctx, uid); err != nil {
+			return nil, "", fmt.Errorf("failed to query trade history cache: %v", err)
+		} else if ok {
+			return v.Trades, v.NextPageToken, nil
+		}
 	}
 
-	var out []TradeRecord
-	iter := u.DB.Collection(fsUserCol).Doc(uid).Collection(fsTradesCol).Documents(ctx)
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			s.RecordError(err)
-			return nil, err
-		}
-		var v TradeRecord
-		if err := doc.DataTo(&v); err != nil {
-			s.RecordError(err)
-			return nil, err
+	out, next, err := u.DB.TradeHistory(ctx, uid, TradeFilter{}, u.tradesPageSize(), pageToken)
+	if err != nil {
+		s.RecordError(err)
+		return nil, "", err
+	}
+
+	if pageToken == "" {
+		if err := u.Cache.SaveTrades(ctx, uid, TradePage{Trades: out, NextPageToken: next}); err != nil {
+			ctxzap.Extract(ctx).Warn("failed to save trade history to cache", zap.String("uid", uid))
 		}
-		out = append(out, v)
 	}
+	return out, next, nil
+}
 
-	if err := u.Cache.SaveTrades(ctx, uid, out); err != nil {
-		ctxzap.Extract(ctx).Warn("failed to save trade history to cache", zap.String("uid", uid))
+func (u *UserDB) tradesPageSize() int {
+	if u.TradesPageSize > 0 {
+		return u.TradesPageSize
 	}
-	return out, nil
+	return defaultTradesPageSize
+}
+
+// TradeFilter narrows down the trades returned by TradeHistory. Zero values
+// match all trades.
+type TradeFilter struct {
+	Ticker string
+	Since  time.Time // inclusive
+	Until  time.Time // exclusive
 }
 
-func (u *UserDB) RotateTradeHistory(ctx context.Context, uid string, maxHist int) error {
-	it := u.DB.Collection(fsUserCol).Doc(uid).Collection(fsTradesCol).
-		OrderBy("date", firestore.Desc).Offset(maxHist).Documents(ctx)
-	return firestoreutil.BatchDeleteAll(ctx, u.DB, it)
+// match reports whether the trade matches the filter.
+func (f TradeFilter) match(tr TradeRecord) bool {
+	return (f.Ticker == "" || tr.Ticker == f.Ticker) &&
+		(f.Since.IsZero() || !tr.Date.Before(f.Since)) &&
+		(f.Until.IsZero() || tr.Date.Before(f.Until))
+}
+
+// TradeHistory returns a page of user's trades (most recent first) starting
+// after the pageToken returned with the previous page, or from the beginning
+// if pageToken is empty. The returned page token is empty on the last page.
+// Unlike UserTrades, results are not cached.
+func (u *UserDB) TradeHistory(ctx context.Context, uid string, f TradeFilter, pageSize int, pageToken string) ([]TradeRecord, string, error) {
+	ctx, s := u.T.Start(ctx, "trade history page")
+	defer s.End()
+
+	out, next, err := u.DB.TradeHistory(ctx, uid, f, pageSize, pageToken)
+	if err != nil {
+		s.RecordError(err)
+	}
+	return out, next, err
 }
 
-func (u *UserDB) UserValuationHistory(ctx context.Context, uid string) ([]ValuationHistory, error) {
-	// TODO implement caching around this with a hourly key and precise ttl.
+// UserValuationHistory returns user's valuation history of the range in
+// ascending order.
+func (u *UserDB) UserValuationHistory(ctx context.Context, uid string, rng ValuationRange) ([]ValuationHistory, error) {
 	ctx, s := u.T.Start(ctx, "user valuation history")
 	defer s.End()
+	if rng == "" {
+		rng = ValuationRangeMonth
+	}
 
-	if v, ok, err := u.Cache.GetValuation(ctx, uid, time.Now()); err != nil {
+	if v, ok, err := u.Cache.GetValuation(ctx, uid, rng, time.Now()); err != nil {
 		return nil, fmt.Errorf("failed to retrieve valuation history: %v", err)
 	} else if ok {
 		return v, nil
 	}
 
-	var out []ValuationHistory
-	iter := u.DB.Collection(fsUserCol).Doc(uid).Collection(fsValueHistCol).Documents(ctx)
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			s.RecordError(err)
-			return nil, err
-		}
-		var v ValuationHistory
-		if err := doc.DataTo(&v); err != nil {
-			s.RecordError(err)
-			return nil, err
-		}
-		out = append(out, v)
+	out, err := u.valuations(ctx, uid, rng, time.Now())
+	if err != nil {
+		s.RecordError(err)
+		return nil, err
 	}
-	if err := u.Cache.SaveValuation(ctx, uid, time.Now(), out); err != nil {
+	if err := u.Cache.SaveValuation(ctx, uid, rng, time.Now(), out); err != nil {
 		ctxzap.Extract(ctx).Warn("failed to save portfolio valuation history", zap.String("uid", uid), zap.Int("size", len(out)))
 	}
 	return out, nil
@@ -351,20 +487,9 @@ func (u *UserDB) UserValuationHistory(ctx context.Context, uid string) ([]Valuat
 
 func canonicalizeValuationHistoryDBKey(t time.Time) string { return t.UTC().Format(time.RFC3339) }
 
+// SetUserValuationHistory records the user's hourly valuation.
 func (u *UserDB) SetUserValuationHistory(ctx context.Context, uid string, v ValuationHistory) error {
-	_, err := u.DB.Collection(fsUserCol).Doc(uid).Collection(fsValueHistCol).
-		Doc(canonicalizeValuationHistoryDBKey(v.Date)).Create(ctx, v)
-	if err != nil && status.Code(err) != codes.AlreadyExists {
-		return err
-	}
-	return nil
-}
-
-func (u *UserDB) RotateUserValuationHistory(ctx context.Context, uid string, deleteBefore time.Time) error {
-	// TODO create an index for users/*/date ASC
-	it := u.DB.Collection(fsUserCol).Doc(uid).Collection(fsValueHistCol).
-		Where("date", "<", deleteBefore).Documents(ctx)
-	return firestoreutil.BatchDeleteAll(ctx, u.DB, it)
+	return u.DB.AddValuation(ctx, uid, HourlyValuations, v)
 }
 
 func UserRecordFromContext(ctx context.Context) (User, bool) {
@@ -376,3 +501,25 @@ func UserRecordFromContext(ctx context.Context) (User, bool) {
 func WithUserRecord(ctx context.Context, u User) context.Context {
 	return context.WithValue(ctx, ctxUserRecordKey{}, u)
 }
+
+// encodePageToken returns an opaque page token pointing to the record with
+// the specified time and document id.
+func encodePageToken(t time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%s", t.UnixNano(), id)))
+}
+
+func decodePageToken(v string) (time.Time, string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return time.Time{}, "", status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	parts := strings.SplitN(string(b), "/", 2)
+	if len(parts) != 2 {
+		return time.Time{}, "", status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	ns, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, "", status.Error(codes.InvalidArgument, "invalid page token")
+	}
+	return time.Unix(0, ns).UTC(), parts[1], nil
+}
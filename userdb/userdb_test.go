@@ -16,6 +16,7 @@ package userdb
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 
 	"github.com/grpcoin/grpcoin/api/grpcoin"
 	"github.com/grpcoin/grpcoin/apiserver/firestoreutil"
+	"github.com/grpcoin/grpcoin/apiserver/pgutil"
 	"github.com/grpcoin/grpcoin/testutil"
 	"github.com/grpcoin/grpcoin/tradecounters"
 )
@@ -41,283 +43,514 @@ func (t testUser) DisplayName() string { return t.name }
 func (t testUser) ProfileURL() string  { return "https://" + t.name }
 
 func TestGetUser_notFound(t *testing.T) {
-	ctx := context.Background()
-	udb := &UserDB{DB: firestoreutil.StartTestEmulator(t, ctx),
-		T:            trace.NewNoopTracerProvider().Tracer(""),
-		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
-		Cache:        MockProfileCache{}}
-	u, ok, err := udb.Get(ctx, "foo")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if ok {
-		t.Fatalf("was not expecting to find user: %#v", u)
-	}
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		u, ok, err := udb.Get(ctx, "foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Fatalf("was not expecting to find user: %#v", u)
+		}
+	})
 }
 
 func TestNewUser(t *testing.T) {
-	ctx := context.Background()
-	udb := &UserDB{DB: firestoreutil.StartTestEmulator(t, ctx),
-		T:            trace.NewNoopTracerProvider().Tracer(""),
-		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
-		Cache:        MockProfileCache{}}
-	tu := testUser{id: "foobar", name: "ab"}
-
-	err := udb.Create(ctx, tu)
-	if err != nil {
-		t.Fatal(err)
-	}
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "foobar", name: "ab"}
+
+		err := udb.Create(ctx, tu)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	uv, ok, err := udb.Get(ctx, "foobar")
-	if err != nil {
-		t.Fatal(err)
-	}
-	if !ok {
-		t.Fatal("not found created user")
-	}
+		uv, ok, err := udb.Get(ctx, "foobar")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("not found created user")
+		}
 
-	expected := User{
-		ID:          "foobar",
-		DisplayName: "ab",
-		ProfileURL:  "https://ab",
-		Portfolio: Portfolio{CashUSD: Amount{Units: 100_000},
-			Positions: make(map[string]Amount)},
-	}
-	if diff := cmp.Diff(uv, expected,
-		cmpopts.IgnoreFields(User{}, "CreatedAt")); diff != "" {
-		t.Fatal(diff)
-	}
+		expected := User{
+			ID:          "foobar",
+			DisplayName: "ab",
+			ProfileURL:  "https://ab",
+			Portfolio: Portfolio{CashUSD: Amount{Units: 100_000},
+				Positions: make(map[string]Amount)},
+		}
+		if diff := cmp.Diff(uv, expected,
+			cmpopts.IgnoreFields(User{}, "CreatedAt")); diff != "" {
+			t.Fatal(diff)
+		}
 
-	vals, err := udb.UserValuationHistory(ctx, "foobar")
-	if len(vals) != 1 {
-		t.Fatalf("new user should have 1 valuation record: %#v", vals)
-	}
+		vals, err := udb.UserValuationHistory(ctx, "foobar", ValuationRangeMonth)
+		if len(vals) != 1 {
+			t.Fatalf("new user should have 1 valuation record: %#v", vals)
+		}
+	})
 }
 
 func TestEnsureAccountExists(t *testing.T) {
-	ctx := context.Background()
-	udb := &UserDB{DB: firestoreutil.StartTestEmulator(t, ctx),
-		T:            trace.NewNoopTracerProvider().Tracer(""),
-		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
-		Cache:        MockProfileCache{}}
-	tu := testUser{id: "testuser", name: "abc"}
-
-	u, err := udb.EnsureAccountExists(ctx, tu)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if u.ID == "" {
-		t.Fatal("id should not be empty")
-	}
-	u2, err := udb.EnsureAccountExists(ctx, tu)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if diff := cmp.Diff(u, u2); diff != "" {
-		t.Fatal(diff)
-	}
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+
+		u, err := udb.EnsureAccountExists(ctx, tu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.ID == "" {
+			t.Fatal("id should not be empty")
+		}
+		u2, err := udb.EnsureAccountExists(ctx, tu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(u, u2); diff != "" {
+			t.Fatal(diff)
+		}
+	})
 }
 
 func TestValuationHistory(t *testing.T) {
-	ctx := context.Background()
-	udb := &UserDB{DB: firestoreutil.StartTestEmulator(t, ctx),
-		T:            trace.NewNoopTracerProvider().Tracer(""),
-		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
-		Cache:        MockProfileCache{}}
-	tu := testUser{id: "testuser", name: "abc"}
-	u, err := udb.EnsureAccountExists(ctx, tu)
-	if err != nil {
-		t.Fatal(err)
-	}
-	ti := time.Date(2050, 03, 12, 0, 0, 0, 0, time.UTC)
-	v1 := ValuationHistory{Date: ti, Value: Amount{Units: 5555}}
-	v2 := ValuationHistory{Date: ti, Value: Amount{Units: 6666}}
-	if err := udb.SetUserValuationHistory(ctx, u.ID, v1); err != nil {
-		t.Fatal(err)
-	}
-	if err := udb.SetUserValuationHistory(ctx, u.ID, v2); err != nil {
-		t.Fatal(err)
-	}
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		u, err := udb.EnsureAccountExists(ctx, tu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ti := time.Date(2050, 03, 12, 0, 0, 0, 0, time.UTC)
+		v1 := ValuationHistory{Date: ti, Value: Amount{Units: 5555}}
+		v2 := ValuationHistory{Date: ti, Value: Amount{Units: 6666}}
+		if err := udb.SetUserValuationHistory(ctx, u.ID, v1); err != nil {
+			t.Fatal(err)
+		}
+		if err := udb.SetUserValuationHistory(ctx, u.ID, v2); err != nil {
+			t.Fatal(err)
+		}
 
-	v, err := udb.UserValuationHistory(ctx, u.ID)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if len(v) > 1 {
-		v = v[1:] // remove signup record
-	}
-	expected := []ValuationHistory{v1}
-	diff := cmp.Diff(expected, v)
-	if diff != "" {
-		t.Fatal(diff)
-	}
+		v, err := udb.UserValuationHistory(ctx, u.ID, ValuationRangeMonth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(v) > 1 {
+			v = v[1:] // remove signup record
+		}
+		expected := []ValuationHistory{v1}
+		diff := cmp.Diff(expected, v)
+		if diff != "" {
+			t.Fatal(diff)
+		}
+	})
 }
 
-func TestRotateUserValuationHistory(t *testing.T) {
-	ctx := context.Background()
-	udb := &UserDB{DB: firestoreutil.StartTestEmulator(t, ctx),
-		T:            trace.NewNoopTracerProvider().Tracer(""),
-		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
-		Cache:        MockProfileCache{}}
-	tu := testUser{id: "testuser", name: "abc"}
-	u, err := udb.EnsureAccountExists(ctx, tu)
-	if err != nil {
-		t.Fatal(err)
-	}
-	d := time.Date(2050, time.April, 23, 0, 0, 0, 0, time.UTC)
-	for i := 1; i <= 20; i++ {
-		dv := d.Add(time.Hour * time.Duration(i))
-		if err := udb.SetUserValuationHistory(ctx, u.ID,
-			ValuationHistory{Date: dv}); err != nil {
+func TestRollupUserValuationHistory(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		u, err := udb.EnsureAccountExists(ctx, testUser{id: "testuser", name: "abc"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// remove signup record
+		if err := db.DeleteValuations(ctx, u.ID, HourlyValuations, time.Now().Add(time.Hour)); err != nil {
 			t.Fatal(err)
 		}
-	}
 
-	if err := udb.RotateUserValuationHistory(ctx, u.ID, d.Add(time.Hour*15)); err != nil {
-		t.Fatal(err)
-	}
+		// hourly valuations of 15 days starting on a Monday, valued at the
+		// number of hours since the start
+		d := time.Date(2050, time.April, 4, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 24*15; i++ {
+			if err := udb.SetUserValuationHistory(ctx, u.ID, ValuationHistory{
+				Date: d.Add(time.Hour * time.Duration(i)), Value: Amount{Units: int64(i)}}); err != nil {
+				t.Fatal(err)
+			}
+		}
 
-	v, err := udb.UserValuationHistory(ctx, u.ID)
-	if err != nil {
-		t.Fatal(err)
-	}
-	expected := []ValuationHistory{
-		{Date: d.Add(time.Hour * 15)},
-		{Date: d.Add(time.Hour * 16)},
-		{Date: d.Add(time.Hour * 17)},
-		{Date: d.Add(time.Hour * 18)},
-		{Date: d.Add(time.Hour * 19)},
-		{Date: d.Add(time.Hour * 20)},
-	}
-	diff := cmp.Diff(expected, v)
-	if diff != "" {
-		t.Fatal(diff)
-	}
+		// roll up until the middle of day 10, twice to ensure it is idempotent
+		for i := 0; i < 2; i++ {
+			if err := udb.RollupUserValuationHistory(ctx, u.ID, d.Add(day*9+time.Hour*12)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		hourly, err := db.Valuations(ctx, u.ID, HourlyValuations, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hourly) != 24*6 || !hourly[0].Date.Equal(d.Add(day*9)) {
+			t.Fatalf("expected hourly valuations of last 6 days, got %d starting at %v", len(hourly), hourly[0].Date)
+		}
+		var expectedDaily []ValuationHistory
+		for i := 0; i < 9; i++ {
+			expectedDaily = append(expectedDaily, ValuationHistory{
+				Date: d.Add(day * time.Duration(i)), Value: Amount{Units: int64(24*i + 23)}})
+		}
+		daily, err := db.Valuations(ctx, u.ID, DailyValuations, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(expectedDaily, daily); diff != "" {
+			t.Fatal(diff)
+		}
+		weekly, err := db.Valuations(ctx, u.ID, WeeklyValuations, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]ValuationHistory{{Date: d, Value: Amount{Units: 24*7 - 1}}}, weekly); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// ranges stitch the rolled up valuations before the hourly ones
+		v, err := udb.UserValuationHistory(ctx, u.ID, ValuationRangeMonth)
+		if err != nil {
+			t.Fatal(err)
+		} else if diff := cmp.Diff(hourly, v); diff != "" {
+			t.Fatal(diff)
+		}
+		// (the daily valuations of year 2050 are older than a year only
+		// after 2051)
+		v, err = udb.valuations(ctx, u.ID, ValuationRangeYear, d.Add(day*300))
+		if err != nil {
+			t.Fatal(err)
+		} else if diff := cmp.Diff(append(expectedDaily, hourly...), v); diff != "" {
+			t.Fatal(diff)
+		}
+		v, err = udb.valuations(ctx, u.ID, ValuationRangeAll, d.Add(day*400))
+		if err != nil {
+			t.Fatal(err)
+		} else if diff := cmp.Diff(append(weekly, hourly...), v); diff != "" {
+			t.Fatal(diff)
+		}
+	})
 }
 
 func TestUserDB_Trade_OrderHistory(t *testing.T) {
-	ctx := context.Background()
-	udb := &UserDB{DB: firestoreutil.StartTestEmulator(t, ctx),
-		T:            trace.NewNoopTracerProvider().Tracer(""),
-		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
-		Cache:        MockProfileCache{}}
-	tu := testUser{id: "testuser", name: "abc"}
-	if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
-		t.Fatal(err)
-	}
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
 
-	// bad order
-	if _, err := udb.Trade(ctx, tu.DBKey(), "BTC",
-		grpcoin.TradeAction_SELL,
-		&grpcoin.Amount{Units: 100},
-		&grpcoin.Amount{Units: 100}); status.Code(err) != codes.InvalidArgument {
-		t.Fatalf("expected invalidargument error: %v", err)
-	}
+		// bad order
+		if _, _, err := udb.Trade(ctx, tu.DBKey(), "BTC",
+			grpcoin.TradeAction_SELL,
+			&grpcoin.Amount{Units: 100},
+			&grpcoin.Amount{Units: 100}, ""); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected invalidargument error: %v", err)
+		}
 
-	// several good orders
-	resultingPortfolio, err := udb.Trade(ctx, tu.DBKey(), "BTC",
-		grpcoin.TradeAction_BUY,
-		&grpcoin.Amount{Units: 100},
-		&grpcoin.Amount{Units: 25})
-	if err != nil {
-		t.Fatal(err)
-	}
-	if diff := cmp.Diff(Portfolio{
-		Positions: map[string]Amount{"BTC": {Units: 25}},
-		CashUSD:   Amount{Units: 97_500},
-	}, resultingPortfolio); diff != "" {
-		t.Fatal(diff)
-	}
+		// several good orders
+		_, resultingPortfolio, err := udb.Trade(ctx, tu.DBKey(), "BTC",
+			grpcoin.TradeAction_BUY,
+			&grpcoin.Amount{Units: 100},
+			&grpcoin.Amount{Units: 25}, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(Portfolio{
+			Positions: map[string]Amount{"BTC": {Units: 25}},
+			CashUSD:   Amount{Units: 97_500},
+			CostBasis: map[string]Amount{"BTC": {Units: 100}},
+		}, resultingPortfolio); diff != "" {
+			t.Fatal(diff)
+		}
 
-	resultingPortfolio, err = udb.Trade(ctx, tu.DBKey(), "ETH",
-		grpcoin.TradeAction_BUY,
-		&grpcoin.Amount{Units: 2000},
-		&grpcoin.Amount{Units: 5})
+		_, resultingPortfolio, err = udb.Trade(ctx, tu.DBKey(), "ETH",
+			grpcoin.TradeAction_BUY,
+			&grpcoin.Amount{Units: 2000},
+			&grpcoin.Amount{Units: 5}, "")
 
-	if err != nil {
-		t.Fatal(err)
-	}
-	if diff := cmp.Diff(Portfolio{
-		Positions: map[string]Amount{
-			"BTC": {Units: 25},
-			"ETH": {Units: 5},
-		},
-		CashUSD: Amount{Units: 87_500},
-	}, resultingPortfolio); diff != "" {
-		t.Fatal(diff)
-	}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(Portfolio{
+			Positions: map[string]Amount{
+				"BTC": {Units: 25},
+				"ETH": {Units: 5},
+			},
+			CashUSD: Amount{Units: 87_500},
+			CostBasis: map[string]Amount{
+				"BTC": {Units: 100},
+				"ETH": {Units: 2000},
+			},
+		}, resultingPortfolio); diff != "" {
+			t.Fatal(diff)
+		}
 
-	resultingPortfolio, err = udb.Trade(ctx, tu.DBKey(), "BTC",
-		grpcoin.TradeAction_SELL,
-		&grpcoin.Amount{Units: 200},
-		&grpcoin.Amount{Units: 25})
-	if err != nil {
-		t.Fatal(err)
-	}
-	if diff := cmp.Diff(Portfolio{
-		Positions: map[string]Amount{
-			"ETH": {Units: 5}},
-		CashUSD: Amount{Units: 92_500},
-	}, resultingPortfolio); diff != "" {
-		t.Fatal(diff)
-	}
+		_, resultingPortfolio, err = udb.Trade(ctx, tu.DBKey(), "BTC",
+			grpcoin.TradeAction_SELL,
+			&grpcoin.Amount{Units: 200},
+			&grpcoin.Amount{Units: 25}, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(Portfolio{
+			Positions: map[string]Amount{
+				"ETH": {Units: 5}},
+			CashUSD:     Amount{Units: 92_500},
+			CostBasis:   map[string]Amount{"ETH": {Units: 2000}},
+			RealizedPnL: Amount{Units: 2500},
+		}, resultingPortfolio); diff != "" {
+			t.Fatal(diff)
+		}
 
-	// validate trade history
-	expectedTrades := []TradeRecord{
-		{Ticker: "BTC", Action: grpcoin.TradeAction_BUY, Size: Amount{25, 0}, Price: Amount{100, 0}},
-		{Ticker: "ETH", Action: grpcoin.TradeAction_BUY, Size: Amount{5, 0}, Price: Amount{2000, 0}},
-		{Ticker: "BTC", Action: grpcoin.TradeAction_SELL, Size: Amount{25, 0}, Price: Amount{200, 0}},
-	}
-	got, err := udb.UserTrades(ctx, "testuser")
-	if err != nil {
-		t.Fatal(err)
-	}
-	diff := cmp.Diff(got, expectedTrades,
-		cmpopts.IgnoreFields(TradeRecord{}, "Date"))
-	if diff != "" {
-		t.Fatal(diff)
-	}
+		// validate trade history (most recent first)
+		expectedTrades := []TradeRecord{
+			{Ticker: "BTC", Action: grpcoin.TradeAction_SELL, Size: Amount{25, 0}, Price: Amount{200, 0}},
+			{Ticker: "ETH", Action: grpcoin.TradeAction_BUY, Size: Amount{5, 0}, Price: Amount{2000, 0}},
+			{Ticker: "BTC", Action: grpcoin.TradeAction_BUY, Size: Amount{25, 0}, Price: Amount{100, 0}},
+		}
+		got, _, err := udb.UserTrades(ctx, "testuser", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		diff := cmp.Diff(got, expectedTrades,
+			cmpopts.IgnoreFields(TradeRecord{}, "Date"))
+		if diff != "" {
+			t.Fatal(diff)
+		}
+	})
 }
 
-func TestRotateOrderHistory(t *testing.T) {
-	ctx := context.Background()
-	udb := &UserDB{DB: firestoreutil.StartTestEmulator(t, ctx),
-		T:            trace.NewNoopTracerProvider().Tracer(""),
-		TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
-		Cache:        MockProfileCache{}}
-	tu := testUser{id: "testuser", name: "abc"}
-	if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
-		t.Fatal(err)
-	}
+func TestUserDB_Trade_clientOrderID(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+		buy := func(price int64, id string) (TradeRecord, Portfolio, error) {
+			return udb.Trade(ctx, tu.DBKey(), "BTC", grpcoin.TradeAction_BUY,
+				&grpcoin.Amount{Units: price}, &grpcoin.Amount{Units: 1}, id)
+		}
 
-	// empty trade history (ensure no leftover from other tests but also works with empty set)
-	if err := udb.RotateTradeHistory(ctx, "testuser", 5); err != nil {
-		t.Fatal(err)
-	}
+		tr, p, err := buy(100, "req1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// retry at a different price returns the original trade
+		tr2, p2, err := buy(200, "req1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tr, tr2, cmpopts.EquateApproxTime(time.Millisecond)); diff != "" {
+			t.Fatalf("retry returned a different trade: %s", diff)
+		}
+		if diff := cmp.Diff(p, p2); diff != "" {
+			t.Fatalf("retry returned a different portfolio: %s", diff)
+		}
+		if _, _, err := udb.Trade(ctx, tu.DBKey(), "BTC", grpcoin.TradeAction_SELL,
+			&grpcoin.Amount{Units: 100}, &grpcoin.Amount{Units: 1}, "req1"); status.Code(err) != codes.AlreadyExists {
+			t.Fatalf("expected AlreadyExists for reused id, got: %v", err)
+		}
+		trades, _, err := udb.UserTrades(ctx, tu.DBKey(), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trades) != 1 || trades[0].ClientOrderID != "req1" {
+			t.Fatalf("expected a single trade recorded, got: %#v", trades)
+		}
 
-	// retain last orders
-	ti := time.Date(2020, 04, 15, 0, 0, 0, 0, time.UTC)
-	for i := 0; i < 20; i++ {
-		if err := udb.recordTradeHistory(ctx, "testuser",
-			ti.Add(time.Second*time.Duration(i)), "", grpcoin.TradeAction_UNDEFINED, Amount{Units: int64(i)}, Amount{}); err != nil {
+		// ids are forgotten after they expire
+		udb.ClientOrderIDTTL = time.Millisecond
+		if _, _, err := buy(100, "req2"); err != nil {
 			t.Fatal(err)
 		}
-	}
-	if err := udb.RotateTradeHistory(ctx, "testuser", 3); err != nil {
-		t.Fatal(err)
-	}
-	hist, err := udb.UserTrades(ctx, "testuser")
-	if err != nil {
-		t.Fatal(err)
-	}
-	expected := []TradeRecord{
-		{Date: ti.Add(17 * time.Second), Size: Amount{Units: 17}},
-		{Date: ti.Add(18 * time.Second), Size: Amount{Units: 18}},
-		{Date: ti.Add(19 * time.Second), Size: Amount{Units: 19}},
-	}
-	if diff := cmp.Diff(expected, hist); diff != "" {
-		t.Fatal(diff)
-	}
+		time.Sleep(time.Millisecond * 10)
+		_, p, err = buy(100, "req2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := (Amount{Units: 3}); p.Positions["BTC"] != expected {
+			t.Fatalf("expected trade to execute again after expiry, position: %v", p.Positions["BTC"])
+		}
+
+		// expired records are deleted
+		time.Sleep(time.Millisecond * 10)
+		if err := udb.DeleteExpiredTradeRequests(ctx, tu.DBKey(), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		var remaining []string
+		if err := db.RunTx(ctx, func(ctx context.Context, tx Tx) error {
+			remaining = nil
+			for _, id := range []string{"req1", "req2"} {
+				_, ok, err := tx.GetTradeRequest(tu.DBKey(), id)
+				if err != nil {
+					return err
+				}
+				if ok {
+					remaining = append(remaining, id)
+				}
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"req1"}, remaining); diff != "" {
+			t.Fatalf("expected only the unexpired request to remain: %s", diff)
+		}
+	})
+}
+
+func TestUserTrades(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:              trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter:   &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:          UserDBCache{R: testutil.MockRedis(t)},
+			TradesPageSize: 8}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+
+		// full history is kept and paged through (most recent first)
+		ti := time.Date(2020, 04, 15, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 20; i++ {
+			if err := udb.recordTradeHistory(ctx, "testuser", TradeRecord{
+				Date: ti.Add(time.Second * time.Duration(i)),
+				Size: Amount{Units: int64(i)}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var sizes []int64
+		var pages int
+		for token := ""; pages == 0 || token != ""; pages++ {
+			trades, next, err := udb.UserTrades(ctx, "testuser", token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, tr := range trades {
+				sizes = append(sizes, tr.Size.Units)
+			}
+			token = next
+		}
+		if pages != 3 {
+			t.Fatalf("expected 3 pages, got %d", pages)
+		}
+		var expected []int64
+		for i := 19; i >= 0; i-- {
+			expected = append(expected, int64(i))
+		}
+		if diff := cmp.Diff(expected, sizes); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// first page is served from the cache until invalidated
+		if err := udb.recordTradeHistory(ctx, "testuser", TradeRecord{
+			Date: ti.Add(time.Minute), Size: Amount{Units: 100}}); err != nil {
+			t.Fatal(err)
+		}
+		first, next, err := udb.UserTrades(ctx, "testuser", "")
+		if err != nil {
+			t.Fatal(err)
+		} else if len(first) != 8 || first[0].Size.Units != 19 || next == "" {
+			t.Fatalf("expected cached first page, got: %v (next=%q)", first, next)
+		}
+		if err := udb.Cache.InvalidateTrades(ctx, "testuser"); err != nil {
+			t.Fatal(err)
+		}
+		first, _, err = udb.UserTrades(ctx, "testuser", "")
+		if err != nil {
+			t.Fatal(err)
+		} else if first[0].Size.Units != 100 {
+			t.Fatalf("expected new trade on first page, got: %v", first[0])
+		}
+	})
+}
+
+func TestTradeHistory(t *testing.T) {
+	forEachStore(t, func(t *testing.T, db Store) {
+		ctx := context.Background()
+		udb := &UserDB{DB: db,
+			T:            trace.NewNoopTracerProvider().Tracer(""),
+			TradeCounter: &tradecounters.TradeCounter{DB: testutil.MockRedis(t)},
+			Cache:        MockProfileCache{}}
+		tu := testUser{id: "testuser", name: "abc"}
+		if _, err := udb.EnsureAccountExists(ctx, tu); err != nil {
+			t.Fatal(err)
+		}
+
+		ti := time.Date(2020, 04, 15, 0, 0, 0, 0, time.UTC)
+		var all []TradeRecord
+		for i := 0; i < 10; i++ {
+			ticker := "BTC"
+			if i%2 == 1 {
+				ticker = "ETH"
+			}
+			tr := TradeRecord{Date: ti.Add(time.Second * time.Duration(i)), Ticker: ticker, Size: Amount{Units: int64(i)}}
+			if err := udb.recordTradeHistory(ctx, "testuser", tr); err != nil {
+				t.Fatal(err)
+			}
+			all = append([]TradeRecord{tr}, all...) // most recent first
+		}
+
+		var got []TradeRecord
+		var token string
+		for {
+			page, next, err := udb.TradeHistory(ctx, "testuser", TradeFilter{}, 3, token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, page...)
+			if next == "" {
+				break
+			}
+			token = next
+		}
+		if diff := cmp.Diff(all, got); diff != "" {
+			t.Fatal(diff)
+		}
+
+		got, _, err := udb.TradeHistory(ctx, "testuser", TradeFilter{
+			Ticker: "BTC",
+			Since:  ti.Add(time.Second * 2),
+			Until:  ti.Add(time.Second * 8),
+		}, 10, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []TradeRecord{
+			{Date: ti.Add(6 * time.Second), Ticker: "BTC", Size: Amount{Units: 6}},
+			{Date: ti.Add(4 * time.Second), Ticker: "BTC", Size: Amount{Units: 4}},
+			{Date: ti.Add(2 * time.Second), Ticker: "BTC", Size: Amount{Units: 2}},
+		}
+		if diff := cmp.Diff(expected, got); diff != "" {
+			t.Fatal(diff)
+		}
+	})
 }
 
 func TestAmount_IsNegative(t *testing.T) {
@@ -408,3 +641,35 @@ func Test_batchDeleteAll(t *testing.T) {
 		t.Fatalf("was not expecting results: got %d", len(docs))
 	}
 }
+
+func Test_pageToken(t *testing.T) {
+	ts := time.Date(2050, 1, 1, 10, 0, 0, 123, time.UTC)
+	gotT, gotID, err := decodePageToken(encodePageToken(ts, "a/b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotT.Equal(ts) || gotID != "a/b" {
+		t.Fatalf("got (%v, %s)", gotT, gotID)
+	}
+	for _, v := range []string{"invalid", "!", base64.RawURLEncoding.EncodeToString([]byte("x/y"))} {
+		if _, _, err := decodePageToken(v); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("decodePageToken(%q): expected InvalidArgument, got: %v", v, err)
+		}
+	}
+}
+
+// forEachStore runs the test against each Store implementation.
+func forEachStore(t *testing.T, f func(t *testing.T, db Store)) {
+	t.Run("firestore", func(t *testing.T) {
+		f(t, &FirestoreStore{DB: firestoreutil.StartTestEmulator(t, context.Background())})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		ctx := context.Background()
+		p := &PostgresStore{DB: pgutil.StartTestPostgres(t, ctx)}
+		if err := p.Migrate(ctx); err != nil {
+			t.Fatal(err)
+		}
+		f(t, p)
+	})
+	t.Run("memory", func(t *testing.T) { f(t, NewMemStore()) })
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 Ahmet Alp Balkan
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ValuationResolution identifies a valuation history of a user. Hourly
+// valuations are recorded by the cron job, and rolled up into the daily and
+// weekly valuations as they age (see RollupUserValuationHistory).
+type ValuationResolution string
+
+const (
+	HourlyValuations ValuationResolution = "hourly"
+	DailyValuations  ValuationResolution = "daily"
+	WeeklyValuations ValuationResolution = "weekly"
+)
+
+const (
+	day  = time.Hour * 24
+	week = day * 7 // weeks start on Monday, as the zero time.Time did
+)
+
+// ValuationRange is the period of the valuation history to retrieve.
+type ValuationRange string
+
+const (
+	// ValuationRangeMonth is the hourly valuations that are not rolled up
+	// yet (the last month). It is the default range.
+	ValuationRangeMonth ValuationRange = "1m"
+	// ValuationRangeYear is the daily valuations of the last year, followed
+	// by the hourly valuations.
+	ValuationRangeYear ValuationRange = "1y"
+	// ValuationRangeAll is the weekly valuations since the user signed up,
+	// followed by the daily valuations of the last year and the hourly
+	// valuations.
+	ValuationRangeAll ValuationRange = "all"
+)
+
+// ParseValuationRange parses the range, empty string being the default
+// range. Fails with InvalidArgument for unknown ranges.
+func ParseValuationRange(s string) (ValuationRange, error) {
+	switch r := ValuationRange(s); r {
+	case "":
+		return ValuationRangeMonth, nil
+	case ValuationRangeMonth, ValuationRangeYear, ValuationRangeAll:
+		return r, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "unknown valuation range %q", s)
+}
+
+// valuations returns the valuation history of the range, with the coarser
+// valuations preceding the finer ones.
+func (u *UserDB) valuations(ctx context.Context, uid string, rng ValuationRange, now time.Time) ([]ValuationHistory, error) {
+	out, err := u.DB.Valuations(ctx, uid, HourlyValuations, time.Time{})
+	if err != nil || rng == ValuationRangeMonth {
+		return out, err
+	}
+	daily, err := u.DB.Valuations(ctx, uid, DailyValuations, now.Add(-day*365))
+	if err != nil {
+		return nil, err
+	}
+	out = stitchValuations(daily, out)
+	if rng == ValuationRangeYear {
+		return out, nil
+	}
+	weekly, err := u.DB.Valuations(ctx, uid, WeeklyValuations, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return stitchValuations(weekly, out), nil
+}
+
+// stitchValuations returns the coarse valuations preceding the fine ones,
+// followed by the fine valuations. Both are in ascending order.
+func stitchValuations(coarse, fine []ValuationHistory) []ValuationHistory {
+	if len(fine) == 0 {
+		return coarse
+	}
+	var out []ValuationHistory
+	for _, v := range coarse {
+		if !v.Date.Before(fine[0].Date) {
+			break
+		}
+		out = append(out, v)
+	}
+	return append(out, fine...)
+}
+
+// RollupUserValuationHistory compacts the user's hourly valuations of the days
+// before the specified time into daily valuations, and the daily valuations
+// of the weeks before it into weekly valuations. The rolled up hourly
+// valuations are deleted, the daily and weekly valuations are kept
+// indefinitely. Only whole days (and weeks) in UTC are rolled up, so it can
+// be invoked repeatedly, and resumes the rollups that failed halfway.
+func (u *UserDB) RollupUserValuationHistory(ctx context.Context, uid string, before time.Time) error {
+	ctx, s := u.T.Start(ctx, "rollup valuation history")
+	defer s.End()
+
+	dayCutoff := before.UTC().Truncate(day)
+	hourly, err := u.DB.Valuations(ctx, uid, HourlyValuations, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to read hourly valuations: %w", err)
+	}
+	for _, v := range downsampleValuations(hourly, day, dayCutoff) {
+		if err := u.DB.AddValuation(ctx, uid, DailyValuations, v); err != nil {
+			return fmt.Errorf("failed to save daily valuation: %w", err)
+		}
+	}
+
+	weekly, err := u.DB.Valuations(ctx, uid, WeeklyValuations, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to read weekly valuations: %w", err)
+	}
+	var since time.Time
+	if len(weekly) > 0 {
+		since = weekly[len(weekly)-1].Date.Add(week)
+	}
+	daily, err := u.DB.Valuations(ctx, uid, DailyValuations, since)
+	if err != nil {
+		return fmt.Errorf("failed to read daily valuations: %w", err)
+	}
+	for _, v := range downsampleValuations(daily, week, dayCutoff.Truncate(week)) {
+		if err := u.DB.AddValuation(ctx, uid, WeeklyValuations, v); err != nil {
+			return fmt.Errorf("failed to save weekly valuation: %w", err)
+		}
+	}
+
+	if err := u.DB.DeleteValuations(ctx, uid, HourlyValuations, dayCutoff); err != nil {
+		return fmt.Errorf("failed to delete rolled up hourly valuations: %w", err)
+	}
+	return nil
+}
+
+// downsampleValuations returns the last valuation of each interval of the
+// period (aligned in UTC) before the cutoff, dated at the start of the
+// interval. vals must be in ascending order.
+func downsampleValuations(vals []ValuationHistory, period time.Duration, cutoff time.Time) []ValuationHistory {
+	var out []ValuationHistory
+	for _, v := range vals {
+		if !v.Date.Before(cutoff) {
+			break
+		}
+		t := v.Date.UTC().Truncate(period)
+		if len(out) > 0 && out[len(out)-1].Date.Equal(t) {
+			out[len(out)-1].Value = v.Value
+			continue
+		}
+		out = append(out, ValuationHistory{Date: t, Value: v.Value})
+	}
+	return out
+}